@@ -0,0 +1,317 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: follow/v1/follow.proto
+
+package followv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FollowService_Follow_FullMethodName          = "/follow.v1.FollowService/Follow"
+	FollowService_CancelFollow_FullMethodName    = "/follow.v1.FollowService/CancelFollow"
+	FollowService_GetFollowee_FullMethodName     = "/follow.v1.FollowService/GetFollowee"
+	FollowService_GetFollower_FullMethodName     = "/follow.v1.FollowService/GetFollower"
+	FollowService_FollowInfo_FullMethodName      = "/follow.v1.FollowService/FollowInfo"
+	FollowService_GetFollowStatic_FullMethodName = "/follow.v1.FollowService/GetFollowStatic"
+)
+
+// FollowServiceClient is the client API for FollowService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FollowServiceClient interface {
+	Follow(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowResponse, error)
+	CancelFollow(ctx context.Context, in *CancelFollowRequest, opts ...grpc.CallOption) (*CancelFollowResponse, error)
+	// GetFollowee follower 关注了哪些人，按关注时间倒序
+	GetFollowee(ctx context.Context, in *GetFolloweeRequest, opts ...grpc.CallOption) (*GetFolloweeResponse, error)
+	// GetFollower 哪些人关注了 followee，按关注时间倒序
+	GetFollower(ctx context.Context, in *GetFollowerRequest, opts ...grpc.CallOption) (*GetFollowerResponse, error)
+	// FollowInfo follower 是否关注了 followee
+	FollowInfo(ctx context.Context, in *FollowInfoRequest, opts ...grpc.CallOption) (*FollowInfoResponse, error)
+	GetFollowStatic(ctx context.Context, in *GetFollowStaticRequest, opts ...grpc.CallOption) (*GetFollowStaticResponse, error)
+}
+
+type followServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFollowServiceClient(cc grpc.ClientConnInterface) FollowServiceClient {
+	return &followServiceClient{cc}
+}
+
+func (c *followServiceClient) Follow(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowResponse)
+	err := c.cc.Invoke(ctx, FollowService_Follow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) CancelFollow(ctx context.Context, in *CancelFollowRequest, opts ...grpc.CallOption) (*CancelFollowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelFollowResponse)
+	err := c.cc.Invoke(ctx, FollowService_CancelFollow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetFollowee(ctx context.Context, in *GetFolloweeRequest, opts ...grpc.CallOption) (*GetFolloweeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFolloweeResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetFollowee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetFollower(ctx context.Context, in *GetFollowerRequest, opts ...grpc.CallOption) (*GetFollowerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowerResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetFollower_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) FollowInfo(ctx context.Context, in *FollowInfoRequest, opts ...grpc.CallOption) (*FollowInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowInfoResponse)
+	err := c.cc.Invoke(ctx, FollowService_FollowInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetFollowStatic(ctx context.Context, in *GetFollowStaticRequest, opts ...grpc.CallOption) (*GetFollowStaticResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowStaticResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetFollowStatic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FollowServiceServer is the server API for FollowService service.
+// All implementations must embed UnimplementedFollowServiceServer
+// for forward compatibility.
+type FollowServiceServer interface {
+	Follow(context.Context, *FollowRequest) (*FollowResponse, error)
+	CancelFollow(context.Context, *CancelFollowRequest) (*CancelFollowResponse, error)
+	// GetFollowee follower 关注了哪些人，按关注时间倒序
+	GetFollowee(context.Context, *GetFolloweeRequest) (*GetFolloweeResponse, error)
+	// GetFollower 哪些人关注了 followee，按关注时间倒序
+	GetFollower(context.Context, *GetFollowerRequest) (*GetFollowerResponse, error)
+	// FollowInfo follower 是否关注了 followee
+	FollowInfo(context.Context, *FollowInfoRequest) (*FollowInfoResponse, error)
+	GetFollowStatic(context.Context, *GetFollowStaticRequest) (*GetFollowStaticResponse, error)
+	mustEmbedUnimplementedFollowServiceServer()
+}
+
+// UnimplementedFollowServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFollowServiceServer struct{}
+
+func (UnimplementedFollowServiceServer) Follow(context.Context, *FollowRequest) (*FollowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Follow not implemented")
+}
+func (UnimplementedFollowServiceServer) CancelFollow(context.Context, *CancelFollowRequest) (*CancelFollowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelFollow not implemented")
+}
+func (UnimplementedFollowServiceServer) GetFollowee(context.Context, *GetFolloweeRequest) (*GetFolloweeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowee not implemented")
+}
+func (UnimplementedFollowServiceServer) GetFollower(context.Context, *GetFollowerRequest) (*GetFollowerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollower not implemented")
+}
+func (UnimplementedFollowServiceServer) FollowInfo(context.Context, *FollowInfoRequest) (*FollowInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowInfo not implemented")
+}
+func (UnimplementedFollowServiceServer) GetFollowStatic(context.Context, *GetFollowStaticRequest) (*GetFollowStaticResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowStatic not implemented")
+}
+func (UnimplementedFollowServiceServer) mustEmbedUnimplementedFollowServiceServer() {}
+func (UnimplementedFollowServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeFollowServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FollowServiceServer will
+// result in compilation errors.
+type UnsafeFollowServiceServer interface {
+	mustEmbedUnimplementedFollowServiceServer()
+}
+
+func RegisterFollowServiceServer(s grpc.ServiceRegistrar, srv FollowServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFollowServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FollowService_ServiceDesc, srv)
+}
+
+func _FollowService_Follow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).Follow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_Follow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).Follow(ctx, req.(*FollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_CancelFollow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelFollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).CancelFollow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_CancelFollow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).CancelFollow(ctx, req.(*CancelFollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetFollowee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFolloweeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollowee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollowee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollowee(ctx, req.(*GetFolloweeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetFollower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollower_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollower(ctx, req.(*GetFollowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_FollowInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).FollowInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_FollowInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).FollowInfo(ctx, req.(*FollowInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetFollowStatic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowStaticRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollowStatic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollowStatic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollowStatic(ctx, req.(*GetFollowStaticRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FollowService_ServiceDesc is the grpc.ServiceDesc for FollowService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FollowService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "follow.v1.FollowService",
+	HandlerType: (*FollowServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Follow",
+			Handler:    _FollowService_Follow_Handler,
+		},
+		{
+			MethodName: "CancelFollow",
+			Handler:    _FollowService_CancelFollow_Handler,
+		},
+		{
+			MethodName: "GetFollowee",
+			Handler:    _FollowService_GetFollowee_Handler,
+		},
+		{
+			MethodName: "GetFollower",
+			Handler:    _FollowService_GetFollower_Handler,
+		},
+		{
+			MethodName: "FollowInfo",
+			Handler:    _FollowService_FollowInfo_Handler,
+		},
+		{
+			MethodName: "GetFollowStatic",
+			Handler:    _FollowService_GetFollowStatic_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "follow/v1/follow.proto",
+}
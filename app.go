@@ -3,6 +3,8 @@ package main
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/misakimei123/redbook/interactive/events"
+	"github.com/misakimei123/redbook/internal/job"
+	"github.com/misakimei123/redbook/pkg/outbox"
 	"github.com/robfig/cron/v3"
 )
 
@@ -10,4 +12,7 @@ type App struct {
 	server    *gin.Engine
 	consumers []events.Consumer
 	cron      *cron.Cron
+	scheduler *job.Scheduler
+	relay     *outbox.Relay
+	writer    *outbox.BatchWriter
 }
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/IBM/sarama"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/misakimei123/redbook/pkg/saramax"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+)
+
+// 把 <topic>.dlq 里的消息重新投递回 <topic>
+// go run ./cmd/dlqreplay --addr localhost:9094 --topic article_read
+func main() {
+	addrs := pflag.StringSlice("addr", []string{"localhost:9094"}, "kafka addr")
+	topic := pflag.String("topic", "", "source topic, replay from <topic>.dlq")
+	group := pflag.String("group", "dlq_replay", "group for recording replay offset")
+	pflag.Parse()
+	if *topic == "" {
+		pflag.Usage()
+		os.Exit(1)
+	}
+
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	cfg.Producer.Return.Errors = true
+	client, err := sarama.NewClient(*addrs, cfg)
+	if err != nil {
+		panic(err)
+	}
+	defer client.Close()
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		panic(err)
+	}
+	defer producer.Close()
+
+	zl, err := zap.NewDevelopment()
+	if err != nil {
+		panic(err)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	r := saramax.NewReplayer(client, producer, *group, logger.NewZapLogger(zl))
+	cnt, err := r.Replay(ctx, saramax.DLQTopic(*topic))
+	fmt.Printf("replayed %d messages from %s\n", cnt, saramax.DLQTopic(*topic))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
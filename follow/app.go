@@ -0,0 +1,7 @@
+package main
+
+import "github.com/misakimei123/redbook/pkg/grpcx"
+
+type App struct {
+	server *grpcx.Server
+}
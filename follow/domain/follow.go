@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// FollowRelation Follower 关注了 Followee
+type FollowRelation struct {
+	Id       int64
+	Follower int64
+	Followee int64
+	Ctime    time.Time
+}
+
+// FollowStatic 一个用户的粉丝数和关注数
+type FollowStatic struct {
+	Followers int64 `json:"followers"`
+	Followees int64 `json:"followees"`
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	followv1 "github.com/misakimei123/redbook/api/proto/gen/follow/v1"
+	"github.com/misakimei123/redbook/follow/domain"
+	"github.com/misakimei123/redbook/follow/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxListLimit 关注列表一页最多返回多少条，关注流按批推送的时候一批是 500
+const maxListLimit = 1000
+
+type FollowServiceServer struct {
+	followv1.UnimplementedFollowServiceServer
+	svc service.FollowService
+}
+
+func NewFollowServiceServer(svc service.FollowService) *FollowServiceServer {
+	return &FollowServiceServer{svc: svc}
+}
+
+func (f *FollowServiceServer) Register(server *grpc.Server) {
+	followv1.RegisterFollowServiceServer(server, f)
+}
+
+func (f *FollowServiceServer) Follow(ctx context.Context, request *followv1.FollowRequest) (*followv1.FollowResponse, error) {
+	err := f.svc.Follow(ctx, request.GetFollower(), request.GetFollowee())
+	if errors.Is(err, service.ErrFollowSelf) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &followv1.FollowResponse{}, err
+}
+
+func (f *FollowServiceServer) CancelFollow(ctx context.Context, request *followv1.CancelFollowRequest) (*followv1.CancelFollowResponse, error) {
+	err := f.svc.CancelFollow(ctx, request.GetFollower(), request.GetFollowee())
+	return &followv1.CancelFollowResponse{}, err
+}
+
+func (f *FollowServiceServer) GetFollowee(ctx context.Context, request *followv1.GetFolloweeRequest) (*followv1.GetFolloweeResponse, error) {
+	if err := checkLimit(request.GetLimit()); err != nil {
+		return nil, err
+	}
+	rels, err := f.svc.GetFollowee(ctx, request.GetFollower(), request.GetLastId(), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.GetFolloweeResponse{FollowRelations: f.toDTOs(rels)}, nil
+}
+
+func (f *FollowServiceServer) GetFollower(ctx context.Context, request *followv1.GetFollowerRequest) (*followv1.GetFollowerResponse, error) {
+	if err := checkLimit(request.GetLimit()); err != nil {
+		return nil, err
+	}
+	rels, err := f.svc.GetFollower(ctx, request.GetFollowee(), request.GetLastId(), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.GetFollowerResponse{FollowRelations: f.toDTOs(rels)}, nil
+}
+
+func (f *FollowServiceServer) FollowInfo(ctx context.Context, request *followv1.FollowInfoRequest) (*followv1.FollowInfoResponse, error) {
+	followed, err := f.svc.Followed(ctx, request.GetFollower(), request.GetFollowee())
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.FollowInfoResponse{Followed: followed}, nil
+}
+
+func (f *FollowServiceServer) GetFollowStatic(ctx context.Context, request *followv1.GetFollowStaticRequest) (*followv1.GetFollowStaticResponse, error) {
+	static, err := f.svc.GetFollowStatic(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.GetFollowStaticResponse{FollowStatic: &followv1.FollowStatic{
+		Followers: static.Followers,
+		Followees: static.Followees,
+	}}, nil
+}
+
+func (f *FollowServiceServer) toDTOs(rels []domain.FollowRelation) []*followv1.FollowRelation {
+	return slice.Map(rels, func(idx int, src domain.FollowRelation) *followv1.FollowRelation {
+		return &followv1.FollowRelation{
+			Id:       src.Id,
+			Follower: src.Follower,
+			Followee: src.Followee,
+			Ctime:    src.Ctime.UnixMilli(),
+		}
+	})
+}
+
+func checkLimit(limit int64) error {
+	if limit <= 0 || limit > maxListLimit {
+		return status.Errorf(codes.InvalidArgument, "limit must be in (0, %d]", maxListLimit)
+	}
+	return nil
+}
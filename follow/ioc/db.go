@@ -0,0 +1,46 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/misakimei123/redbook/follow/repository/dao"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/spf13/viper"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	glogger "gorm.io/gorm/logger"
+)
+
+func InitDB(l logger.LoggerV1) *gorm.DB {
+	type Config struct {
+		DSN string `yaml:"dsn"`
+	}
+	var c Config
+	err := viper.UnmarshalKey("db", &c)
+	if err != nil {
+		panic(fmt.Errorf("read config db fail %s", err))
+	}
+	db, err := gorm.Open(mysql.Open(c.DSN), &gorm.Config{
+		Logger: glogger.New(gormLoggerFunc(l.Debug), glogger.Config{
+			SlowThreshold: 0,
+			LogLevel:      glogger.Info,
+		}),
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = dao.InitTables(db)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+type gormLoggerFunc func(msg string, fields ...logger.Field)
+
+func (g gormLoggerFunc) Printf(s string, i ...interface{}) {
+	g(s, logger.Field{
+		Key: "args",
+		Val: i,
+	})
+}
@@ -0,0 +1,31 @@
+package ioc
+
+import (
+	grpc2 "github.com/misakimei123/redbook/follow/grpc"
+	"github.com/misakimei123/redbook/pkg/grpcx"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+func InitGrpcxServer(followSvc *grpc2.FollowServiceServer, l logger.LoggerV1) *grpcx.Server {
+	type Config struct {
+		EtcdAddr string `yaml:"etcdAddr"`
+		Port     int    `yaml:"port"`
+		Name     string `yaml:"name"`
+	}
+	s := grpc.NewServer()
+	followSvc.Register(s)
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.server", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return &grpcx.Server{
+		Server:   s,
+		EtcdAddr: cfg.EtcdAddr,
+		Port:     cfg.Port,
+		Name:     cfg.Name,
+		L:        l,
+	}
+}
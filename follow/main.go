@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func main() {
+	initViper()
+	app := InitApp()
+	err := app.server.Serve()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func initViper() {
+	cfile := pflag.String("config", "config/dev.yaml", "config file path")
+	pflag.Parse()
+	viper.SetConfigType("yaml")
+	viper.SetConfigFile(*cfile)
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("read config fail %s", err))
+	}
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/misakimei123/redbook/follow/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+type FollowCache interface {
+	GetStatic(ctx context.Context, uid int64) (domain.FollowStatic, error)
+	SetStatic(ctx context.Context, uid int64, static domain.FollowStatic) error
+	// DelStatic 关注关系变了之后两边的计数都要删
+	DelStatic(ctx context.Context, uids ...int64) error
+}
+
+type RedisFollowCache struct {
+	cmd        redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisFollowCache(cmd redis.Cmdable) FollowCache {
+	return &RedisFollowCache{
+		cmd:        cmd,
+		expiration: time.Minute * 15,
+	}
+}
+
+func (c *RedisFollowCache) GetStatic(ctx context.Context, uid int64) (domain.FollowStatic, error) {
+	data, err := c.cmd.Get(ctx, c.staticKey(uid)).Bytes()
+	if err != nil {
+		return domain.FollowStatic{}, err
+	}
+	var static domain.FollowStatic
+	err = json.Unmarshal(data, &static)
+	return static, err
+}
+
+func (c *RedisFollowCache) SetStatic(ctx context.Context, uid int64, static domain.FollowStatic) error {
+	data, err := json.Marshal(static)
+	if err != nil {
+		return err
+	}
+	return c.cmd.Set(ctx, c.staticKey(uid), data, c.expiration).Err()
+}
+
+func (c *RedisFollowCache) DelStatic(ctx context.Context, uids ...int64) error {
+	keys := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		keys = append(keys, c.staticKey(uid))
+	}
+	return c.cmd.Del(ctx, keys...).Err()
+}
+
+func (c *RedisFollowCache) staticKey(uid int64) string {
+	return fmt.Sprintf("follow:static:%d", uid)
+}
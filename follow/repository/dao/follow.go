@@ -0,0 +1,182 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrRecordNotFound = gorm.ErrRecordNotFound
+	// ErrFollowSelf 不能关注自己
+	ErrFollowSelf = errors.New("can not follow yourself")
+)
+
+const (
+	followStatusInactive uint8 = iota
+	followStatusActive
+)
+
+// FollowRelation 取消关注只改状态，不删数据
+type FollowRelation struct {
+	Id       int64 `gorm:"primaryKey, autoIncrement"`
+	Follower int64 `gorm:"uniqueIndex:follower_followee"`
+	Followee int64 `gorm:"uniqueIndex:follower_followee;index"`
+	Status   uint8
+	Ctime    int64
+	Utime    int64
+}
+
+// FollowStatic 冗余的计数，和关系在同一个事务里面更新
+type FollowStatic struct {
+	Id        int64 `gorm:"primaryKey, autoIncrement"`
+	Uid       int64 `gorm:"unique"`
+	Followers int64
+	Followees int64
+	Ctime     int64
+	Utime     int64
+}
+
+type FollowDao interface {
+	// Follow 已经关注了返回 false
+	Follow(ctx context.Context, follower, followee int64) (bool, error)
+	// CancelFollow 本来就没有关注返回 false
+	CancelFollow(ctx context.Context, follower, followee int64) (bool, error)
+	// FolloweeList 按关注时间 (utime, id) 倒序，重新关注的排在前面，lastId 是上一页最后一条的 id，为 0 表示第一页
+	FolloweeList(ctx context.Context, follower int64, lastId int64, limit int) ([]FollowRelation, error)
+	FollowerList(ctx context.Context, followee int64, lastId int64, limit int) ([]FollowRelation, error)
+	FollowRelationDetail(ctx context.Context, follower, followee int64) (FollowRelation, error)
+	// GetStatic 没有数据的时候返回零值
+	GetStatic(ctx context.Context, uid int64) (FollowStatic, error)
+}
+
+type GormFollowDao struct {
+	db *gorm.DB
+}
+
+func NewGormFollowDao(db *gorm.DB) FollowDao {
+	return &GormFollowDao{db: db}
+}
+
+func (g *GormFollowDao) Follow(ctx context.Context, follower, followee int64) (bool, error) {
+	if follower == followee {
+		return false, ErrFollowSelf
+	}
+	now := time.Now().UnixMilli()
+	changed := false
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 并发的第一次关注也不会撞唯一索引。已经关注了就什么都不改，影响行数是 0；
+		// status 要放在最后赋值，前面的 IF 看到的才是原来的状态
+		res := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "ctime"}, Value: gorm.Expr("IF(`status` = ?, `ctime`, ?)", followStatusActive, now)},
+				{Column: clause.Column{Name: "utime"}, Value: gorm.Expr("IF(`status` = ?, `utime`, ?)", followStatusActive, now)},
+				{Column: clause.Column{Name: "status"}, Value: followStatusActive},
+			},
+		}).Create(&FollowRelation{
+			Follower: follower,
+			Followee: followee,
+			Status:   followStatusActive,
+			Ctime:    now,
+			Utime:    now,
+		})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		changed = true
+		return g.incrStatic(tx, follower, followee, 1, now)
+	})
+	return changed, err
+}
+
+func (g *GormFollowDao) CancelFollow(ctx context.Context, follower, followee int64) (bool, error) {
+	now := time.Now().UnixMilli()
+	changed := false
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&FollowRelation{}).
+			Where("follower = ? AND followee = ? AND status = ?", follower, followee, followStatusActive).
+			Updates(map[string]any{
+				"status": followStatusInactive,
+				"utime":  now,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		changed = true
+		return g.incrStatic(tx, follower, followee, -1, now)
+	})
+	return changed, err
+}
+
+// incrStatic follower 的关注数和 followee 的粉丝数一起变
+func (g *GormFollowDao) incrStatic(tx *gorm.DB, follower, followee int64, delta int64, now int64) error {
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"followees": gorm.Expr("`followees` + ?", delta),
+			"utime":     now,
+		}),
+	}).Create(&FollowStatic{
+		Uid:       follower,
+		Followees: max(delta, 0),
+		Ctime:     now,
+		Utime:     now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"followers": gorm.Expr("`followers` + ?", delta),
+			"utime":     now,
+		}),
+	}).Create(&FollowStatic{
+		Uid:       followee,
+		Followers: max(delta, 0),
+		Ctime:     now,
+		Utime:     now,
+	}).Error
+}
+
+func (g *GormFollowDao) FolloweeList(ctx context.Context, follower int64, lastId int64, limit int) ([]FollowRelation, error) {
+	return g.list(ctx, "follower", follower, lastId, limit)
+}
+
+func (g *GormFollowDao) FollowerList(ctx context.Context, followee int64, lastId int64, limit int) ([]FollowRelation, error) {
+	return g.list(ctx, "followee", followee, lastId, limit)
+}
+
+// list 重新关注不会换 id，所以按 utime 排序，游标只给了 id，先查出它的 utime
+func (g *GormFollowDao) list(ctx context.Context, column string, uid int64, lastId int64, limit int) ([]FollowRelation, error) {
+	var res []FollowRelation
+	db := g.db.WithContext(ctx).Where(column+" = ? AND status = ?", uid, followStatusActive)
+	if lastId > 0 {
+		var last FollowRelation
+		err := g.db.WithContext(ctx).Select("id", "utime").Where("id = ?", lastId).First(&last).Error
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("(utime < ? OR (utime = ? AND id < ?))", last.Utime, last.Utime, last.Id)
+	}
+	err := db.Order("utime DESC, id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormFollowDao) FollowRelationDetail(ctx context.Context, follower, followee int64) (FollowRelation, error) {
+	var rel FollowRelation
+	err := g.db.WithContext(ctx).
+		Where("follower = ? AND followee = ? AND status = ?", follower, followee, followStatusActive).
+		First(&rel).Error
+	return rel, err
+}
+
+func (g *GormFollowDao) GetStatic(ctx context.Context, uid int64) (FollowStatic, error) {
+	var static FollowStatic
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).First(&static).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return FollowStatic{Uid: uid}, nil
+	}
+	return static, err
+}
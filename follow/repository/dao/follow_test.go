@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGormFollowDao_Follow(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sqlmock     func(t *testing.T) *sql.DB
+		follower    int64
+		followee    int64
+		wantChanged bool
+		wantErr     error
+	}{
+		{
+			name: "follow yourself",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, _, err := sqlmock.New()
+				assert.NoError(t, err)
+				return db
+			},
+			follower: 1,
+			followee: 1,
+			wantErr:  ErrFollowSelf,
+		},
+		{
+			name: "first follow",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `follow_relations` .*ON DUPLICATE KEY UPDATE `ctime`=IF.*,`utime`=IF.*,`status`=").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `follow_statics` .*ON DUPLICATE KEY UPDATE.*`followees`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `follow_statics` .*ON DUPLICATE KEY UPDATE.*`followers`").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			follower:    1,
+			followee:    2,
+			wantChanged: true,
+		},
+		{
+			name: "already followed",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				// 状态没变，影响行数是 0
+				mock.ExpectExec("INSERT INTO `follow_relations` .*ON DUPLICATE KEY UPDATE.*").
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectCommit()
+				return db
+			},
+			follower: 1,
+			followee: 2,
+		},
+		{
+			name: "follow again after cancel",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				// 更新了已有的行，影响行数是 2
+				mock.ExpectExec("INSERT INTO `follow_relations` .*ON DUPLICATE KEY UPDATE.*").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT INTO `follow_statics` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `follow_statics` .*").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			follower:    1,
+			followee:    2,
+			wantChanged: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      tc.sqlmock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				SkipDefaultTransaction: true,
+				DisableAutomaticPing:   true,
+			})
+			assert.NoError(t, err)
+			changed, err := NewGormFollowDao(db).Follow(context.Background(), tc.follower, tc.followee)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantChanged, changed)
+		})
+	}
+}
+
+// 重新关注不会换 id，翻页要按 utime 排，游标的 utime 从 lastId 查出来
+func TestGormFollowDao_FolloweeList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	mock.ExpectQuery("SELECT `id`,`utime` FROM `follow_relations` WHERE id = \\?").
+		WithArgs(10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(10, 200))
+	mock.ExpectQuery("SELECT \\* FROM `follow_relations` WHERE \\(follower = \\? AND status = \\?\\) "+
+		"AND \\(\\(utime < \\? OR \\(utime = \\? AND id < \\?\\)\\)\\) ORDER BY utime DESC, id DESC LIMIT \\?").
+		WithArgs(1, followStatusActive, 200, 200, 10, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "follower", "followee", "utime"}).
+			AddRow(3, 1, 4, 150).
+			AddRow(12, 1, 5, 100))
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	assert.NoError(t, err)
+	rels, err := NewGormFollowDao(gormDB).FolloweeList(context.Background(), 1, 10, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 12}, []int64{rels[0].Id, rels[1].Id})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
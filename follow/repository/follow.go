@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/misakimei123/redbook/follow/domain"
+	"github.com/misakimei123/redbook/follow/repository/cache"
+	"github.com/misakimei123/redbook/follow/repository/dao"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+var (
+	ErrFollowRelationNotFound = dao.ErrRecordNotFound
+	ErrFollowSelf             = dao.ErrFollowSelf
+)
+
+type FollowRepository interface {
+	AddFollowRelation(ctx context.Context, follower, followee int64) error
+	InactiveFollowRelation(ctx context.Context, follower, followee int64) error
+	GetFollowee(ctx context.Context, follower int64, lastId int64, limit int) ([]domain.FollowRelation, error)
+	GetFollower(ctx context.Context, followee int64, lastId int64, limit int) ([]domain.FollowRelation, error)
+	FollowInfo(ctx context.Context, follower, followee int64) (domain.FollowRelation, error)
+	GetFollowStatic(ctx context.Context, uid int64) (domain.FollowStatic, error)
+}
+
+type CachedFollowRepository struct {
+	dao   dao.FollowDao
+	cache cache.FollowCache
+	l     logger.LoggerV1
+}
+
+func NewCachedFollowRepository(dao dao.FollowDao, cache cache.FollowCache, l logger.LoggerV1) FollowRepository {
+	return &CachedFollowRepository{dao: dao, cache: cache, l: l}
+}
+
+func (c *CachedFollowRepository) AddFollowRelation(ctx context.Context, follower, followee int64) error {
+	changed, err := c.dao.Follow(ctx, follower, followee)
+	if err != nil || !changed {
+		return err
+	}
+	c.delStatic(ctx, follower, followee)
+	return nil
+}
+
+func (c *CachedFollowRepository) InactiveFollowRelation(ctx context.Context, follower, followee int64) error {
+	changed, err := c.dao.CancelFollow(ctx, follower, followee)
+	if err != nil || !changed {
+		return err
+	}
+	c.delStatic(ctx, follower, followee)
+	return nil
+}
+
+func (c *CachedFollowRepository) GetFollowee(ctx context.Context, follower int64, lastId int64, limit int) ([]domain.FollowRelation, error) {
+	rels, err := c.dao.FolloweeList(ctx, follower, lastId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rels, func(idx int, src dao.FollowRelation) domain.FollowRelation {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedFollowRepository) GetFollower(ctx context.Context, followee int64, lastId int64, limit int) ([]domain.FollowRelation, error) {
+	rels, err := c.dao.FollowerList(ctx, followee, lastId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rels, func(idx int, src dao.FollowRelation) domain.FollowRelation {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedFollowRepository) FollowInfo(ctx context.Context, follower, followee int64) (domain.FollowRelation, error) {
+	rel, err := c.dao.FollowRelationDetail(ctx, follower, followee)
+	if err != nil {
+		return domain.FollowRelation{}, err
+	}
+	return c.toDomain(rel), nil
+}
+
+func (c *CachedFollowRepository) GetFollowStatic(ctx context.Context, uid int64) (domain.FollowStatic, error) {
+	static, err := c.cache.GetStatic(ctx, uid)
+	if err == nil {
+		return static, nil
+	}
+	daoStatic, err := c.dao.GetStatic(ctx, uid)
+	if err != nil {
+		return domain.FollowStatic{}, err
+	}
+	static = domain.FollowStatic{
+		Followers: daoStatic.Followers,
+		Followees: daoStatic.Followees,
+	}
+	err = c.cache.SetStatic(ctx, uid, static)
+	if err != nil {
+		c.l.Error("set follow static cache fail", logger.Int64("uid", uid), logger.Error(err))
+	}
+	return static, nil
+}
+
+// delStatic 数据库已经提交了，删缓存失败只能等过期
+func (c *CachedFollowRepository) delStatic(ctx context.Context, uids ...int64) {
+	err := c.cache.DelStatic(ctx, uids...)
+	if err != nil {
+		c.l.Error("del follow static cache fail", logger.Error(err))
+	}
+}
+
+func (c *CachedFollowRepository) toDomain(rel dao.FollowRelation) domain.FollowRelation {
+	return domain.FollowRelation{
+		Id:       rel.Id,
+		Follower: rel.Follower,
+		Followee: rel.Followee,
+		Ctime:    time.UnixMilli(rel.Ctime),
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/misakimei123/redbook/follow/domain"
+	"github.com/misakimei123/redbook/follow/repository"
+)
+
+var ErrFollowSelf = repository.ErrFollowSelf
+
+//go:generate mockgen -source=./follow.go -package=svcmocks -destination=./mocks/follow.mock.go FollowService
+type FollowService interface {
+	Follow(ctx context.Context, follower, followee int64) error
+	CancelFollow(ctx context.Context, follower, followee int64) error
+	GetFollowee(ctx context.Context, follower int64, lastId int64, limit int) ([]domain.FollowRelation, error)
+	GetFollower(ctx context.Context, followee int64, lastId int64, limit int) ([]domain.FollowRelation, error)
+	Followed(ctx context.Context, follower, followee int64) (bool, error)
+	GetFollowStatic(ctx context.Context, uid int64) (domain.FollowStatic, error)
+}
+
+type followService struct {
+	repo repository.FollowRepository
+}
+
+func NewFollowService(repo repository.FollowRepository) FollowService {
+	return &followService{repo: repo}
+}
+
+func (f *followService) Follow(ctx context.Context, follower, followee int64) error {
+	return f.repo.AddFollowRelation(ctx, follower, followee)
+}
+
+func (f *followService) CancelFollow(ctx context.Context, follower, followee int64) error {
+	return f.repo.InactiveFollowRelation(ctx, follower, followee)
+}
+
+func (f *followService) GetFollowee(ctx context.Context, follower int64, lastId int64, limit int) ([]domain.FollowRelation, error) {
+	return f.repo.GetFollowee(ctx, follower, lastId, limit)
+}
+
+func (f *followService) GetFollower(ctx context.Context, followee int64, lastId int64, limit int) ([]domain.FollowRelation, error) {
+	return f.repo.GetFollower(ctx, followee, lastId, limit)
+}
+
+func (f *followService) Followed(ctx context.Context, follower, followee int64) (bool, error) {
+	_, err := f.repo.FollowInfo(ctx, follower, followee)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, repository.ErrFollowRelationNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (f *followService) GetFollowStatic(ctx context.Context, uid int64) (domain.FollowStatic, error) {
+	return f.repo.GetFollowStatic(ctx, uid)
+}
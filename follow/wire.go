@@ -0,0 +1,36 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"github.com/misakimei123/redbook/follow/grpc"
+	"github.com/misakimei123/redbook/follow/ioc"
+	"github.com/misakimei123/redbook/follow/repository"
+	"github.com/misakimei123/redbook/follow/repository/cache"
+	"github.com/misakimei123/redbook/follow/repository/dao"
+	"github.com/misakimei123/redbook/follow/service"
+)
+
+var (
+	thirdPartySet = wire.NewSet(
+		ioc.InitRedis, ioc.InitLogger, ioc.InitDB,
+	)
+	followSvcSet = wire.NewSet(
+		dao.NewGormFollowDao,
+		cache.NewRedisFollowCache,
+		repository.NewCachedFollowRepository,
+		service.NewFollowService,
+	)
+)
+
+func InitApp() *App {
+	wire.Build(
+		thirdPartySet,
+		followSvcSet,
+		grpc.NewFollowServiceServer,
+		ioc.InitGrpcxServer,
+		wire.Struct(new(App), "*"),
+	)
+	return new(App)
+}
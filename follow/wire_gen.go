@@ -0,0 +1,42 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"github.com/misakimei123/redbook/follow/grpc"
+	"github.com/misakimei123/redbook/follow/ioc"
+	"github.com/misakimei123/redbook/follow/repository"
+	"github.com/misakimei123/redbook/follow/repository/cache"
+	"github.com/misakimei123/redbook/follow/repository/dao"
+	"github.com/misakimei123/redbook/follow/service"
+)
+
+// Injectors from wire.go:
+
+func InitApp() *App {
+	loggerV1 := ioc.InitLogger()
+	db := ioc.InitDB(loggerV1)
+	followDao := dao.NewGormFollowDao(db)
+	cmdable := ioc.InitRedis()
+	followCache := cache.NewRedisFollowCache(cmdable)
+	followRepository := repository.NewCachedFollowRepository(followDao, followCache, loggerV1)
+	followService := service.NewFollowService(followRepository)
+	followServiceServer := grpc.NewFollowServiceServer(followService)
+	server := ioc.InitGrpcxServer(followServiceServer, loggerV1)
+	app := &App{
+		server: server,
+	}
+	return app
+}
+
+// wire.go:
+
+var (
+	thirdPartySet = wire.NewSet(ioc.InitRedis, ioc.InitLogger, ioc.InitDB)
+	followSvcSet  = wire.NewSet(dao.NewGormFollowDao, cache.NewRedisFollowCache, repository.NewCachedFollowRepository, service.NewFollowService)
+)
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Collection 收藏夹，Id 为 0 的是每个用户都有的默认收藏夹，不落库
+type Collection struct {
+	Id    int64
+	Uid   int64
+	Name  string
+	Ctime time.Time
+	Utime time.Time
+}
+
+// CollectionItem 收藏夹里面的一条收藏
+type CollectionItem struct {
+	Id    int64
+	Cid   int64
+	Biz   string
+	BizId int64
+	Ctime time.Time
+}
@@ -1,11 +1,14 @@
 package domain
 
 type Interactive struct {
-	Biz        string
-	BizId      int64 `json:"bizId,omitempty"`
-	ReadCnt    int64 `json:"readCnt,omitempty"`
+	Biz     string
+	BizId   int64 `json:"bizId,omitempty"`
+	ReadCnt int64 `json:"readCnt,omitempty"`
+	// UvCnt 读过的用户数
+	UvCnt      int64 `json:"uvCnt,omitempty"`
 	LikeCnt    int64 `json:"likeCnt,omitempty"`
 	CollectCnt int64 `json:"collectCnt,omitempty"`
+	CommentCnt int64 `json:"commentCnt,omitempty"`
 	Liked      bool  `json:"liked,omitempty"`
 	Collected  bool  `json:"collected,omitempty"`
 }
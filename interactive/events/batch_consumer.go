@@ -18,16 +18,20 @@ type InteractiveReadEventBatchConsumer struct {
 	bizStr string
 	l      logger.LoggerV1
 	vector *prometheus.GaugeVec
+	opts   []saramax.Option
+	// dedupWindow 同一个用户在这个时间内重复阅读只算一次，0 就是不去重
+	dedupWindow time.Duration
 }
 
 func NewInteractiveReadEventBatchConsumer(repo repository.InteractiveRepository, client sarama.Client,
 	l logger.LoggerV1,
 	opts prometheus.GaugeOpts,
-
+	dedupWindow time.Duration,
+	handlerOpts ...saramax.Option,
 ) *InteractiveReadEventBatchConsumer {
 	vec := prometheus.NewGaugeVec(opts, []string{"topic", "type"})
 	prometheus.MustRegister(vec)
-	return &InteractiveReadEventBatchConsumer{repo: repo, client: client, l: l, bizStr: "article", vector: vec}
+	return &InteractiveReadEventBatchConsumer{repo: repo, client: client, l: l, bizStr: "article", vector: vec, opts: handlerOpts, dedupWindow: dedupWindow}
 }
 
 func (c *InteractiveReadEventBatchConsumer) Start() error {
@@ -36,7 +40,7 @@ func (c *InteractiveReadEventBatchConsumer) Start() error {
 		return err
 	}
 	go func() {
-		er := cg.Consume(context.Background(), []string{TopicReadEvent}, saramax.NewBatchHandler[ReadEvent](c.l, c.Consume))
+		er := cg.Consume(context.Background(), []string{TopicReadEvent}, saramax.NewBatchHandler[ReadEvent](c.l, c.Consume, c.opts...))
 		if er != nil {
 			c.l.Error("consume fail", logger.Error(er))
 		}
@@ -50,14 +54,16 @@ func (c *InteractiveReadEventBatchConsumer) Consume(messages []*sarama.ConsumerM
 
 	ids := make([]int64, 0, len(events))
 	bizs := make([]string, 0, len(events))
+	uids := make([]int64, 0, len(events))
 
 	for _, event := range events {
 		ids = append(ids, event.Aid)
 		bizs = append(bizs, c.bizStr)
+		uids = append(uids, event.Uid)
 	}
 
 	defer func() {
 		c.vector.WithLabelValues(TopicReadEvent, "consumer").Add(float64(len(events)))
 	}()
-	return c.repo.BatchIncrReadCnt(ctx, bizs, ids)
+	return c.repo.BatchIncrReadCnt(ctx, bizs, ids, uids, c.dedupWindow)
 }
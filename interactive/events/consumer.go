@@ -22,11 +22,13 @@ type InteractiveReadEventConsumer struct {
 	client sarama.Client
 	bizStr string
 	l      logger.LoggerV1
+	// dedupWindow 同一个用户在这个时间内重复阅读只算一次，0 就是不去重
+	dedupWindow time.Duration
 }
 
 func NewInteractiveReadEventConsumer(repo repository.InteractiveRepository, client sarama.Client,
-	l logger.LoggerV1) *InteractiveReadEventConsumer {
-	return &InteractiveReadEventConsumer{repo: repo, client: client, l: l, bizStr: "article"}
+	l logger.LoggerV1, dedupWindow time.Duration) *InteractiveReadEventConsumer {
+	return &InteractiveReadEventConsumer{repo: repo, client: client, l: l, bizStr: "article", dedupWindow: dedupWindow}
 }
 
 func (c *InteractiveReadEventConsumer) Start() error {
@@ -46,5 +48,5 @@ func (c *InteractiveReadEventConsumer) Start() error {
 func (c *InteractiveReadEventConsumer) Consume(msg *sarama.ConsumerMessage, event ReadEvent) error {
 	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
 	defer cancelFunc()
-	return c.repo.IncrReadCnt(ctx, c.bizStr, event.Aid)
+	return c.repo.BatchIncrReadCnt(ctx, []string{c.bizStr}, []int64{event.Aid}, []int64{event.Uid}, c.dedupWindow)
 }
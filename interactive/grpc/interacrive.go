@@ -2,12 +2,16 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"github.com/ecodeclub/ekit/slice"
 	intrv1 "github.com/misakimei123/redbook/api/proto/gen/intr/v1"
 	"github.com/misakimei123/redbook/interactive/domain"
 	"github.com/misakimei123/redbook/interactive/service"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type InteractiveServiceServer struct {
@@ -35,7 +39,10 @@ func (i *InteractiveServiceServer) Like(ctx context.Context, request *intrv1.Lik
 
 func (i *InteractiveServiceServer) Collect(ctx context.Context, request *intrv1.CollectRequest) (*intrv1.CollectResponse, error) {
 	err := i.svc.Collect(ctx, request.GetBizStr(), request.GetBizId(), request.GetCid(), request.GetUid())
-	return &intrv1.CollectResponse{}, err
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.CollectResponse{}, nil
 }
 
 func (i *InteractiveServiceServer) Get(ctx context.Context, request *intrv1.GetRequest) (*intrv1.GetResponse, error) {
@@ -47,7 +54,7 @@ func (i *InteractiveServiceServer) Get(ctx context.Context, request *intrv1.GetR
 }
 
 func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *intrv1.GetByIdsRequest) (*intrv1.GetByIdsResponse, error) {
-	res, err := i.svc.GetByIds(ctx, request.GetBizStr(), request.GetIds())
+	res, err := i.svc.GetByIds(ctx, request.GetBizStr(), request.GetIds(), request.GetUid())
 	if err != nil {
 		return nil, err
 	}
@@ -59,13 +66,94 @@ func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *intrv1
 	return &intrv1.GetByIdsResponse{Interacs: intrs}, nil
 }
 
+func (i *InteractiveServiceServer) IncrCommentCnt(ctx context.Context, request *intrv1.IncrCommentCntRequest) (*intrv1.IncrCommentCntResponse, error) {
+	err := i.svc.IncrCommentCnt(ctx, request.GetBizStr(), request.GetBizId(), request.GetDelta())
+	return &intrv1.IncrCommentCntResponse{}, err
+}
+
+func (i *InteractiveServiceServer) Uncollect(ctx context.Context, request *intrv1.UncollectRequest) (*intrv1.UncollectResponse, error) {
+	err := i.svc.Uncollect(ctx, request.GetBizStr(), request.GetBizId(), request.GetUid())
+	return &intrv1.UncollectResponse{}, err
+}
+
+func (i *InteractiveServiceServer) CreateCollection(ctx context.Context, request *intrv1.CreateCollectionRequest) (*intrv1.CreateCollectionResponse, error) {
+	id, err := i.svc.CreateCollection(ctx, request.GetUid(), request.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (i *InteractiveServiceServer) RenameCollection(ctx context.Context, request *intrv1.RenameCollectionRequest) (*intrv1.RenameCollectionResponse, error) {
+	err := i.svc.RenameCollection(ctx, request.GetId(), request.GetUid(), request.GetName())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.RenameCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) DeleteCollection(ctx context.Context, request *intrv1.DeleteCollectionRequest) (*intrv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, request.GetId(), request.GetUid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.DeleteCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollections(ctx context.Context, request *intrv1.ListCollectionsRequest) (*intrv1.ListCollectionsResponse, error) {
+	collections, err := i.svc.ListCollections(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.ListCollectionsResponse{Collections: slice.Map(collections, func(idx int, src domain.Collection) *intrv1.Collection {
+		return &intrv1.Collection{
+			Id:    src.Id,
+			Uid:   src.Uid,
+			Name:  src.Name,
+			Ctime: src.Ctime.UnixMilli(),
+			Utime: src.Utime.UnixMilli(),
+		}
+	})}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollectionItems(ctx context.Context, request *intrv1.ListCollectionItemsRequest) (*intrv1.ListCollectionItemsResponse, error) {
+	items, err := i.svc.ListCollectionItems(ctx, request.GetUid(), request.GetCid(), request.GetLastId(), int(request.GetLimit()))
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.ListCollectionItemsResponse{Items: slice.Map(items, func(idx int, src domain.CollectionItem) *intrv1.CollectionItem {
+		return &intrv1.CollectionItem{
+			Id:     src.Id,
+			Cid:    src.Cid,
+			BizStr: src.Biz,
+			BizId:  src.BizId,
+			Ctime:  src.Ctime.UnixMilli(),
+		}
+	})}, nil
+}
+
+func (i *InteractiveServiceServer) Preload(ctx context.Context, request *intrv1.PreloadRequest) (*intrv1.PreloadResponse, error) {
+	err := i.svc.Preload(ctx, request.GetBizStr(), request.GetIds())
+	return &intrv1.PreloadResponse{}, err
+}
+
+// toStatus 收藏夹不存在转成 NotFound，调用方据此区分业务错误
+func (i *InteractiveServiceServer) toStatus(err error) error {
+	if errors.Is(err, service.ErrCollectionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
 func (i *InteractiveServiceServer) toDTO(interactive domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
 		BizStr:     interactive.Biz,
 		BizId:      interactive.BizId,
 		ReadCnt:    interactive.ReadCnt,
+		UvCnt:      interactive.UvCnt,
 		LikeCnt:    interactive.LikeCnt,
 		CollectCnt: interactive.CollectCnt,
+		CommentCnt: interactive.CommentCnt,
 		Liked:      interactive.Liked,
 		Collected:  interactive.Collected,
 	}
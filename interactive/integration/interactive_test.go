@@ -230,7 +230,7 @@ func (s *InteractiveTestSuite) TestLike() {
 					Uid:    123,
 					BizId:  1,
 					BizStr: "test",
-					Status: 1,
+					Status: 0,
 					Utime:  now,
 					Ctime:  now,
 				})
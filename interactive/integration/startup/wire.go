@@ -16,8 +16,10 @@ var (
 		InitRedis, InitDB, InitLogger)
 	interactiveSvcSet = wire.NewSet(
 		dao.NewInteractiveGormDao,
+		dao.NewGormCollectionDao,
 		cache.NewInteractiveRedisCache,
 		repository.NewCachedInteractiveRepository,
+		repository.NewCachedCollectionRepository,
 		service.NewInteractiveService,
 	)
 )
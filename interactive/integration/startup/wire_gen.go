@@ -23,7 +23,9 @@ func InitInteractiveService() *grpc.InteractiveServiceServer {
 	cmdable := InitRedis()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDao, interactiveCache)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	collectionDao := dao.NewGormCollectionDao(db)
+	collectionRepository := repository.NewCachedCollectionRepository(collectionDao, interactiveCache)
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	return interactiveServiceServer
 }
@@ -33,5 +35,5 @@ func InitInteractiveService() *grpc.InteractiveServiceServer {
 var (
 	thirdPartySet = wire.NewSet(
 		InitRedis, InitDB, InitLogger)
-	interactiveSvcSet = wire.NewSet(dao.NewInteractiveGormDao, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, service.NewInteractiveService)
+	interactiveSvcSet = wire.NewSet(dao.NewInteractiveGormDao, dao.NewGormCollectionDao, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, repository.NewCachedCollectionRepository, service.NewInteractiveService)
 )
@@ -8,7 +8,12 @@ import (
 	"google.golang.org/grpc"
 )
 
-func NewGrpcxServer(intrSvc *grpc2.InteractiveServiceServer, l logger.LoggerV1) *grpcx.Server {
+// InitJobExecutorServer interactive 托管的任务在这里注册，调度器通过 grpc 执行器调用
+func InitJobExecutorServer() *grpcx.JobExecutorServer {
+	return grpcx.NewJobExecutorServer()
+}
+
+func NewGrpcxServer(intrSvc *grpc2.InteractiveServiceServer, jobSvc *grpcx.JobExecutorServer, l logger.LoggerV1) *grpcx.Server {
 	type Config struct {
 		EtcdAddr string `yaml:"etcdAddr"`
 		Port     int    `yaml:"port"`
@@ -16,6 +21,7 @@ func NewGrpcxServer(intrSvc *grpc2.InteractiveServiceServer, l logger.LoggerV1)
 	}
 	s := grpc.NewServer()
 	intrSvc.Register(s)
+	jobSvc.Register(s)
 	var cfg Config
 	err := viper.UnmarshalKey("grpc.server", &cfg)
 	if err != nil {
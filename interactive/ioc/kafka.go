@@ -1,11 +1,14 @@
 package ioc
 
 import (
+	"time"
+
 	"github.com/IBM/sarama"
 	"github.com/misakimei123/redbook/interactive/events"
 	"github.com/misakimei123/redbook/interactive/repository"
 	"github.com/misakimei123/redbook/interactive/repository/dao"
 	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/misakimei123/redbook/pkg/saramax"
 
 	"github.com/misakimei123/redbook/pkg/migrator/events/fixer"
 	"github.com/prometheus/client_golang/prometheus"
@@ -36,12 +39,21 @@ func InitConsumers(consumer *events.InteractiveReadEventBatchConsumer, fixConsum
 }
 
 func InitialInteractiveReadEventBatchConsumer(repo repository.InteractiveRepository, client sarama.Client,
-	l logger.LoggerV1) *events.InteractiveReadEventBatchConsumer {
+	producer sarama.SyncProducer, l logger.LoggerV1) *events.InteractiveReadEventBatchConsumer {
 	return events.NewInteractiveReadEventBatchConsumer(repo, client, l, prometheus.GaugeOpts{
 		Namespace: "misakimei123",
 		Subsystem: "redbook",
 		Name:      "kafka_consumer",
-	})
+	},
+		viper.GetDuration("readEvent.dedupWindow"),
+		saramax.WithBatchSize(100),
+		saramax.WithMaxWait(time.Second),
+		saramax.WithMaxBytes(1<<20),
+		saramax.WithRetry(saramax.RetryPolicy{
+			MaxAttempts: 3,
+			Backoff:     saramax.ExponentialBackoff(100*time.Millisecond, time.Second),
+		}),
+		saramax.WithDeadLetter(producer))
 }
 
 func InitSaramaSyncProducer(client sarama.Client) sarama.SyncProducer {
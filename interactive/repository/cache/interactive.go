@@ -15,7 +15,11 @@ import (
 
 var (
 	//go:embed lua/incr_cnt.lua
-	luaIncrCnt     string
+	luaIncrCnt string
+	//go:embed lua/mark_read.lua
+	luaMarkRead string
+	//go:embed lua/check_read.lua
+	luaCheckRead   string
 	ErrKeyNotExist = errors.New("cache key not exist")
 )
 
@@ -23,15 +27,28 @@ const (
 	fieldReadCnt    = "read_cnt"
 	fieldLikeCnt    = "like_cnt"
 	fieldCollectCnt = "collect_cnt"
+	fieldCommentCnt = "comment_cnt"
+	fieldUvCnt      = "uv_cnt"
 )
 
 type InteractiveCache interface {
 	IncrReadCntIfPresent(ctx context.Context, bizStr string, bizId int64) error
+	// BatchIncrReadCntIfPresent 一次 pipeline 把 deltas 加到已经缓存的 read_cnt 上，uvDeltas 加到 uv_cnt 上
+	BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, ids []int64, deltas []int64, uvDeltas []int64) error
+	// CheckReads 只检查不记录。counted 表示这次要不要计数，window 内同一个用户重复阅读只算一次，window 为 0 不去重。
+	// newVisitors 表示是不是第一次读。uid 为 0 的不去重，也不算访客。同一批里面重复的只有第一次算
+	CheckReads(ctx context.Context, bizs []string, ids []int64, uids []int64, window time.Duration) (counted []bool, newVisitors []bool, err error)
+	// MarkReads 记录阅读，计数写进数据库之后再调用，这样写失败了重试还能重新计数
+	MarkReads(ctx context.Context, bizs []string, ids []int64, uids []int64, window time.Duration) error
 	IncrLikeCntIfPresent(ctx context.Context, bizStr string, bizId int64) error
 	DecrLikeCntIfPresent(ctx context.Context, bizStr string, bizId int64) error
 	IncrCollectCntIfPresent(ctx context.Context, bizStr string, bizId int64) error
+	DecrCollectCntIfPresent(ctx context.Context, bizStr string, bizId int64) error
+	IncrCommentCntIfPresent(ctx context.Context, bizStr string, bizId int64, delta int64) error
 	Get(ctx context.Context, bizStr string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, bizStr string, bizId int64, intra domain.Interactive) error
+	// BatchSet 一次 pipeline 写入多个
+	BatchSet(ctx context.Context, bizStr string, intras []domain.Interactive) error
 }
 
 type InteractiveRedisCache struct {
@@ -42,6 +59,88 @@ func (i *InteractiveRedisCache) IncrReadCntIfPresent(ctx context.Context, bizStr
 	return i.client.Eval(ctx, luaIncrCnt, []string{i.key(bizStr, bizId)}, fieldReadCnt, 1).Err()
 }
 
+func (i *InteractiveRedisCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, ids []int64, deltas []int64, uvDeltas []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	pipe := i.client.Pipeline()
+	for k, id := range ids {
+		key := i.key(bizs[k], id)
+		pipe.Eval(ctx, luaIncrCnt, []string{key}, fieldReadCnt, deltas[k])
+		if uvDeltas[k] > 0 {
+			pipe.Eval(ctx, luaIncrCnt, []string{key}, fieldUvCnt, uvDeltas[k])
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (i *InteractiveRedisCache) CheckReads(ctx context.Context, bizs []string, ids []int64, uids []int64, window time.Duration) ([]bool, []bool, error) {
+	type readKey struct {
+		biz string
+		id  int64
+		uid int64
+	}
+	counted := make([]bool, len(ids))
+	newVisitors := make([]bool, len(ids))
+	cmds := make([]*redis.Cmd, len(ids))
+	seen := make(map[readKey]struct{}, len(ids))
+	pipe := i.client.Pipeline()
+	for k, id := range ids {
+		if uids[k] <= 0 {
+			counted[k] = true
+			continue
+		}
+		rk := readKey{biz: bizs[k], id: id, uid: uids[k]}
+		if _, ok := seen[rk]; ok {
+			// 还没有记录，同一批里面的重复阅读只能在这里去掉
+			counted[k] = window <= 0
+			continue
+		}
+		seen[rk] = struct{}{}
+		uvKey := i.uvKey(bizs[k], id)
+		cmds[k] = pipe.Eval(ctx, luaCheckRead,
+			[]string{i.readKey(bizs[k], id, uids[k]), uvKey, uvKey + ":probe"},
+			uids[k], window.Milliseconds())
+	}
+	if pipe.Len() == 0 {
+		return counted, newVisitors, nil
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		res, er := cmd.Int64Slice()
+		if er != nil || len(res) != 2 {
+			return nil, nil, fmt.Errorf("check read 返回值不对 %v %w", res, er)
+		}
+		counted[k] = res[0] == 1
+		newVisitors[k] = res[1] == 1
+	}
+	return counted, newVisitors, nil
+}
+
+func (i *InteractiveRedisCache) MarkReads(ctx context.Context, bizs []string, ids []int64, uids []int64, window time.Duration) error {
+	pipe := i.client.Pipeline()
+	for k, id := range ids {
+		if uids[k] <= 0 {
+			continue
+		}
+		pipe.Eval(ctx, luaMarkRead,
+			[]string{i.readKey(bizs[k], id, uids[k]), i.uvKey(bizs[k], id)},
+			uids[k], window.Milliseconds())
+	}
+	if pipe.Len() == 0 {
+		return nil
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (i *InteractiveRedisCache) IncrLikeCntIfPresent(ctx context.Context, bizStr string, bizId int64) error {
 	return i.client.Eval(ctx, luaIncrCnt, []string{i.key(bizStr, bizId)}, fieldLikeCnt, 1).Err()
 }
@@ -54,6 +153,14 @@ func (i *InteractiveRedisCache) IncrCollectCntIfPresent(ctx context.Context, biz
 	return i.client.Eval(ctx, luaIncrCnt, []string{i.key(bizStr, bizId)}, fieldCollectCnt, 1).Err()
 }
 
+func (i *InteractiveRedisCache) DecrCollectCntIfPresent(ctx context.Context, bizStr string, bizId int64) error {
+	return i.client.Eval(ctx, luaIncrCnt, []string{i.key(bizStr, bizId)}, fieldCollectCnt, -1).Err()
+}
+
+func (i *InteractiveRedisCache) IncrCommentCntIfPresent(ctx context.Context, bizStr string, bizId int64, delta int64) error {
+	return i.client.Eval(ctx, luaIncrCnt, []string{i.key(bizStr, bizId)}, fieldCommentCnt, delta).Err()
+}
+
 func (i *InteractiveRedisCache) Get(ctx context.Context, bizStr string, bizId int64) (domain.Interactive, error) {
 	result, err := i.client.HGetAll(ctx, i.key(bizStr, bizId)).Result()
 	if err != nil {
@@ -76,13 +183,21 @@ func (i *InteractiveRedisCache) Get(ctx context.Context, bizStr string, bizId in
 	if err != nil {
 		return domain.Interactive{}, err
 	}
+	interactive.CommentCnt, err = strconv.ParseInt(result[fieldCommentCnt], 10, 64)
+	if err != nil {
+		return domain.Interactive{}, err
+	}
+	interactive.UvCnt, err = strconv.ParseInt(result[fieldUvCnt], 10, 64)
+	if err != nil {
+		return domain.Interactive{}, err
+	}
 	return interactive, nil
 
 }
 
 func (i *InteractiveRedisCache) Set(ctx context.Context, bizStr string, bizId int64, intra domain.Interactive) error {
 	key := i.key(bizStr, bizId)
-	err := i.client.HSet(ctx, key, fieldReadCnt, intra.ReadCnt, fieldLikeCnt, intra.LikeCnt, fieldCollectCnt, intra.CollectCnt).Err()
+	err := i.client.HSet(ctx, key, fieldReadCnt, intra.ReadCnt, fieldLikeCnt, intra.LikeCnt, fieldCollectCnt, intra.CollectCnt, fieldCommentCnt, intra.CommentCnt, fieldUvCnt, intra.UvCnt).Err()
 	if err != nil {
 		return err
 	}
@@ -90,6 +205,21 @@ func (i *InteractiveRedisCache) Set(ctx context.Context, bizStr string, bizId in
 
 }
 
+func (i *InteractiveRedisCache) BatchSet(ctx context.Context, bizStr string, intras []domain.Interactive) error {
+	if len(intras) == 0 {
+		return nil
+	}
+	pipe := i.client.Pipeline()
+	for _, intra := range intras {
+		key := i.key(bizStr, intra.BizId)
+		pipe.HSet(ctx, key, fieldReadCnt, intra.ReadCnt, fieldLikeCnt, intra.LikeCnt, fieldCollectCnt, intra.CollectCnt,
+			fieldCommentCnt, intra.CommentCnt, fieldUvCnt, intra.UvCnt)
+		pipe.Expire(ctx, key, time.Minute*15)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func NewInteractiveRedisCache(client redis.Cmdable) InteractiveCache {
 	return &InteractiveRedisCache{client: client}
 }
@@ -97,3 +227,11 @@ func NewInteractiveRedisCache(client redis.Cmdable) InteractiveCache {
 func (i *InteractiveRedisCache) key(bizStr string, bizId int64) string {
 	return fmt.Sprintf("interactive:%s:%d", bizStr, bizId)
 }
+
+func (i *InteractiveRedisCache) readKey(bizStr string, bizId int64, uid int64) string {
+	return fmt.Sprintf("interactive:read:%s:%d:%d", bizStr, bizId, uid)
+}
+
+func (i *InteractiveRedisCache) uvKey(bizStr string, bizId int64) string {
+	return fmt.Sprintf("interactive:uv:%s:%d", bizStr, bizId)
+}
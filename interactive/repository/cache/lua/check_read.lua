@@ -0,0 +1,18 @@
+-- 只检查不记录，返回 {是否计数, 是否新访客}，数据库写成功之后再用 mark_read.lua 记录
+local dedupKey = KEYS[1]
+local uvKey = KEYS[2]
+local probeKey = KEYS[3]
+local uid = ARGV[1]
+local window = tonumber(ARGV[2])
+local counted = 1
+if window > 0 and redis.call('EXISTS', dedupKey) == 1 then
+    counted = 0
+end
+-- HyperLogLog 没法只查不写，复制一份出来加一下试试
+local hll = redis.call('GET', uvKey)
+if hll then
+    redis.call('SET', probeKey, hll)
+end
+local newVisitor = redis.call('PFADD', probeKey, uid)
+redis.call('DEL', probeKey)
+return {counted, newVisitor}
@@ -1,10 +1,14 @@
 local key = KEYS[1]
-local cntKey = ARGS[1]
-local delta = ARGS[2]
+local cntKey = ARGV[1]
+local delta = ARGV[2]
 local exist = redis.call('EXISTS', key)
 if exist == 0 then
     return 0
 end
 
-redis.call('HINCRBY', key, cntKey, delta)
-return 1
\ No newline at end of file
+-- 和数据库的 GREATEST 一样，计数不能减成负数
+local cnt = redis.call('HINCRBY', key, cntKey, delta)
+if cnt < 0 then
+    redis.call('HSET', key, cntKey, 0)
+end
+return 1
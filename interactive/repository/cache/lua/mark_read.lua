@@ -0,0 +1,10 @@
+-- 记录一次阅读，window 内再读不计数
+local dedupKey = KEYS[1]
+local uvKey = KEYS[2]
+local uid = ARGV[1]
+local window = tonumber(ARGV[2])
+redis.call('PFADD', uvKey, uid)
+if window > 0 then
+    redis.call('SET', dedupKey, 1, 'NX', 'PX', window)
+end
+return 0
@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive/repository/cache/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive/repository/cache/interactive.go -package=cachemocks -destination=./interactive/repository/cache/mocks/interactive.mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/misakimei123/redbook/interactive/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveCache is a mock of InteractiveCache interface.
+type MockInteractiveCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveCacheMockRecorder
+}
+
+// MockInteractiveCacheMockRecorder is the mock recorder for MockInteractiveCache.
+type MockInteractiveCacheMockRecorder struct {
+	mock *MockInteractiveCache
+}
+
+// NewMockInteractiveCache creates a new mock instance.
+func NewMockInteractiveCache(ctrl *gomock.Controller) *MockInteractiveCache {
+	mock := &MockInteractiveCache{ctrl: ctrl}
+	mock.recorder = &MockInteractiveCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveCache) EXPECT() *MockInteractiveCacheMockRecorder {
+	return m.recorder
+}
+
+// BatchIncrReadCntIfPresent mocks base method.
+func (m *MockInteractiveCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, ids, deltas, uvDeltas []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCntIfPresent", ctx, bizs, ids, deltas, uvDeltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCntIfPresent indicates an expected call of BatchIncrReadCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) BatchIncrReadCntIfPresent(ctx, bizs, ids, deltas, uvDeltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).BatchIncrReadCntIfPresent), ctx, bizs, ids, deltas, uvDeltas)
+}
+
+// BatchSet mocks base method.
+func (m *MockInteractiveCache) BatchSet(ctx context.Context, bizStr string, intras []domain.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchSet", ctx, bizStr, intras)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchSet indicates an expected call of BatchSet.
+func (mr *MockInteractiveCacheMockRecorder) BatchSet(ctx, bizStr, intras any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchSet", reflect.TypeOf((*MockInteractiveCache)(nil).BatchSet), ctx, bizStr, intras)
+}
+
+// CheckReads mocks base method.
+func (m *MockInteractiveCache) CheckReads(ctx context.Context, bizs []string, ids, uids []int64, window time.Duration) ([]bool, []bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReads", ctx, bizs, ids, uids, window)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].([]bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckReads indicates an expected call of CheckReads.
+func (mr *MockInteractiveCacheMockRecorder) CheckReads(ctx, bizs, ids, uids, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReads", reflect.TypeOf((*MockInteractiveCache)(nil).CheckReads), ctx, bizs, ids, uids, window)
+}
+
+// DecrCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, bizStr string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrCollectCntIfPresent", ctx, bizStr, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrCollectCntIfPresent indicates an expected call of DecrCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecrCollectCntIfPresent(ctx, bizStr, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrCollectCntIfPresent), ctx, bizStr, bizId)
+}
+
+// DecrLikeCntIfPresent mocks base method.
+func (m *MockInteractiveCache) DecrLikeCntIfPresent(ctx context.Context, bizStr string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrLikeCntIfPresent", ctx, bizStr, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrLikeCntIfPresent indicates an expected call of DecrLikeCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecrLikeCntIfPresent(ctx, bizStr, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLikeCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrLikeCntIfPresent), ctx, bizStr, bizId)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveCache) Get(ctx context.Context, bizStr string, bizId int64) (domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, bizStr, bizId)
+	ret0, _ := ret[0].(domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveCacheMockRecorder) Get(ctx, bizStr, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveCache)(nil).Get), ctx, bizStr, bizId)
+}
+
+// IncrCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrCollectCntIfPresent(ctx context.Context, bizStr string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCollectCntIfPresent", ctx, bizStr, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCollectCntIfPresent indicates an expected call of IncrCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrCollectCntIfPresent(ctx, bizStr, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrCollectCntIfPresent), ctx, bizStr, bizId)
+}
+
+// IncrCommentCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrCommentCntIfPresent(ctx context.Context, bizStr string, bizId, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCommentCntIfPresent", ctx, bizStr, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCommentCntIfPresent indicates an expected call of IncrCommentCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrCommentCntIfPresent(ctx, bizStr, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCommentCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrCommentCntIfPresent), ctx, bizStr, bizId, delta)
+}
+
+// IncrLikeCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrLikeCntIfPresent(ctx context.Context, bizStr string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLikeCntIfPresent", ctx, bizStr, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLikeCntIfPresent indicates an expected call of IncrLikeCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrLikeCntIfPresent(ctx, bizStr, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLikeCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrLikeCntIfPresent), ctx, bizStr, bizId)
+}
+
+// IncrReadCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrReadCntIfPresent(ctx context.Context, bizStr string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCntIfPresent", ctx, bizStr, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCntIfPresent indicates an expected call of IncrReadCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrReadCntIfPresent(ctx, bizStr, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrReadCntIfPresent), ctx, bizStr, bizId)
+}
+
+// MarkReads mocks base method.
+func (m *MockInteractiveCache) MarkReads(ctx context.Context, bizs []string, ids, uids []int64, window time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReads", ctx, bizs, ids, uids, window)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReads indicates an expected call of MarkReads.
+func (mr *MockInteractiveCacheMockRecorder) MarkReads(ctx, bizs, ids, uids, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReads", reflect.TypeOf((*MockInteractiveCache)(nil).MarkReads), ctx, bizs, ids, uids, window)
+}
+
+// Set mocks base method.
+func (m *MockInteractiveCache) Set(ctx context.Context, bizStr string, bizId int64, intra domain.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, bizStr, bizId, intra)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockInteractiveCacheMockRecorder) Set(ctx, bizStr, bizId, intra any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockInteractiveCache)(nil).Set), ctx, bizStr, bizId, intra)
+}
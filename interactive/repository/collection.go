@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/misakimei123/redbook/interactive/domain"
+	"github.com/misakimei123/redbook/interactive/repository/cache"
+	"github.com/misakimei123/redbook/interactive/repository/dao"
+)
+
+var ErrCollectionNotFound = dao.ErrRecordNotFound
+
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Rename(ctx context.Context, id int64, uid int64, name string) error
+	FindById(ctx context.Context, id int64, uid int64) (domain.Collection, error)
+	FindByUid(ctx context.Context, uid int64) ([]domain.Collection, error)
+	Delete(ctx context.Context, id int64, uid int64) error
+	FindItems(ctx context.Context, uid int64, cid int64, lastId int64, limit int) ([]domain.CollectionItem, error)
+}
+
+type CachedCollectionRepository struct {
+	dao   dao.CollectionDao
+	cache cache.InteractiveCache
+}
+
+func NewCachedCollectionRepository(dao dao.CollectionDao, cache cache.InteractiveCache) CollectionRepository {
+	return &CachedCollectionRepository{dao: dao, cache: cache}
+}
+
+func (c *CachedCollectionRepository) Create(ctx context.Context, collection domain.Collection) (int64, error) {
+	return c.dao.Insert(ctx, dao.Collection{
+		Uid:  collection.Uid,
+		Name: collection.Name,
+	})
+}
+
+func (c *CachedCollectionRepository) Rename(ctx context.Context, id int64, uid int64, name string) error {
+	return c.dao.UpdateName(ctx, id, uid, name)
+}
+
+func (c *CachedCollectionRepository) FindById(ctx context.Context, id int64, uid int64) (domain.Collection, error) {
+	collection, err := c.dao.FindById(ctx, id, uid)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return c.toDomain(collection), nil
+}
+
+func (c *CachedCollectionRepository) FindByUid(ctx context.Context, uid int64) ([]domain.Collection, error) {
+	collections, err := c.dao.FindByUid(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(collections, func(idx int, src dao.Collection) domain.Collection {
+		return c.toDomain(src)
+	}), nil
+}
+
+// Delete 库里面已经扣减了 collect_cnt，缓存跟着扣
+func (c *CachedCollectionRepository) Delete(ctx context.Context, id int64, uid int64) error {
+	items, err := c.dao.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		err = c.cache.DecrCollectCntIfPresent(ctx, item.BizStr, item.BizId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CachedCollectionRepository) FindItems(ctx context.Context, uid int64, cid int64, lastId int64, limit int) ([]domain.CollectionItem, error) {
+	items, err := c.dao.FindItems(ctx, uid, cid, lastId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(items, func(idx int, src dao.UserCollectBiz) domain.CollectionItem {
+		return domain.CollectionItem{
+			Id:    src.Id,
+			Cid:   src.Cid,
+			Biz:   src.BizStr,
+			BizId: src.BizId,
+			Ctime: time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
+
+func (c *CachedCollectionRepository) toDomain(collection dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:    collection.Id,
+		Uid:   collection.Uid,
+		Name:  collection.Name,
+		Ctime: time.UnixMilli(collection.Ctime),
+		Utime: time.UnixMilli(collection.Utime),
+	}
+}
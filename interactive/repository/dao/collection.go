@@ -0,0 +1,114 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CollectionDao interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	UpdateName(ctx context.Context, id int64, uid int64, name string) error
+	FindById(ctx context.Context, id int64, uid int64) (Collection, error)
+	FindByUid(ctx context.Context, uid int64) ([]Collection, error)
+	// Delete 同一个事务里面删掉收藏夹和里面的收藏，并扣减对应的 collect_cnt，返回被删掉的收藏
+	Delete(ctx context.Context, id int64, uid int64) ([]UserCollectBiz, error)
+	// FindItems 按 id 倒序分页，lastId 为 0 表示第一页
+	FindItems(ctx context.Context, uid int64, cid int64, lastId int64, limit int) ([]UserCollectBiz, error)
+}
+
+type Collection struct {
+	Id    int64  `gorm:"primaryKey, autoIncrement"`
+	Uid   int64  `gorm:"index"`
+	Name  string `gorm:"type:varchar(128)"`
+	Utime int64
+	Ctime int64
+}
+
+type GormCollectionDao struct {
+	db *gorm.DB
+}
+
+func NewGormCollectionDao(db *gorm.DB) CollectionDao {
+	return &GormCollectionDao{db: db}
+}
+
+func (g *GormCollectionDao) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GormCollectionDao) UpdateName(ctx context.Context, id int64, uid int64, name string) error {
+	res := g.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? AND uid = ?", id, uid).
+		Updates(map[string]any{
+			"name":  name,
+			"utime": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (g *GormCollectionDao) FindById(ctx context.Context, id int64, uid int64) (Collection, error) {
+	var c Collection
+	err := g.db.WithContext(ctx).Where("id = ? AND uid = ?", id, uid).First(&c).Error
+	return c, err
+}
+
+func (g *GormCollectionDao) FindByUid(ctx context.Context, uid int64) ([]Collection, error) {
+	var res []Collection
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (g *GormCollectionDao) Delete(ctx context.Context, id int64, uid int64) ([]UserCollectBiz, error) {
+	var items []UserCollectBiz
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		err := tx.Where("uid = ? AND cid = ?", uid, id).Find(&items).Error
+		if err != nil || len(items) == 0 {
+			return err
+		}
+		err = tx.Where("uid = ? AND cid = ?", uid, id).Delete(&UserCollectBiz{}).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		for _, item := range items {
+			err = decrCollectCnt(tx, item.BizStr, item.BizId, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (g *GormCollectionDao) FindItems(ctx context.Context, uid int64, cid int64, lastId int64, limit int) ([]UserCollectBiz, error) {
+	db := g.db.WithContext(ctx).Where("uid = ? AND cid = ?", uid, cid)
+	if lastId > 0 {
+		db = db.Where("id < ?", lastId)
+	}
+	var res []UserCollectBiz
+	err := db.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
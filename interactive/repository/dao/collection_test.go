@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGormCollectionDao_Delete(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sqlmock   func(t *testing.T) *sql.DB
+		id        int64
+		uid       int64
+		wantItems []UserCollectBiz
+		wantErr   error
+	}{
+		{
+			name: "not found",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return db
+			},
+			id:      1,
+			uid:     123,
+			wantErr: ErrRecordNotFound,
+		},
+		{
+			name: "empty collection",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_collect_bizs` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+				return db
+			},
+			id:        1,
+			uid:       123,
+			wantItems: []UserCollectBiz{},
+		},
+		{
+			name: "uncollect items",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_collect_bizs` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "biz_id", "biz_str", "cid"}).
+						AddRow(10, 123, 2, "article", 1).
+						AddRow(11, 123, 3, "article", 1))
+				mock.ExpectExec("DELETE FROM `user_collect_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE `interactives` SET `collect_cnt`=GREATEST.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET `collect_cnt`=GREATEST.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			id:  1,
+			uid: 123,
+			wantItems: []UserCollectBiz{
+				{Id: 10, Uid: 123, BizId: 2, BizStr: "article", Cid: 1},
+				{Id: 11, Uid: 123, BizId: 3, BizStr: "article", Cid: 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			items, err := NewGormCollectionDao(openMockDB(t, tc.sqlmock(t))).Delete(context.Background(), tc.id, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantItems, items)
+		})
+	}
+}
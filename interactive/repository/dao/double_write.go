@@ -61,17 +61,22 @@ func (d *DoubleWriteDAO) IncrReadCnt(ctx context.Context, bizStr string, bizId i
 	return nil
 }
 
-func (d *DoubleWriteDAO) InsertLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) error {
+func (d *DoubleWriteDAO) InsertLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
-func (d *DoubleWriteDAO) DeleteLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) error {
+func (d *DoubleWriteDAO) DeleteLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
-func (d *DoubleWriteDAO) InsertCollectBiz(ctx context.Context, userCollectBiz UserCollectBiz) error {
+func (d *DoubleWriteDAO) InsertCollectBiz(ctx context.Context, userCollectBiz UserCollectBiz) (bool, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (d *DoubleWriteDAO) DeleteCollectBiz(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error) {
 	//TODO implement me
 	panic("implement me")
 }
@@ -86,6 +91,16 @@ func (d *DoubleWriteDAO) GetCollectInfo(ctx context.Context, bizStr string, bizI
 	panic("implement me")
 }
 
+func (d *DoubleWriteDAO) GetLikeInfos(ctx context.Context, bizStr string, ids []int64, uid int64) ([]UserLikeBiz, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (d *DoubleWriteDAO) GetCollectInfos(ctx context.Context, bizStr string, ids []int64, uid int64) ([]UserCollectBiz, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (d *DoubleWriteDAO) Get(ctx context.Context, bizStr string, bizId int64) (Interactive, error) {
 	pattern := d.pattern.Load()
 	switch pattern {
@@ -98,7 +113,7 @@ func (d *DoubleWriteDAO) Get(ctx context.Context, bizStr string, bizId int64) (I
 	}
 }
 
-func (d *DoubleWriteDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error {
+func (d *DoubleWriteDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, deltas []int64, uvDeltas []int64) error {
 	//TODO implement me
 	panic("implement me")
 }
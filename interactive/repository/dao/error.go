@@ -1,5 +1,16 @@
 package dao
 
-import "gorm.io/gorm"
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
 
 var ErrRecordNotFound = gorm.ErrRecordNotFound
+
+func isDuplicateErr(err error) bool {
+	const duplicateErr uint16 = 1062
+	var sqlErr *mysql.MySQLError
+	return errors.As(err, &sqlErr) && sqlErr.Number == duplicateErr
+}
@@ -6,6 +6,6 @@ import (
 
 func InitTables(db *gorm.DB) error {
 	return db.AutoMigrate(
-		&Interactive{}, &UserLikeBiz{}, &UserCollectBiz{},
+		&Interactive{}, &UserLikeBiz{}, &UserCollectBiz{}, &Collection{},
 	)
 }
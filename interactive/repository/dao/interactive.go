@@ -11,14 +11,21 @@ import (
 
 type InteractiveDao interface {
 	IncrReadCnt(ctx context.Context, bizStr string, bizId int64) error
-	InsertLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) error
-	DeleteLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) error
-	InsertCollectBiz(ctx context.Context, userCollectBiz UserCollectBiz) error
+	// InsertLikeInfo 等四个方法返回状态是否真的改变了，重复调用不会重复计数
+	InsertLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error)
+	DeleteLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error)
+	InsertCollectBiz(ctx context.Context, userCollectBiz UserCollectBiz) (bool, error)
+	DeleteCollectBiz(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error)
 	GetLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) (UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, bizStr string, bizId int64, uid int64) (UserCollectBiz, error)
+	// GetLikeInfos 只返回 ids 里面 uid 点过赞的
+	GetLikeInfos(ctx context.Context, bizStr string, ids []int64, uid int64) ([]UserLikeBiz, error)
+	GetCollectInfos(ctx context.Context, bizStr string, ids []int64, uid int64) ([]UserCollectBiz, error)
 	Get(ctx context.Context, bizStr string, bizId int64) (Interactive, error)
-	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error
+	// BatchIncrReadCnt 一条语句把 deltas 加到对应的 read_cnt 上，uvDeltas 加到 uv_cnt 上
+	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, deltas []int64, uvDeltas []int64) error
 	GetByIds(ctx context.Context, bizStr string, ids []int64) ([]Interactive, error)
+	IncrCommentCnt(ctx context.Context, bizStr string, bizId int64, delta int64) error
 }
 
 func NewInteractiveGormDao(db *gorm.DB) InteractiveDao {
@@ -56,15 +63,35 @@ func (i *InteractiveGormDao) GetCollectInfo(ctx context.Context, bizStr string,
 	return collect, err
 }
 
-func (i *InteractiveGormDao) InsertCollectBiz(ctx context.Context, userCollectBiz UserCollectBiz) error {
+func (i *InteractiveGormDao) GetLikeInfos(ctx context.Context, bizStr string, ids []int64, uid int64) ([]UserLikeBiz, error) {
+	var likes []UserLikeBiz
+	err := i.db.WithContext(ctx).
+		Where("uid=? and biz_str=? and biz_id IN ? and status=?", uid, bizStr, ids, 1).Find(&likes).Error
+	return likes, err
+}
+
+func (i *InteractiveGormDao) GetCollectInfos(ctx context.Context, bizStr string, ids []int64, uid int64) ([]UserCollectBiz, error) {
+	var collects []UserCollectBiz
+	err := i.db.WithContext(ctx).
+		Where("uid=? and biz_str=? and biz_id IN ?", uid, bizStr, ids).Find(&collects).Error
+	return collects, err
+}
+
+func (i *InteractiveGormDao) InsertCollectBiz(ctx context.Context, userCollectBiz UserCollectBiz) (bool, error) {
 	now := time.Now().UnixMilli()
 	userCollectBiz.Ctime = now
 	userCollectBiz.Utime = now
-	return i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	changed := false
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		err := tx.Create(&userCollectBiz).Error
+		if isDuplicateErr(err) {
+			// 已经收藏过了
+			return nil
+		}
 		if err != nil {
 			return err
 		}
+		changed = true
 		return tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"collect_cnt": gorm.Expr("`collect_cnt` + 1"),
@@ -79,50 +106,89 @@ func (i *InteractiveGormDao) InsertCollectBiz(ctx context.Context, userCollectBi
 			Ctime:      now,
 		}).Error
 	})
+	return changed && err == nil, err
+}
+
+func (i *InteractiveGormDao) DeleteCollectBiz(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error) {
+	changed := false
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("uid=? and biz_id=? and biz_str=?", uid, bizId, bizStr).Delete(&UserCollectBiz{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		changed = true
+		return decrCollectCnt(tx, bizStr, bizId, time.Now().UnixMilli())
+	})
+	return changed && err == nil, err
+}
+
+// decrCollectCnt 减的时候不会减到 0 以下
+func decrCollectCnt(tx *gorm.DB, bizStr string, bizId int64, now int64) error {
+	return tx.Model(&Interactive{}).
+		Where("biz_id=? and biz_str=?", bizId, bizStr).
+		Updates(map[string]interface{}{
+			"collect_cnt": gorm.Expr("GREATEST(CAST(`collect_cnt` AS SIGNED) - 1, 0)"),
+			"utime":       now,
+		}).Error
 }
 
-func (i *InteractiveGormDao) DeleteLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) error {
+// DeleteLikeInfo 只有从点赞状态改过来的才扣减 like_cnt
+func (i *InteractiveGormDao) DeleteLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error) {
 	now := time.Now().UnixMilli()
-	return i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(UserLikeBiz{}).
-			Where("uid=? and biz_id=? and biz_str=?", uid, bizId, bizStr).
+	changed := false
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(UserLikeBiz{}).
+			Where("uid=? and biz_id=? and biz_str=? and status=?", uid, bizId, bizStr, 1).
 			Updates(map[string]interface{}{
 				"utime":  now,
 				"status": 0,
-			}).Error
-		if err != nil {
-			return err
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
 		}
+		changed = true
 		return tx.Model(&Interactive{}).
 			Where("biz_id=? and biz_str=?", bizId, bizStr).
 			Updates(map[string]interface{}{
-				"like_cnt": gorm.Expr("`like_cnt` - 1"),
+				"like_cnt": gorm.Expr("GREATEST(CAST(`like_cnt` AS SIGNED) - 1, 0)"),
 				"utime":    now,
 			}).Error
 	})
+	return changed && err == nil, err
 }
 
-func (i *InteractiveGormDao) InsertLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) error {
+// InsertLikeInfo 先尝试把取消过的赞改回来，没有记录再插入，
+// 插入冲突说明并发的请求已经点过赞了，这两种情况都不会重复增加 like_cnt
+func (i *InteractiveGormDao) InsertLikeInfo(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error) {
 	now := time.Now().UnixMilli()
-	return i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
+	changed := false
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(UserLikeBiz{}).
+			Where("uid=? and biz_id=? and biz_str=? and status=?", uid, bizId, bizStr, 0).
+			Updates(map[string]interface{}{
 				"utime":  now,
 				"status": 1,
-			}),
-		}).Create(&UserLikeBiz{
-			Id:     0,
-			Uid:    uid,
-			BizId:  bizId,
-			BizStr: bizStr,
-			Status: 1,
-			Utime:  now,
-			Ctime:  now,
-		}).Error
-		if err != nil {
-			return err
+			})
+		if res.Error != nil {
+			return res.Error
 		}
-
+		if res.RowsAffected == 0 {
+			err := tx.Create(&UserLikeBiz{
+				Uid:    uid,
+				BizId:  bizId,
+				BizStr: bizStr,
+				Status: 1,
+				Utime:  now,
+				Ctime:  now,
+			}).Error
+			if isDuplicateErr(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+		changed = true
 		return tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"like_cnt": gorm.Expr("`like_cnt` + 1"),
@@ -137,6 +203,7 @@ func (i *InteractiveGormDao) InsertLikeInfo(ctx context.Context, bizStr string,
 			Ctime:   now,
 		}).Error
 	})
+	return changed && err == nil, err
 }
 
 func (i *InteractiveGormDao) IncrReadCnt(ctx context.Context, bizStr string, bizId int64) error {
@@ -156,15 +223,48 @@ func (i *InteractiveGormDao) IncrReadCnt(ctx context.Context, bizStr string, biz
 	}).Error
 }
 
-func (i *InteractiveGormDao) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error {
-	return i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txDAO := NewInteractiveGormDao(tx)
-		var err error
-		for i, id := range ids {
-			err = txDAO.IncrReadCnt(ctx, bizs[i], id)
-		}
-		return err
-	})
+// IncrCommentCnt 减的时候不会减到 0 以下
+func (i *InteractiveGormDao) IncrCommentCnt(ctx context.Context, bizStr string, bizId int64, delta int64) error {
+	now := time.Now().UnixMilli()
+	return i.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"comment_cnt": gorm.Expr("GREATEST(CAST(`comment_cnt` AS SIGNED) + ?, 0)", delta),
+			"utime":       now,
+		}),
+		UpdateAll: false,
+	}).Create(&Interactive{
+		BizId:      bizId,
+		BizStr:     bizStr,
+		CommentCnt: max(delta, 0),
+		Utime:      now,
+		Ctime:      now,
+	}).Error
+}
+
+func (i *InteractiveGormDao) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, deltas []int64, uvDeltas []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	intrs := make([]Interactive, 0, len(ids))
+	for k, id := range ids {
+		intrs = append(intrs, Interactive{
+			BizId:   id,
+			BizStr:  bizs[k],
+			ReadCnt: deltas[k],
+			UvCnt:   uvDeltas[k],
+			Utime:   now,
+			Ctime:   now,
+		})
+	}
+	return i.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"read_cnt": gorm.Expr("`read_cnt` + VALUES(`read_cnt`)"),
+			"uv_cnt":   gorm.Expr("`uv_cnt` + VALUES(`uv_cnt`)"),
+			"utime":    now,
+		}),
+		UpdateAll: false,
+	}).Create(&intrs).Error
 }
 
 type UserLikeBiz struct {
@@ -192,8 +292,10 @@ type Interactive struct {
 	BizId      int64  `gorm:"uniqueIndex:biz_type_id"`
 	BizStr     string `gorm:"type:varchar(128);uniqueIndex:biz_type_id"`
 	ReadCnt    int64
+	UvCnt      int64
 	LikeCnt    int64
 	CollectCnt int64
+	CommentCnt int64
 	Utime      int64
 	Ctime      int64
 }
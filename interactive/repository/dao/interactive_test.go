@@ -0,0 +1,181 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestInteractiveGormDao_InsertLikeInfo(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sqlmock     func(t *testing.T) *sql.DB
+		wantChanged bool
+		wantErr     error
+	}{
+		{
+			name: "first like",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactives` .*ON DUPLICATE KEY UPDATE.*`like_cnt`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantChanged: true,
+		},
+		{
+			name: "like again after cancel",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactives` .*ON DUPLICATE KEY UPDATE.*`like_cnt`").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+				return db
+			},
+			wantChanged: true,
+		},
+		{
+			name: "already liked",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `user_like_bizs` .*").
+					WillReturnError(&mysqlDriver.MySQLError{Number: 1062})
+				mock.ExpectCommit()
+				return db
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := NewInteractiveGormDao(openMockDB(t, tc.sqlmock(t))).
+				InsertLikeInfo(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantChanged, changed)
+		})
+	}
+}
+
+func TestInteractiveGormDao_DeleteLikeInfo(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sqlmock     func(t *testing.T) *sql.DB
+		wantChanged bool
+		wantErr     error
+	}{
+		{
+			name: "cancel like",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET `like_cnt`=GREATEST.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantChanged: true,
+		},
+		{
+			name: "not liked",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := NewInteractiveGormDao(openMockDB(t, tc.sqlmock(t))).
+				DeleteLikeInfo(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantChanged, changed)
+		})
+	}
+}
+
+func TestInteractiveGormDao_BatchIncrReadCnt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sqlmock  func(t *testing.T) *sql.DB
+		bizs     []string
+		ids      []int64
+		deltas   []int64
+		uvDeltas []int64
+		wantErr  error
+	}{
+		{
+			name: "one upsert",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectExec("INSERT INTO `interactives` .* VALUES \\(.*\\),\\(.*\\) ON DUPLICATE KEY UPDATE `read_cnt`=`read_cnt` \\+ VALUES\\(`read_cnt`\\),`utime`=\\?,`uv_cnt`=`uv_cnt` \\+ VALUES\\(`uv_cnt`\\)").
+					WithArgs(1, "article", 3, 2, 0, 0, 0, sqlmock.AnyArg(), sqlmock.AnyArg(),
+						2, "article", 1, 0, 0, 0, 0, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 3))
+				return db
+			},
+			bizs:     []string{"article", "article"},
+			ids:      []int64{1, 2},
+			deltas:   []int64{3, 1},
+			uvDeltas: []int64{2, 0},
+		},
+		{
+			name: "empty",
+			sqlmock: func(t *testing.T) *sql.DB {
+				db, _, err := sqlmock.New()
+				assert.NoError(t, err)
+				return db
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewInteractiveGormDao(openMockDB(t, tc.sqlmock(t))).
+				BatchIncrReadCnt(context.Background(), tc.bizs, tc.ids, tc.deltas, tc.uvDeltas)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func openMockDB(t *testing.T, conn *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      conn,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	assert.NoError(t, err)
+	return db
+}
@@ -0,0 +1,233 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive/repository/dao/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive/repository/dao/interactive.go -package=daomocks -destination=./interactive/repository/dao/mocks/interactive.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/misakimei123/redbook/interactive/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveDao is a mock of InteractiveDao interface.
+type MockInteractiveDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveDaoMockRecorder
+}
+
+// MockInteractiveDaoMockRecorder is the mock recorder for MockInteractiveDao.
+type MockInteractiveDaoMockRecorder struct {
+	mock *MockInteractiveDao
+}
+
+// NewMockInteractiveDao creates a new mock instance.
+func NewMockInteractiveDao(ctrl *gomock.Controller) *MockInteractiveDao {
+	mock := &MockInteractiveDao{ctrl: ctrl}
+	mock.recorder = &MockInteractiveDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveDao) EXPECT() *MockInteractiveDaoMockRecorder {
+	return m.recorder
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractiveDao) BatchIncrReadCnt(ctx context.Context, bizs []string, ids, deltas, uvDeltas []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, bizs, ids, deltas, uvDeltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractiveDaoMockRecorder) BatchIncrReadCnt(ctx, bizs, ids, deltas, uvDeltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveDao)(nil).BatchIncrReadCnt), ctx, bizs, ids, deltas, uvDeltas)
+}
+
+// DeleteCollectBiz mocks base method.
+func (m *MockInteractiveDao) DeleteCollectBiz(ctx context.Context, bizStr string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectBiz", ctx, bizStr, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollectBiz indicates an expected call of DeleteCollectBiz.
+func (mr *MockInteractiveDaoMockRecorder) DeleteCollectBiz(ctx, bizStr, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectBiz", reflect.TypeOf((*MockInteractiveDao)(nil).DeleteCollectBiz), ctx, bizStr, bizId, uid)
+}
+
+// DeleteLikeInfo mocks base method.
+func (m *MockInteractiveDao) DeleteLikeInfo(ctx context.Context, bizStr string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeInfo", ctx, bizStr, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLikeInfo indicates an expected call of DeleteLikeInfo.
+func (mr *MockInteractiveDaoMockRecorder) DeleteLikeInfo(ctx, bizStr, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeInfo", reflect.TypeOf((*MockInteractiveDao)(nil).DeleteLikeInfo), ctx, bizStr, bizId, uid)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveDao) Get(ctx context.Context, bizStr string, bizId int64) (dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, bizStr, bizId)
+	ret0, _ := ret[0].(dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveDaoMockRecorder) Get(ctx, bizStr, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveDao)(nil).Get), ctx, bizStr, bizId)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractiveDao) GetByIds(ctx context.Context, bizStr string, ids []int64) ([]dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, bizStr, ids)
+	ret0, _ := ret[0].([]dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveDaoMockRecorder) GetByIds(ctx, bizStr, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveDao)(nil).GetByIds), ctx, bizStr, ids)
+}
+
+// GetCollectInfo mocks base method.
+func (m *MockInteractiveDao) GetCollectInfo(ctx context.Context, bizStr string, bizId, uid int64) (dao.UserCollectBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectInfo", ctx, bizStr, bizId, uid)
+	ret0, _ := ret[0].(dao.UserCollectBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectInfo indicates an expected call of GetCollectInfo.
+func (mr *MockInteractiveDaoMockRecorder) GetCollectInfo(ctx, bizStr, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectInfo", reflect.TypeOf((*MockInteractiveDao)(nil).GetCollectInfo), ctx, bizStr, bizId, uid)
+}
+
+// GetCollectInfos mocks base method.
+func (m *MockInteractiveDao) GetCollectInfos(ctx context.Context, bizStr string, ids []int64, uid int64) ([]dao.UserCollectBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectInfos", ctx, bizStr, ids, uid)
+	ret0, _ := ret[0].([]dao.UserCollectBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectInfos indicates an expected call of GetCollectInfos.
+func (mr *MockInteractiveDaoMockRecorder) GetCollectInfos(ctx, bizStr, ids, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectInfos", reflect.TypeOf((*MockInteractiveDao)(nil).GetCollectInfos), ctx, bizStr, ids, uid)
+}
+
+// GetLikeInfo mocks base method.
+func (m *MockInteractiveDao) GetLikeInfo(ctx context.Context, bizStr string, bizId, uid int64) (dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikeInfo", ctx, bizStr, bizId, uid)
+	ret0, _ := ret[0].(dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikeInfo indicates an expected call of GetLikeInfo.
+func (mr *MockInteractiveDaoMockRecorder) GetLikeInfo(ctx, bizStr, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeInfo", reflect.TypeOf((*MockInteractiveDao)(nil).GetLikeInfo), ctx, bizStr, bizId, uid)
+}
+
+// GetLikeInfos mocks base method.
+func (m *MockInteractiveDao) GetLikeInfos(ctx context.Context, bizStr string, ids []int64, uid int64) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikeInfos", ctx, bizStr, ids, uid)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikeInfos indicates an expected call of GetLikeInfos.
+func (mr *MockInteractiveDaoMockRecorder) GetLikeInfos(ctx, bizStr, ids, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeInfos", reflect.TypeOf((*MockInteractiveDao)(nil).GetLikeInfos), ctx, bizStr, ids, uid)
+}
+
+// IncrCommentCnt mocks base method.
+func (m *MockInteractiveDao) IncrCommentCnt(ctx context.Context, bizStr string, bizId, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCommentCnt", ctx, bizStr, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCommentCnt indicates an expected call of IncrCommentCnt.
+func (mr *MockInteractiveDaoMockRecorder) IncrCommentCnt(ctx, bizStr, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCommentCnt", reflect.TypeOf((*MockInteractiveDao)(nil).IncrCommentCnt), ctx, bizStr, bizId, delta)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractiveDao) IncrReadCnt(ctx context.Context, bizStr string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, bizStr, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractiveDaoMockRecorder) IncrReadCnt(ctx, bizStr, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveDao)(nil).IncrReadCnt), ctx, bizStr, bizId)
+}
+
+// InsertCollectBiz mocks base method.
+func (m *MockInteractiveDao) InsertCollectBiz(ctx context.Context, userCollectBiz dao.UserCollectBiz) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCollectBiz", ctx, userCollectBiz)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCollectBiz indicates an expected call of InsertCollectBiz.
+func (mr *MockInteractiveDaoMockRecorder) InsertCollectBiz(ctx, userCollectBiz any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCollectBiz", reflect.TypeOf((*MockInteractiveDao)(nil).InsertCollectBiz), ctx, userCollectBiz)
+}
+
+// InsertLikeInfo mocks base method.
+func (m *MockInteractiveDao) InsertLikeInfo(ctx context.Context, bizStr string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLikeInfo", ctx, bizStr, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertLikeInfo indicates an expected call of InsertLikeInfo.
+func (mr *MockInteractiveDaoMockRecorder) InsertLikeInfo(ctx, bizStr, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLikeInfo", reflect.TypeOf((*MockInteractiveDao)(nil).InsertLikeInfo), ctx, bizStr, bizId, uid)
+}
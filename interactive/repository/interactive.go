@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/misakimei123/redbook/interactive/domain"
 	"github.com/misakimei123/redbook/interactive/repository/cache"
@@ -15,11 +17,21 @@ type InteractiveRepository interface {
 	IncrLike(ctx context.Context, bizStr string, bizId int64, uid int64) error
 	DecrLike(ctx context.Context, bizStr string, bizId int64, uid int64) error
 	AddCollectItem(ctx context.Context, bizStr string, bizId, cid, uid int64) error
+	// DelCollectItem 没有收藏过直接返回
+	DelCollectItem(ctx context.Context, bizStr string, bizId, uid int64) error
 	Get(ctx context.Context, bizStr string, bizId int64) (domain.Interactive, error)
 	Collectd(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error)
 	Liked(ctx context.Context, bizStr string, bizId int64, uid int64) (bool, error)
-	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error
+	// BatchCollected 返回 ids 里面 uid 收藏过的
+	BatchCollected(ctx context.Context, bizStr string, ids []int64, uid int64) (map[int64]bool, error)
+	// BatchLiked 返回 ids 里面 uid 点过赞的
+	BatchLiked(ctx context.Context, bizStr string, ids []int64, uid int64) (map[int64]bool, error)
+	// BatchIncrReadCnt window 大于 0 的时候，同一个用户在 window 内重复阅读只算一次
+	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, uids []int64, window time.Duration) error
 	GetByIds(ctx context.Context, bizStr string, ids []int64) ([]domain.Interactive, error)
+	IncrCommentCnt(ctx context.Context, bizStr string, bizId int64, delta int64) error
+	// Preload 从数据库加载到缓存
+	Preload(ctx context.Context, bizStr string, ids []int64) error
 }
 
 func NewCachedInteractiveRepository(dao dao.InteractiveDao, cache cache.InteractiveCache) InteractiveRepository {
@@ -65,6 +77,30 @@ func (c *CachedInteractiveRepository) Liked(ctx context.Context, bizStr string,
 	}
 }
 
+func (c *CachedInteractiveRepository) BatchCollected(ctx context.Context, bizStr string, ids []int64, uid int64) (map[int64]bool, error) {
+	collects, err := c.dao.GetCollectInfos(ctx, bizStr, ids, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]bool, len(collects))
+	for _, collect := range collects {
+		res[collect.BizId] = true
+	}
+	return res, nil
+}
+
+func (c *CachedInteractiveRepository) BatchLiked(ctx context.Context, bizStr string, ids []int64, uid int64) (map[int64]bool, error) {
+	likes, err := c.dao.GetLikeInfos(ctx, bizStr, ids, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]bool, len(likes))
+	for _, like := range likes {
+		res[like.BizId] = true
+	}
+	return res, nil
+}
+
 func (c *CachedInteractiveRepository) Get(ctx context.Context, bizStr string, bizId int64) (domain.Interactive, error) {
 	intra, err := c.cache.Get(ctx, bizStr, bizId)
 	if err == nil {
@@ -84,30 +120,39 @@ func (c *CachedInteractiveRepository) Get(ctx context.Context, bizStr string, bi
 	return intra, nil
 }
 
+// AddCollectItem 已经收藏过的不会重复计数，下面几个也一样
 func (c *CachedInteractiveRepository) AddCollectItem(ctx context.Context, bizStr string, bizId, cid, uid int64) error {
-	err := c.dao.InsertCollectBiz(ctx, dao.UserCollectBiz{
+	changed, err := c.dao.InsertCollectBiz(ctx, dao.UserCollectBiz{
 		Uid:    uid,
 		BizId:  bizId,
 		BizStr: bizStr,
 		Cid:    cid,
 	})
-	if err != nil {
+	if err != nil || !changed {
 		return err
 	}
 	return c.cache.IncrCollectCntIfPresent(ctx, bizStr, bizId)
 }
 
+func (c *CachedInteractiveRepository) DelCollectItem(ctx context.Context, bizStr string, bizId, uid int64) error {
+	changed, err := c.dao.DeleteCollectBiz(ctx, bizStr, bizId, uid)
+	if err != nil || !changed {
+		return err
+	}
+	return c.cache.DecrCollectCntIfPresent(ctx, bizStr, bizId)
+}
+
 func (c *CachedInteractiveRepository) IncrLike(ctx context.Context, bizStr string, bizId int64, uid int64) error {
-	err := c.dao.InsertLikeInfo(ctx, bizStr, bizId, uid)
-	if err != nil {
+	changed, err := c.dao.InsertLikeInfo(ctx, bizStr, bizId, uid)
+	if err != nil || !changed {
 		return err
 	}
 	return c.cache.IncrLikeCntIfPresent(ctx, bizStr, bizId)
 }
 
 func (c *CachedInteractiveRepository) DecrLike(ctx context.Context, bizStr string, bizId int64, uid int64) error {
-	err := c.dao.DeleteLikeInfo(ctx, bizStr, bizId, uid)
-	if err != nil {
+	changed, err := c.dao.DeleteLikeInfo(ctx, bizStr, bizId, uid)
+	if err != nil || !changed {
 		return err
 	}
 	return c.cache.DecrLikeCntIfPresent(ctx, bizStr, bizId)
@@ -125,25 +170,104 @@ func (c *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, bizStr st
 	return nil
 }
 
-func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error {
-	err := c.dao.BatchIncrReadCnt(ctx, bizs, ids)
+func (c *CachedInteractiveRepository) IncrCommentCnt(ctx context.Context, bizStr string, bizId int64, delta int64) error {
+	err := c.dao.IncrCommentCnt(ctx, bizStr, bizId, delta)
 	if err != nil {
 		return err
 	}
-	for i, id := range ids {
-		er := c.cache.IncrReadCntIfPresent(ctx, bizs[i], id)
-		if er != nil {
-			//	TODO: record log
+	return c.cache.IncrCommentCntIfPresent(ctx, bizStr, bizId, delta)
+}
+
+// BatchIncrReadCnt 同一个资源的阅读先合并，热点文章一批里面只写一次。
+// 数据库写成功了才记录阅读，写失败了重试的时候还会重新计数
+func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64,
+	uids []int64, window time.Duration) error {
+	counted, newVisitors, err := c.cache.CheckReads(ctx, bizs, ids, uids, window)
+	if err != nil {
+		// 去重失败，宁可多算也不少算
+		counted = make([]bool, len(ids))
+		for i := range counted {
+			counted[i] = true
 		}
+		newVisitors = make([]bool, len(ids))
+	}
+	cntBizs, cntIds, deltas, uvDeltas := mergeReadCnt(bizs, ids, counted, newVisitors)
+	if len(cntIds) == 0 {
+		return nil
+	}
+	err = c.dao.BatchIncrReadCnt(ctx, cntBizs, cntIds, deltas, uvDeltas)
+	if err != nil {
+		return err
+	}
+	err = c.cache.MarkReads(ctx, bizs, ids, uids, window)
+	if err != nil {
+		//	TODO: record log
+	}
+	err = c.cache.BatchIncrReadCntIfPresent(ctx, cntBizs, cntIds, deltas, uvDeltas)
+	if err != nil {
+		//	TODO: record log
 	}
 	return nil
 }
 
+// mergeReadCnt 按照 (biz, bizId) 合并，跳过不计数的，结果有序，避免并发写的时候加锁顺序不一致导致死锁
+func mergeReadCnt(bizs []string, ids []int64, counted []bool, newVisitors []bool) ([]string, []int64, []int64, []int64) {
+	type bizKey struct {
+		biz string
+		id  int64
+	}
+	cnts := make(map[bizKey]int64, len(ids))
+	uvs := make(map[bizKey]int64, len(ids))
+	keys := make([]bizKey, 0, len(ids))
+	for i, id := range ids {
+		if !counted[i] && !newVisitors[i] {
+			continue
+		}
+		k := bizKey{biz: bizs[i], id: id}
+		if _, ok := cnts[k]; !ok {
+			keys = append(keys, k)
+		}
+		if counted[i] {
+			cnts[k]++
+		}
+		if newVisitors[i] {
+			uvs[k]++
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].biz != keys[j].biz {
+			return keys[i].biz < keys[j].biz
+		}
+		return keys[i].id < keys[j].id
+	})
+	resBizs := make([]string, 0, len(keys))
+	resIds := make([]int64, 0, len(keys))
+	deltas := make([]int64, 0, len(keys))
+	uvDeltas := make([]int64, 0, len(keys))
+	for _, k := range keys {
+		resBizs = append(resBizs, k.biz)
+		resIds = append(resIds, k.id)
+		deltas = append(deltas, cnts[k])
+		uvDeltas = append(uvDeltas, uvs[k])
+	}
+	return resBizs, resIds, deltas, uvDeltas
+}
+
+func (c *CachedInteractiveRepository) Preload(ctx context.Context, bizStr string, ids []int64) error {
+	intras, err := c.GetByIds(ctx, bizStr, ids)
+	if err != nil {
+		return err
+	}
+	return c.cache.BatchSet(ctx, bizStr, intras)
+}
+
 func (c *CachedInteractiveRepository) toDomain(intra dao.Interactive) domain.Interactive {
 	return domain.Interactive{
 		BizId:      intra.BizId,
 		ReadCnt:    intra.ReadCnt,
+		UvCnt:      intra.UvCnt,
 		LikeCnt:    intra.LikeCnt,
 		CollectCnt: intra.CollectCnt,
+		CommentCnt: intra.CommentCnt,
 	}
 }
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cachemocks "github.com/misakimei123/redbook/interactive/repository/cache/mocks"
+	daomocks "github.com/misakimei123/redbook/interactive/repository/dao/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeReadCnt(t *testing.T) {
+	testCases := []struct {
+		name        string
+		bizs        []string
+		ids         []int64
+		counted     []bool
+		newVisitors []bool
+
+		wantBizs     []string
+		wantIds      []int64
+		wantDeltas   []int64
+		wantUvDeltas []int64
+	}{
+		{
+			name:         "merge same article",
+			bizs:         []string{"article", "article", "article", "article"},
+			ids:          []int64{3, 1, 3, 3},
+			counted:      []bool{true, true, true, true},
+			newVisitors:  []bool{true, false, false, true},
+			wantBizs:     []string{"article", "article"},
+			wantIds:      []int64{1, 3},
+			wantDeltas:   []int64{1, 3},
+			wantUvDeltas: []int64{0, 2},
+		},
+		{
+			name:         "different biz",
+			bizs:         []string{"video", "article", "video"},
+			ids:          []int64{1, 1, 1},
+			counted:      []bool{true, true, true},
+			newVisitors:  []bool{false, false, false},
+			wantBizs:     []string{"article", "video"},
+			wantIds:      []int64{1, 1},
+			wantDeltas:   []int64{1, 2},
+			wantUvDeltas: []int64{0, 0},
+		},
+		{
+			name:         "skip dedup reads",
+			bizs:         []string{"article", "article", "article"},
+			ids:          []int64{1, 2, 1},
+			counted:      []bool{true, false, false},
+			newVisitors:  []bool{true, false, false},
+			wantBizs:     []string{"article"},
+			wantIds:      []int64{1},
+			wantDeltas:   []int64{1},
+			wantUvDeltas: []int64{1},
+		},
+		{
+			name:         "empty",
+			wantBizs:     []string{},
+			wantIds:      []int64{},
+			wantDeltas:   []int64{},
+			wantUvDeltas: []int64{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bizs, ids, deltas, uvDeltas := mergeReadCnt(tc.bizs, tc.ids, tc.counted, tc.newVisitors)
+			assert.Equal(t, tc.wantBizs, bizs)
+			assert.Equal(t, tc.wantIds, ids)
+			assert.Equal(t, tc.wantDeltas, deltas)
+			assert.Equal(t, tc.wantUvDeltas, uvDeltas)
+		})
+	}
+}
+
+// TestCachedInteractiveRepository_BatchIncrReadCnt 数据库写失败的时候不能记录阅读，不然重试的时候就不计数了
+func TestCachedInteractiveRepository_BatchIncrReadCnt(t *testing.T) {
+	bizs := []string{"article", "article"}
+	ids := []int64{1, 2}
+	uids := []int64{123, 123}
+	window := time.Minute
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := cachemocks.NewMockInteractiveCache(ctrl)
+	d := daomocks.NewMockInteractiveDao(ctrl)
+	dbErr := errors.New("mock db fail")
+	gomock.InOrder(
+		// 第一次数据库失败，没有 MarkReads
+		c.EXPECT().CheckReads(gomock.Any(), bizs, ids, uids, window).
+			Return([]bool{true, true}, []bool{true, false}, nil),
+		d.EXPECT().BatchIncrReadCnt(gomock.Any(), bizs, ids, []int64{1, 1}, []int64{1, 0}).
+			Return(dbErr),
+		// 重试的时候还是会计数
+		c.EXPECT().CheckReads(gomock.Any(), bizs, ids, uids, window).
+			Return([]bool{true, true}, []bool{true, false}, nil),
+		d.EXPECT().BatchIncrReadCnt(gomock.Any(), bizs, ids, []int64{1, 1}, []int64{1, 0}).
+			Return(nil),
+		c.EXPECT().MarkReads(gomock.Any(), bizs, ids, uids, window).Return(nil),
+		c.EXPECT().BatchIncrReadCntIfPresent(gomock.Any(), bizs, ids, []int64{1, 1}, []int64{1, 0}).
+			Return(nil),
+	)
+	repo := NewCachedInteractiveRepository(d, c)
+	err := repo.BatchIncrReadCnt(context.Background(), bizs, ids, uids, window)
+	assert.Equal(t, dbErr, err)
+	err = repo.BatchIncrReadCnt(context.Background(), bizs, ids, uids, window)
+	assert.NoError(t, err)
+}
@@ -15,24 +15,64 @@ type InteractiveService interface {
 	Like(ctx context.Context, like bool, bizStr string, bizId int64, uid int64) error
 	Collect(ctx context.Context, bizStr string, bizId, cid, uid int64) error
 	Get(ctx context.Context, bizStr string, bizId int64, uid int64) (domain.Interactive, error)
-	GetByIds(ctx context.Context, bizStr string, ids []int64) (map[int64]domain.Interactive, error)
+	// GetByIds uid 大于 0 的时候顺便查出这个用户有没有点赞、收藏
+	GetByIds(ctx context.Context, bizStr string, ids []int64, uid int64) (map[int64]domain.Interactive, error)
+	IncrCommentCnt(ctx context.Context, bizStr string, bizId int64, delta int64) error
+	Uncollect(ctx context.Context, bizStr string, bizId, uid int64) error
+	CreateCollection(ctx context.Context, uid int64, name string) (int64, error)
+	RenameCollection(ctx context.Context, id int64, uid int64, name string) error
+	DeleteCollection(ctx context.Context, id int64, uid int64) error
+	ListCollections(ctx context.Context, uid int64) ([]domain.Collection, error)
+	// ListCollectionItems cid 为 0 表示默认收藏夹
+	ListCollectionItems(ctx context.Context, uid int64, cid int64, lastId int64, limit int) ([]domain.CollectionItem, error)
+	// Preload 把计数提前加载到缓存
+	Preload(ctx context.Context, bizStr string, ids []int64) error
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository) InteractiveService {
-	return &interactiveService{repo: repo}
+// ErrCollectionNotFound 收藏夹不存在或者不是自己的
+var ErrCollectionNotFound = repository.ErrCollectionNotFound
+
+func NewInteractiveService(repo repository.InteractiveRepository, collectionRepo repository.CollectionRepository) InteractiveService {
+	return &interactiveService{repo: repo, collectionRepo: collectionRepo}
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
+	repo           repository.InteractiveRepository
+	collectionRepo repository.CollectionRepository
 }
 
-func (i *interactiveService) GetByIds(ctx context.Context, bizStr string, ids []int64) (map[int64]domain.Interactive, error) {
-	intras, err := i.repo.GetByIds(ctx, bizStr, ids)
+func (i *interactiveService) GetByIds(ctx context.Context, bizStr string, ids []int64, uid int64) (map[int64]domain.Interactive, error) {
+	var (
+		eg                  errgroup.Group
+		intras              []domain.Interactive
+		likedMap, collected map[int64]bool
+	)
+	eg.Go(func() error {
+		var er error
+		intras, er = i.repo.GetByIds(ctx, bizStr, ids)
+		return er
+	})
+	if uid > 0 {
+		eg.Go(func() error {
+			var er error
+			likedMap, er = i.repo.BatchLiked(ctx, bizStr, ids, uid)
+			return er
+		})
+		eg.Go(func() error {
+			var er error
+			collected, er = i.repo.BatchCollected(ctx, bizStr, ids, uid)
+			return er
+		})
+	}
+	err := eg.Wait()
 	if err != nil {
 		return nil, err
 	}
 	res := make(map[int64]domain.Interactive, len(intras))
 	for _, intra := range intras {
+		intra.Biz = bizStr
+		intra.Liked = likedMap[intra.BizId]
+		intra.Collected = collected[intra.BizId]
 		res[intra.BizId] = intra
 	}
 	return res, nil
@@ -63,9 +103,50 @@ func (i *interactiveService) Get(ctx context.Context, bizStr string, bizId int64
 }
 
 func (i *interactiveService) Collect(ctx context.Context, bizStr string, bizId, cid, uid int64) error {
+	err := i.checkCollection(ctx, cid, uid)
+	if err != nil {
+		return err
+	}
 	return i.repo.AddCollectItem(ctx, bizStr, bizId, cid, uid)
 }
 
+func (i *interactiveService) Uncollect(ctx context.Context, bizStr string, bizId, uid int64) error {
+	return i.repo.DelCollectItem(ctx, bizStr, bizId, uid)
+}
+
+func (i *interactiveService) CreateCollection(ctx context.Context, uid int64, name string) (int64, error) {
+	return i.collectionRepo.Create(ctx, domain.Collection{Uid: uid, Name: name})
+}
+
+func (i *interactiveService) RenameCollection(ctx context.Context, id int64, uid int64, name string) error {
+	return i.collectionRepo.Rename(ctx, id, uid, name)
+}
+
+func (i *interactiveService) DeleteCollection(ctx context.Context, id int64, uid int64) error {
+	return i.collectionRepo.Delete(ctx, id, uid)
+}
+
+func (i *interactiveService) ListCollections(ctx context.Context, uid int64) ([]domain.Collection, error) {
+	return i.collectionRepo.FindByUid(ctx, uid)
+}
+
+func (i *interactiveService) ListCollectionItems(ctx context.Context, uid int64, cid int64, lastId int64, limit int) ([]domain.CollectionItem, error) {
+	err := i.checkCollection(ctx, cid, uid)
+	if err != nil {
+		return nil, err
+	}
+	return i.collectionRepo.FindItems(ctx, uid, cid, lastId, limit)
+}
+
+// checkCollection 默认收藏夹不用查，其它的必须是自己的
+func (i *interactiveService) checkCollection(ctx context.Context, cid int64, uid int64) error {
+	if cid == 0 {
+		return nil
+	}
+	_, err := i.collectionRepo.FindById(ctx, cid, uid)
+	return err
+}
+
 func (i *interactiveService) Like(ctx context.Context, like bool, bizStr string, bizId int64, uid int64) error {
 	var err error
 	if like {
@@ -76,6 +157,14 @@ func (i *interactiveService) Like(ctx context.Context, like bool, bizStr string,
 	return err
 }
 
+func (i *interactiveService) Preload(ctx context.Context, bizStr string, ids []int64) error {
+	return i.repo.Preload(ctx, bizStr, ids)
+}
+
+func (i *interactiveService) IncrCommentCnt(ctx context.Context, bizStr string, bizId int64, delta int64) error {
+	return i.repo.IncrCommentCnt(ctx, bizStr, bizId, delta)
+}
+
 func (i *interactiveService) IncrReadCnt(ctx context.Context, bizStr string, bizId int64) error {
 	return i.repo.IncrReadCnt(ctx, bizStr, bizId)
 }
@@ -54,6 +54,35 @@ func (mr *MockInteractiveServiceMockRecorder) Collect(ctx, bizStr, bizId, cid, u
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveService)(nil).Collect), ctx, bizStr, bizId, cid, uid)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractiveService) CreateCollection(ctx context.Context, uid int64, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, uid, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractiveServiceMockRecorder) CreateCollection(ctx, uid, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractiveService)(nil).CreateCollection), ctx, uid, name)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractiveService) DeleteCollection(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractiveServiceMockRecorder) DeleteCollection(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractiveService)(nil).DeleteCollection), ctx, id, uid)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveService) Get(ctx context.Context, bizStr string, bizId, uid int64) (domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -70,18 +99,32 @@ func (mr *MockInteractiveServiceMockRecorder) Get(ctx, bizStr, bizId, uid any) *
 }
 
 // GetByIds mocks base method.
-func (m *MockInteractiveService) GetByIds(ctx context.Context, bizStr string, ids []int64) (map[int64]domain.Interactive, error) {
+func (m *MockInteractiveService) GetByIds(ctx context.Context, bizStr string, ids []int64, uid int64) (map[int64]domain.Interactive, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByIds", ctx, bizStr, ids)
+	ret := m.ctrl.Call(m, "GetByIds", ctx, bizStr, ids, uid)
 	ret0, _ := ret[0].(map[int64]domain.Interactive)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByIds indicates an expected call of GetByIds.
-func (mr *MockInteractiveServiceMockRecorder) GetByIds(ctx, bizStr, ids any) *gomock.Call {
+func (mr *MockInteractiveServiceMockRecorder) GetByIds(ctx, bizStr, ids, uid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetByIds), ctx, bizStr, ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetByIds), ctx, bizStr, ids, uid)
+}
+
+// IncrCommentCnt mocks base method.
+func (m *MockInteractiveService) IncrCommentCnt(ctx context.Context, bizStr string, bizId, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCommentCnt", ctx, bizStr, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCommentCnt indicates an expected call of IncrCommentCnt.
+func (mr *MockInteractiveServiceMockRecorder) IncrCommentCnt(ctx, bizStr, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCommentCnt", reflect.TypeOf((*MockInteractiveService)(nil).IncrCommentCnt), ctx, bizStr, bizId, delta)
 }
 
 // IncrReadCnt mocks base method.
@@ -111,3 +154,75 @@ func (mr *MockInteractiveServiceMockRecorder) Like(ctx, like, bizStr, bizId, uid
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, like, bizStr, bizId, uid)
 }
+
+// ListCollectionItems mocks base method.
+func (m *MockInteractiveService) ListCollectionItems(ctx context.Context, uid, cid, lastId int64, limit int) ([]domain.CollectionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionItems", ctx, uid, cid, lastId, limit)
+	ret0, _ := ret[0].([]domain.CollectionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractiveServiceMockRecorder) ListCollectionItems(ctx, uid, cid, lastId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractiveService)(nil).ListCollectionItems), ctx, uid, cid, lastId, limit)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractiveService) ListCollections(ctx context.Context, uid int64) ([]domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", ctx, uid)
+	ret0, _ := ret[0].([]domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractiveServiceMockRecorder) ListCollections(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractiveService)(nil).ListCollections), ctx, uid)
+}
+
+// Preload mocks base method.
+func (m *MockInteractiveService) Preload(ctx context.Context, bizStr string, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preload", ctx, bizStr, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Preload indicates an expected call of Preload.
+func (mr *MockInteractiveServiceMockRecorder) Preload(ctx, bizStr, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preload", reflect.TypeOf((*MockInteractiveService)(nil).Preload), ctx, bizStr, ids)
+}
+
+// RenameCollection mocks base method.
+func (m *MockInteractiveService) RenameCollection(ctx context.Context, id, uid int64, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCollection", ctx, id, uid, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameCollection indicates an expected call of RenameCollection.
+func (mr *MockInteractiveServiceMockRecorder) RenameCollection(ctx, id, uid, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCollection", reflect.TypeOf((*MockInteractiveService)(nil).RenameCollection), ctx, id, uid, name)
+}
+
+// Uncollect mocks base method.
+func (m *MockInteractiveService) Uncollect(ctx context.Context, bizStr string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uncollect", ctx, bizStr, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Uncollect indicates an expected call of Uncollect.
+func (mr *MockInteractiveServiceMockRecorder) Uncollect(ctx, bizStr, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uncollect", reflect.TypeOf((*MockInteractiveService)(nil).Uncollect), ctx, bizStr, bizId, uid)
+}
@@ -20,8 +20,10 @@ var (
 	)
 	interactiveSvcSet = wire.NewSet(
 		dao.NewInteractiveGormDao,
+		dao.NewGormCollectionDao,
 		cache.NewInteractiveRedisCache,
 		repository.NewCachedInteractiveRepository,
+		repository.NewCachedCollectionRepository,
 		service.NewInteractiveService,
 	)
 )
@@ -33,6 +35,7 @@ func InitApp() *App {
 		ioc.InitConsumers,
 		ioc.InitialInteractiveReadEventBatchConsumer,
 		grpc.NewInteractiveServiceServer,
+		ioc.InitJobExecutorServer,
 		ioc.NewGrpcxServer,
 		ioc.InitGinServer,
 		ioc.InitInteractiveProducer,
@@ -33,13 +33,16 @@ func InitApp() *App {
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDao, interactiveCache)
 	client := ioc.InitSaramaClient()
-	interactiveReadEventBatchConsumer := ioc.InitialInteractiveReadEventBatchConsumer(interactiveRepository, client, loggerV1)
+	syncProducer := ioc.InitSaramaSyncProducer(client)
+	interactiveReadEventBatchConsumer := ioc.InitialInteractiveReadEventBatchConsumer(interactiveRepository, client, syncProducer, loggerV1)
 	consumer := ioc.InitFixerConsumer(client, loggerV1, srcDB, dstDB)
 	v := ioc.InitConsumers(interactiveReadEventBatchConsumer, consumer)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	collectionDao := dao.NewGormCollectionDao(db)
+	collectionRepository := repository.NewCachedCollectionRepository(collectionDao, interactiveCache)
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
-	server := ioc.NewGrpcxServer(interactiveServiceServer, loggerV1)
-	syncProducer := ioc.InitSaramaSyncProducer(client)
+	jobExecutorServer := ioc.InitJobExecutorServer()
+	server := ioc.NewGrpcxServer(interactiveServiceServer, jobExecutorServer, loggerV1)
 	producer := ioc.InitInteractiveProducer(syncProducer)
 	engine := ioc.InitGinServer(loggerV1, srcDB, dstDB, doubleWritePool, producer)
 	app := &App{
@@ -54,5 +57,5 @@ func InitApp() *App {
 
 var (
 	thirdPartySet     = wire.NewSet(ioc.InitRedis, ioc.InitialLogger, ioc.InitSaramaClient, ioc.InitSaramaSyncProducer, ioc.InitSrcDB, ioc.InitDstDB, ioc.InitDoubleWritePool, ioc.InitBizDB)
-	interactiveSvcSet = wire.NewSet(dao.NewInteractiveGormDao, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, service.NewInteractiveService)
+	interactiveSvcSet = wire.NewSet(dao.NewInteractiveGormDao, dao.NewGormCollectionDao, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, repository.NewCachedCollectionRepository, service.NewInteractiveService)
 )
@@ -39,6 +39,38 @@ func (i *InteractiveClient) GetByIds(ctx context.Context, in *intrv1.GetByIdsReq
 	return i.selectClient().GetByIds(ctx, in, opts...)
 }
 
+func (i *InteractiveClient) IncrCommentCnt(ctx context.Context, in *intrv1.IncrCommentCntRequest, opts ...grpc.CallOption) (*intrv1.IncrCommentCntResponse, error) {
+	return i.selectClient().IncrCommentCnt(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) Uncollect(ctx context.Context, in *intrv1.UncollectRequest, opts ...grpc.CallOption) (*intrv1.UncollectResponse, error) {
+	return i.selectClient().Uncollect(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	return i.selectClient().CreateCollection(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) RenameCollection(ctx context.Context, in *intrv1.RenameCollectionRequest, opts ...grpc.CallOption) (*intrv1.RenameCollectionResponse, error) {
+	return i.selectClient().RenameCollection(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	return i.selectClient().DeleteCollection(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	return i.selectClient().ListCollections(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	return i.selectClient().ListCollectionItems(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) Preload(ctx context.Context, in *intrv1.PreloadRequest, opts ...grpc.CallOption) (*intrv1.PreloadResponse, error) {
+	return i.selectClient().Preload(ctx, in, opts...)
+}
+
 func (i *InteractiveClient) selectClient() intrv1.InteractiveServiceClient {
 	num := rand.Int31n(100)
 	// threshold default 0
@@ -2,11 +2,15 @@ package client
 
 import (
 	"context"
+	"errors"
 
+	"github.com/ecodeclub/ekit/slice"
 	intrv1 "github.com/misakimei123/redbook/api/proto/gen/intr/v1"
 	"github.com/misakimei123/redbook/interactive/domain"
 	"github.com/misakimei123/redbook/interactive/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type LocalInteractiveServiceAdapter struct {
@@ -29,7 +33,10 @@ func (l *LocalInteractiveServiceAdapter) Like(ctx context.Context, in *intrv1.Li
 
 func (l *LocalInteractiveServiceAdapter) Collect(ctx context.Context, in *intrv1.CollectRequest, opts ...grpc.CallOption) (*intrv1.CollectResponse, error) {
 	err := l.svc.Collect(ctx, in.GetBizStr(), in.GetBizId(), in.GetCid(), in.GetUid())
-	return &intrv1.CollectResponse{}, err
+	if err != nil {
+		return nil, l.toStatus(err)
+	}
+	return &intrv1.CollectResponse{}, nil
 }
 
 func (l *LocalInteractiveServiceAdapter) Get(ctx context.Context, in *intrv1.GetRequest, opts ...grpc.CallOption) (*intrv1.GetResponse, error) {
@@ -41,7 +48,7 @@ func (l *LocalInteractiveServiceAdapter) Get(ctx context.Context, in *intrv1.Get
 }
 
 func (l *LocalInteractiveServiceAdapter) GetByIds(ctx context.Context, in *intrv1.GetByIdsRequest, opts ...grpc.CallOption) (*intrv1.GetByIdsResponse, error) {
-	resp, err := l.svc.GetByIds(ctx, in.GetBizStr(), in.GetIds())
+	resp, err := l.svc.GetByIds(ctx, in.GetBizStr(), in.GetIds(), in.GetUid())
 	if err != nil {
 		return nil, err
 	}
@@ -52,13 +59,94 @@ func (l *LocalInteractiveServiceAdapter) GetByIds(ctx context.Context, in *intrv
 	return &intrv1.GetByIdsResponse{Interacs: intrs}, nil
 }
 
+func (l *LocalInteractiveServiceAdapter) IncrCommentCnt(ctx context.Context, in *intrv1.IncrCommentCntRequest, opts ...grpc.CallOption) (*intrv1.IncrCommentCntResponse, error) {
+	err := l.svc.IncrCommentCnt(ctx, in.GetBizStr(), in.GetBizId(), in.GetDelta())
+	return &intrv1.IncrCommentCntResponse{}, err
+}
+
+func (l *LocalInteractiveServiceAdapter) Uncollect(ctx context.Context, in *intrv1.UncollectRequest, opts ...grpc.CallOption) (*intrv1.UncollectResponse, error) {
+	err := l.svc.Uncollect(ctx, in.GetBizStr(), in.GetBizId(), in.GetUid())
+	return &intrv1.UncollectResponse{}, err
+}
+
+func (l *LocalInteractiveServiceAdapter) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	id, err := l.svc.CreateCollection(ctx, in.GetUid(), in.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (l *LocalInteractiveServiceAdapter) RenameCollection(ctx context.Context, in *intrv1.RenameCollectionRequest, opts ...grpc.CallOption) (*intrv1.RenameCollectionResponse, error) {
+	err := l.svc.RenameCollection(ctx, in.GetId(), in.GetUid(), in.GetName())
+	if err != nil {
+		return nil, l.toStatus(err)
+	}
+	return &intrv1.RenameCollectionResponse{}, nil
+}
+
+func (l *LocalInteractiveServiceAdapter) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	err := l.svc.DeleteCollection(ctx, in.GetId(), in.GetUid())
+	if err != nil {
+		return nil, l.toStatus(err)
+	}
+	return &intrv1.DeleteCollectionResponse{}, nil
+}
+
+func (l *LocalInteractiveServiceAdapter) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	collections, err := l.svc.ListCollections(ctx, in.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.ListCollectionsResponse{Collections: slice.Map(collections, func(idx int, src domain.Collection) *intrv1.Collection {
+		return &intrv1.Collection{
+			Id:    src.Id,
+			Uid:   src.Uid,
+			Name:  src.Name,
+			Ctime: src.Ctime.UnixMilli(),
+			Utime: src.Utime.UnixMilli(),
+		}
+	})}, nil
+}
+
+func (l *LocalInteractiveServiceAdapter) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	items, err := l.svc.ListCollectionItems(ctx, in.GetUid(), in.GetCid(), in.GetLastId(), int(in.GetLimit()))
+	if err != nil {
+		return nil, l.toStatus(err)
+	}
+	return &intrv1.ListCollectionItemsResponse{Items: slice.Map(items, func(idx int, src domain.CollectionItem) *intrv1.CollectionItem {
+		return &intrv1.CollectionItem{
+			Id:     src.Id,
+			Cid:    src.Cid,
+			BizStr: src.Biz,
+			BizId:  src.BizId,
+			Ctime:  src.Ctime.UnixMilli(),
+		}
+	})}, nil
+}
+
+func (l *LocalInteractiveServiceAdapter) Preload(ctx context.Context, in *intrv1.PreloadRequest, opts ...grpc.CallOption) (*intrv1.PreloadResponse, error) {
+	err := l.svc.Preload(ctx, in.GetBizStr(), in.GetIds())
+	return &intrv1.PreloadResponse{}, err
+}
+
+// toStatus 收藏夹不存在转成 NotFound，调用方据此区分业务错误
+func (l *LocalInteractiveServiceAdapter) toStatus(err error) error {
+	if errors.Is(err, service.ErrCollectionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
 func (l *LocalInteractiveServiceAdapter) toDTO(interactive domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
 		BizStr:     interactive.Biz,
 		BizId:      interactive.BizId,
 		ReadCnt:    interactive.ReadCnt,
+		UvCnt:      interactive.UvCnt,
 		LikeCnt:    interactive.LikeCnt,
 		CollectCnt: interactive.CollectCnt,
+		CommentCnt: interactive.CommentCnt,
 		Liked:      interactive.Liked,
 		Collected:  interactive.Collected,
 	}
@@ -1,6 +1,13 @@
 package domain
 
-import "time"
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 type Article struct {
 	Id      int64
@@ -8,6 +15,7 @@ type Article struct {
 	Content string
 	Author  Author
 	Status  ArticleStatus
+	Tags    []string
 	Ctime   time.Time
 	Utime   time.Time
 }
@@ -20,6 +28,11 @@ func (a *Article) Abstract() string {
 	return string(str)
 }
 
+// Cursor 返回以当前文章为最后一条时，下一页的游标
+func (a *Article) Cursor() Cursor {
+	return Cursor{Utime: a.Utime.UnixMilli(), Id: a.Id}
+}
+
 type ArticleStatus uint8
 
 func (s ArticleStatus) ToInt() uint8 {
@@ -37,3 +50,80 @@ type Author struct {
 	Id   int64
 	Name string
 }
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidTags   = errors.New("invalid tags")
+)
+
+const (
+	maxTagCnt = 10
+	maxTagLen = 32
+)
+
+// NormalizeTags 去掉首尾空白、转小写并去重，顺序不变，没有标签时返回 nil
+func NormalizeTags(tags []string) ([]string, error) {
+	var res []string
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLen {
+			return nil, ErrInvalidTags
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+	if len(res) > maxTagCnt {
+		return nil, ErrInvalidTags
+	}
+	return res, nil
+}
+
+// Cursor 按 (utime, id) 倒序翻页的位置，零值表示从第一页开始
+type Cursor struct {
+	Utime int64
+	Id    int64
+}
+
+func (c Cursor) IsZero() bool {
+	return c.Utime == 0 && c.Id == 0
+}
+
+// Encode 编码成对外暴露的不透明 token
+func (c Cursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := strconv.FormatInt(c.Utime, 10) + ":" + strconv.FormatInt(c.Id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor 解析 Encode 生成的 token，空字符串表示第一页
+func DecodeCursor(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	utimeStr, idStr, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	utime, err := strconv.ParseInt(utimeStr, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{Utime: utime, Id: id}, nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/misakimei123/redbook/pkg/textdiff"
+)
+
+// ArticleRevision 每次保存或者发表时文章的快照
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	AuthorId  int64
+	Title     string
+	Content   string
+	Status    ArticleStatus
+	Tags      []string
+	Ctime     time.Time
+}
+
+func (r *ArticleRevision) Cursor() Cursor {
+	return Cursor{Utime: r.Ctime.UnixMilli(), Id: r.Id}
+}
+
+// ArticleRevisionDiff 从 From 到 To 的逐行差异
+type ArticleRevisionDiff struct {
+	From    ArticleRevision
+	To      ArticleRevision
+	Title   []textdiff.Line
+	Content []textdiff.Line
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Comment 和 interactive 一样用 Biz + BizId 标记评论的对象。
+// 只有两层：根评论的 RootId 是 0，所有回复都挂在根评论下面，ParentId 是直接回复的那条
+type Comment struct {
+	Id       int64
+	Uid      int64
+	Biz      string
+	BizId    int64
+	RootId   int64
+	ParentId int64
+	Content  string
+	// ReplyCnt 和 Replies 只有列根评论的时候才会填
+	ReplyCnt int64
+	Replies  []Comment
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+func (c *Comment) IsRoot() bool {
+	return c.RootId == 0
+}
+
+func (c *Comment) Cursor() Cursor {
+	return Cursor{Utime: c.Ctime.UnixMilli(), Id: c.Id}
+}
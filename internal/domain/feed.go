@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// FeedItem 关注流里面的一篇文章，Ctime 是发表时间
+type FeedItem struct {
+	Aid      int64
+	AuthorId int64
+	Title    string
+	Abstract string
+	Ctime    time.Time
+}
+
+func (f *FeedItem) Cursor() Cursor {
+	return Cursor{Utime: f.Ctime.UnixMilli(), Id: f.Aid}
+}
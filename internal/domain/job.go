@@ -1,11 +1,15 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom |
+	cron.Month | cron.Dow | cron.Descriptor)
+
 type Job struct {
 	Id         int64
 	CancelFunc func()
@@ -13,11 +17,158 @@ type Job struct {
 	Executor   string
 	// job type
 	Name string
+	// Cfg 执行器自己解析的参数
+	Cfg    string
+	Status JobStatus
+	// NextExecTime 下一次执行的时间，查询任务列表的时候有。
+	// 抢占到的任务里面是这一次安排的执行时间，用来发现执行期间任务被重新安排了
+	NextExecTime time.Time
+	// Owner 正在执行这个任务的节点，没有在执行就是空
+	Owner string
+	// Attempts 连续失败的次数，成功之后清零
+	Attempts int
+	// Version 抢占之后的版本号，和 Owner 一起用来续约和释放
+	Version int
+	Utime   time.Time
 }
 
+// NextTime 没有 cron 表达式的是一次性任务，返回零值
 func (j *Job) NextTime() time.Time {
-	parser := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom |
-		cron.Month | cron.Dow | cron.Descriptor)
-	s, _ := parser.Parse(j.Expression)
+	if j.Expression == "" {
+		return time.Time{}
+	}
+	s, err := cronParser.Parse(j.Expression)
+	if err != nil {
+		return time.Time{}
+	}
 	return s.Next(time.Now())
 }
+
+// CheckExpression 校验 cron 表达式，带秒
+func (j *Job) CheckExpression() error {
+	_, err := cronParser.Parse(j.Expression)
+	return err
+}
+
+// RetryPolicy 从 Cfg 的 retry 字段里面解析，没有配置就不重试
+// 比如 {"retry": {"maxAttempts": 3, "backoff": "10s", "maxBackoff": "1m"}}
+func (j *Job) RetryPolicy() JobRetryPolicy {
+	var cfg struct {
+		Retry *JobRetryCfg `json:"retry"`
+	}
+	policy := JobRetryPolicy{MaxAttempts: 1, Backoff: defaultJobBackoff}
+	if json.Unmarshal([]byte(j.Cfg), &cfg) != nil || cfg.Retry == nil {
+		return policy
+	}
+	if cfg.Retry.MaxAttempts > 1 {
+		policy.MaxAttempts = cfg.Retry.MaxAttempts
+	}
+	if backoff, err := time.ParseDuration(cfg.Retry.Backoff); err == nil && backoff > 0 {
+		policy.Backoff = backoff
+	}
+	if maxBackoff, err := time.ParseDuration(cfg.Retry.MaxBackoff); err == nil && maxBackoff > 0 {
+		policy.MaxBackoff = maxBackoff
+	}
+	return policy
+}
+
+const defaultJobBackoff = time.Second * 10
+
+// JobRetryCfg Cfg 里面 retry 字段的格式，时间用 time.ParseDuration 的格式
+type JobRetryCfg struct {
+	MaxAttempts int    `json:"maxAttempts"`
+	Backoff     string `json:"backoff"`
+	MaxBackoff  string `json:"maxBackoff"`
+}
+
+// JobRetryPolicy MaxAttempts 包含第一次执行，MaxBackoff 为 0 表示不限制
+type JobRetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// NextBackoff 第 attempts 次失败之后等多久，每次翻倍
+func (p JobRetryPolicy) NextBackoff(attempts int) time.Duration {
+	backoff := p.Backoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+type JobStatus uint8
+
+func (s JobStatus) ToInt() uint8 {
+	return uint8(s)
+}
+
+func (s JobStatus) String() string {
+	switch s {
+	case JobStatusWaiting:
+		return "waiting"
+	case JobStatusRunning:
+		return "running"
+	case JobStatusPaused:
+		return "paused"
+	case JobStatusFinished:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// 和 dao 里面的状态一一对应
+const (
+	JobStatusWaiting JobStatus = iota
+	JobStatusRunning
+	JobStatusPaused
+	JobStatusFinished
+)
+
+// JobExecution 任务的一次执行记录
+type JobExecution struct {
+	Id      int64
+	JobId   int64
+	JobName string
+	// Node 执行任务的节点
+	Node string
+	// Attempt 第几次尝试，从 1 开始
+	Attempt int
+	Status  JobExecutionStatus
+	Error   string
+	Start   time.Time
+	End     time.Time
+}
+
+type JobExecutionStatus uint8
+
+func (s JobExecutionStatus) ToInt() uint8 {
+	return uint8(s)
+}
+
+func (s JobExecutionStatus) String() string {
+	switch s {
+	case JobExecutionStatusRunning:
+		return "running"
+	case JobExecutionStatusSuccess:
+		return "success"
+	case JobExecutionStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	JobExecutionStatusUnknown JobExecutionStatus = iota
+	JobExecutionStatusRunning
+	JobExecutionStatusSuccess
+	JobExecutionStatusFailed
+)
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// RankingSnapshot 榜单某一天的快照，同一天算了多次只保留最后一次
+type RankingSnapshot struct {
+	Board    string
+	Date     time.Time
+	Articles []Article
+}
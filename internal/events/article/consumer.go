@@ -0,0 +1,61 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/misakimei123/redbook/pkg/saramax"
+)
+
+// SyncEventHandler 订阅方的处理逻辑，返回 error 会按 saramax 的策略重试
+type SyncEventHandler func(ctx context.Context, event SyncEvent) error
+
+// SyncEventConsumer 通用的文章事件消费者，搜索、关注流这些订阅方各用自己的 group，
+// 互不影响消费进度。版本不认识的消息直接跳过
+type SyncEventConsumer struct {
+	client  sarama.Client
+	group   string
+	handler SyncEventHandler
+	l       logger.LoggerV1
+	timeout time.Duration
+}
+
+func NewSyncEventConsumer(client sarama.Client, group string, timeout time.Duration,
+	handler SyncEventHandler, l logger.LoggerV1) *SyncEventConsumer {
+	return &SyncEventConsumer{
+		client:  client,
+		group:   group,
+		handler: handler,
+		l:       l,
+		timeout: timeout,
+	}
+}
+
+func (c *SyncEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient(c.group, c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(context.Background(), []string{TopicSyncEvent}, saramax.NewHandler[SyncEvent](c.l, c.Consume))
+		if er != nil {
+			c.l.Error("consume fail", logger.String("group", c.group), logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+func (c *SyncEventConsumer) Consume(msg *sarama.ConsumerMessage, event SyncEvent) error {
+	if event.Version > SyncEventVersion {
+		c.l.Warn("skip unsupported article sync event",
+			logger.String("group", c.group),
+			logger.Int64("aid", event.Aid),
+			logger.Int64("version", int64(event.Version)))
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	return c.handler(ctx, event)
+}
@@ -0,0 +1,58 @@
+package article
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncEventConsumer_Consume(t *testing.T) {
+	testCases := []struct {
+		name     string
+		event    SyncEvent
+		wantCall bool
+		wantType string
+	}{
+		{
+			name:     "updated",
+			event:    SyncEvent{Version: SyncEventVersion, Type: SyncTypeUpdated, Aid: 1},
+			wantCall: true,
+			wantType: SyncTypeUpdated,
+		},
+		{
+			name:     "legacy published",
+			event:    SyncEvent{Aid: 1, Status: domain.ArticleStatusPublished},
+			wantCall: true,
+			wantType: SyncTypePublished,
+		},
+		{
+			name:     "legacy withdrawn",
+			event:    SyncEvent{Aid: 1, Status: domain.ArticleStatusPrivate},
+			wantCall: true,
+			wantType: SyncTypeWithdrawn,
+		},
+		{
+			name:  "unsupported version",
+			event: SyncEvent{Version: SyncEventVersion + 1, Type: SyncTypePublished, Aid: 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotType string
+			called := false
+			c := NewSyncEventConsumer(nil, "test", time.Second, func(ctx context.Context, event SyncEvent) error {
+				called = true
+				gotType = event.SyncType()
+				return nil
+			}, logger.NewNopLogger())
+			err := c.Consume(nil, tc.event)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantCall, called)
+			assert.Equal(t, tc.wantType, gotType)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/events/article/producer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/events/article/producer.go -package=articlemocks -destination=./internal/events/article/mocks/producer.mock.go
+//
+
+// Package articlemocks is a generated GoMock package.
+package articlemocks
+
+import (
+	reflect "reflect"
+
+	article "github.com/misakimei123/redbook/internal/events/article"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// ProduceReadEvent mocks base method.
+func (m *MockProducer) ProduceReadEvent(event article.ReadEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceReadEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceReadEvent indicates an expected call of ProduceReadEvent.
+func (mr *MockProducerMockRecorder) ProduceReadEvent(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceReadEvent", reflect.TypeOf((*MockProducer)(nil).ProduceReadEvent), event)
+}
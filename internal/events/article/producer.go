@@ -2,12 +2,30 @@ package article
 
 import (
 	"encoding/json"
+
 	"github.com/IBM/sarama"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/pkg/outbox"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const TopicReadEvent = "article_read"
+const (
+	TopicReadEvent = "article_read"
+	// TopicSyncEvent 文章生命周期的变化，发表、修改草稿、撤回都会发
+	TopicSyncEvent = "article_sync"
+)
+
+// SyncEventVersion 当前 SyncEvent 的版本，字段有不兼容的变化时加一
+const SyncEventVersion = 1
+
+const (
+	SyncTypePublished = "published"
+	// SyncTypeUpdated 只是草稿改了，线上库没有变化
+	SyncTypeUpdated   = "updated"
+	SyncTypeWithdrawn = "withdrawn"
+)
 
+// Producer 只发阅读事件，发表、撤回这些事件由 dao 在文章的事务里面写进 outbox
 type Producer interface {
 	ProduceReadEvent(event ReadEvent) error
 }
@@ -17,6 +35,31 @@ type ReadEvent struct {
 	Uid int64
 }
 
+// SyncEvent 文章的变化，Version 为 0 的是加版本之前的老消息，只有发表和撤回，按 Status 区分
+type SyncEvent struct {
+	Version int
+	Type    string
+	Aid     int64
+	Uid     int64
+	Title   string
+	Content string
+	Status  uint8
+	Tags    []string
+	// Utime 文章的更新时间，毫秒，消费者用来丢弃乱序的旧消息
+	Utime int64
+}
+
+// SyncType 老消息没有 Type，按 Status 补上
+func (e SyncEvent) SyncType() string {
+	if e.Type != "" {
+		return e.Type
+	}
+	if e.Status == domain.ArticleStatusPublished {
+		return SyncTypePublished
+	}
+	return SyncTypeWithdrawn
+}
+
 func NewSaramaSyncProducer(producer sarama.SyncProducer,
 	opts prometheus.GaugeOpts,
 ) Producer {
@@ -33,18 +76,37 @@ type SaramaSyncProducer struct {
 }
 
 func (s *SaramaSyncProducer) ProduceReadEvent(event ReadEvent) error {
+	return s.produce(TopicReadEvent, nil, event)
+}
+
+func (s *SaramaSyncProducer) produce(topic string, key sarama.Encoder, event any) error {
 	val, err := json.Marshal(event)
 	if err != nil {
 		return err
 	}
 	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
-		Topic: TopicReadEvent,
+		Topic: topic,
+		Key:   key,
 		Value: sarama.StringEncoder(val),
 	})
 	if err != nil {
-		s.vector.WithLabelValues(TopicReadEvent, err.Error()).Inc()
+		s.vector.WithLabelValues(topic, err.Error()).Inc()
 	} else {
-		s.vector.WithLabelValues(TopicReadEvent, "success").Inc()
+		s.vector.WithLabelValues(topic, "success").Inc()
 	}
 	return err
 }
+
+// OutboxProducer 阅读事件攒批写进 outbox，由 outbox.Relay 投递到 kafka，
+// 不阻塞读请求，kafka 挂了也不会丢
+type OutboxProducer struct {
+	writer *outbox.BatchWriter
+}
+
+func NewOutboxProducer(writer *outbox.BatchWriter) Producer {
+	return &OutboxProducer{writer: writer}
+}
+
+func (o *OutboxProducer) ProduceReadEvent(event ReadEvent) error {
+	return o.writer.Write(TopicReadEvent, "", event)
+}
@@ -0,0 +1,46 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/events/article"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+// SyncEventConsumer 发表的时候写关注流，撤回的时候删掉
+type SyncEventConsumer struct {
+	svc    service.FeedService
+	client sarama.Client
+	l      logger.LoggerV1
+}
+
+func NewSyncEventConsumer(svc service.FeedService, client sarama.Client, l logger.LoggerV1) *SyncEventConsumer {
+	return &SyncEventConsumer{svc: svc, client: client, l: l}
+}
+
+// Start 推给粉丝要分批写，给的时间长一点
+func (c *SyncEventConsumer) Start() error {
+	return article.NewSyncEventConsumer(c.client, "feed", time.Second*10, c.handle, c.l).Start()
+}
+
+func (c *SyncEventConsumer) handle(ctx context.Context, event article.SyncEvent) error {
+	switch event.SyncType() {
+	case article.SyncTypeUpdated:
+		// 草稿改了，已经推出去的还是线上的版本
+		return nil
+	case article.SyncTypeWithdrawn:
+		return c.svc.Withdraw(ctx, event.Aid)
+	}
+	art := domain.Article{Content: event.Content}
+	return c.svc.Publish(ctx, domain.FeedItem{
+		Aid:      event.Aid,
+		AuthorId: event.Uid,
+		Title:    event.Title,
+		Abstract: art.Abstract(),
+		Ctime:    time.UnixMilli(event.Utime),
+	})
+}
@@ -0,0 +1,62 @@
+package search
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/events/article"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+// SyncEventConsumer 索引在进程内，每个节点都要收到全部消息，所以每个节点用自己的消费组
+type SyncEventConsumer struct {
+	svc    service.SearchService
+	client sarama.Client
+	l      logger.LoggerV1
+	group  string
+}
+
+// NewSyncEventConsumer 消费组按主机名固定下来，重启之后接着上次的进度消费，不会留下一堆没用的消费组
+func NewSyncEventConsumer(svc service.SearchService, client sarama.Client, l logger.LoggerV1) *SyncEventConsumer {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = uuid.New().String()
+	}
+	return &SyncEventConsumer{svc: svc, client: client, l: l, group: "search_" + hostname}
+}
+
+// Start 先开始消费再全量加载，加载期间的消息靠版本号去重
+func (c *SyncEventConsumer) Start() error {
+	err := article.NewSyncEventConsumer(c.client, c.group, time.Second, c.handle, c.l).Start()
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := c.svc.Rebuild(context.Background())
+		if er != nil {
+			c.l.Error("rebuild search index fail", logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+// handle 草稿的修改不影响线上的文章，不用重建索引
+func (c *SyncEventConsumer) handle(ctx context.Context, event article.SyncEvent) error {
+	if event.SyncType() == article.SyncTypeUpdated {
+		return nil
+	}
+	return c.svc.Index(ctx, domain.Article{
+		Id:      event.Aid,
+		Title:   event.Title,
+		Content: event.Content,
+		Author:  domain.Author{Id: event.Uid},
+		Status:  domain.ArticleStatus(event.Status),
+		Tags:    event.Tags,
+		Utime:   time.UnixMilli(event.Utime),
+	})
+}
@@ -103,6 +103,43 @@ func (s *SchedulerTestSuite) TestSchedule() {
 	}
 }
 
+// 执行期间重新安排的一次性任务，这一次执行结束之后不能被结束掉，要按新的时间再执行
+func (s *SchedulerTestSuite) TestRescheduleWhileRunning() {
+	t := s.T()
+	ctx := context.Background()
+	err := s.db.Exec("TRUNCATE TABLE `jobs`").Error
+	assert.NoError(t, err)
+	jobDao := dao.NewGormJobDao(s.db)
+	now := time.Now()
+	err = jobDao.Insert(ctx, dao.Job{
+		Executor: "local",
+		Name:     "reschedule_job",
+		NextTime: now.Add(-time.Second).UnixMilli(),
+	})
+	assert.NoError(t, err)
+
+	lease, err := jobDao.Preempt(ctx, "node-1")
+	assert.NoError(t, err)
+
+	next := now.Add(time.Hour).UnixMilli()
+	err = jobDao.Upsert(ctx, dao.Job{Executor: "local", Name: "reschedule_job", NextTime: next})
+	assert.NoError(t, err)
+
+	err = jobDao.Finish(ctx, lease.Id, lease.Owner, lease.Version, lease.NextTime)
+	assert.Equal(t, dao.ErrJobLost, err)
+	err = jobDao.Release(ctx, lease.Id, lease.Owner, lease.Version)
+	assert.NoError(t, err)
+
+	j, err := jobDao.FindByName(ctx, "reschedule_job")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.JobStatusWaiting.ToInt(), j.Status)
+	assert.Equal(t, next, j.NextTime)
+	assert.Equal(t, "", j.Owner)
+
+	err = s.db.Exec("TRUNCATE TABLE `jobs`").Error
+	assert.NoError(t, err)
+}
+
 type testJob struct {
 	cnt int
 }
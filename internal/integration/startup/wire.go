@@ -26,13 +26,16 @@ var (
 		InitRedis, InitDB, InitLogger, InitSaramaClient, InitialProducer)
 	interactiveSvcSet = wire.NewSet(
 		dao2.NewInteractiveGormDao,
+		dao2.NewGormCollectionDao,
 		cache2.NewInteractiveRedisCache,
 		repository2.NewCachedInteractiveRepository,
+		repository2.NewCachedCollectionRepository,
 		service2.NewInteractiveService,
 	)
 
 	jobSet = wire.NewSet(
 		dao.NewGormJobDao,
+		dao.NewGormJobExecutionDao,
 		repository.NewPreemptJobRepository,
 		service.NewCronJobService,
 	)
@@ -46,14 +49,21 @@ func InitWebServer() *gin.Engine {
 		dao.NewGormUserDao, dao.NewGormProfileDao, dao.NewArticleGormDao,
 		cache.NewRedisUserCache, code.NewRedisCodeCache, cache.NewArticleRedisCache, cache.NewRedisRankingCache,
 		repository.NewCacheUserRepository, repository.NewCacheCodeRepository, repository.NewCachedArticleRepository,
-		repository.NewCachedRankingRepository,
+		dao.NewGormRankingDao, repository.NewCachedRankingRepository,
 		ioc.InitSMSService,
 		service.NewUserService, service.NewCodeService, service.NewArticleService, service.NewArticleRankingService,
-		interactiveSvcSet,
+		jobSet, web.NewJobHandler,
 		jwt.NewRedisJWTHandler, ginadaptor.NewLogContextBuilder,
 		web.NewArticleHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewUserHandler,
+		service.NewArticleSearchService,
+		web.NewSearchHandler,
+		dao.NewGormCommentDao, repository.NewDaoCommentRepository, service.NewCommentService,
+		web.NewCommentHandler,
+		web.NewCollectionHandler,
+		ioc.InitFollowClient, web.NewFollowHandler,
+		dao.NewGormFeedDao, repository.NewDaoFeedRepository, service.NewFeedService, web.NewFeedHandler,
 		ioc.InitGinMiddlewares,
 		ioc.InitWebServer,
 		ioc.InitIntrClientV1,
@@ -68,9 +78,10 @@ func InitArticleHandler(articleDao dao.ArticleDao) *web.ArticleHandler {
 		InitialSaramaSyncProducer,
 		dao.NewGormUserDao, dao.NewGormProfileDao,
 		cache.NewRedisUserCache, cache.NewArticleRedisCache, cache.NewRedisRankingCache,
-		repository.NewCacheUserRepository, repository.NewCachedArticleRepository, repository.NewCachedRankingRepository,
+		repository.NewCacheUserRepository, repository.NewCachedArticleRepository,
+		dao.NewGormRankingDao, repository.NewCachedRankingRepository,
 		service.NewArticleService, service.NewArticleRankingService,
-		interactiveSvcSet,
+		jobSet,
 		ioc.InitIntrClientV1,
 		ioc.InitEtcdClient,
 		web.NewArticleHandler)
@@ -43,23 +43,42 @@ func InitWebServer() *gin.Engine {
 	smsService := ioc.InitSMSService()
 	codeService := service.NewCodeService(codeRepository, smsService)
 	context := ginadaptor.NewLogContextBuilder(loggerV1)
-	userHandler := web.NewUserHandler(userService, codeService, handler, context)
+	client := ioc.InitEtcdClient()
+	followServiceClient := ioc.InitFollowClient(client)
+	userHandler := web.NewUserHandler(userService, codeService, handler, context, followServiceClient)
 	articleDao := dao.NewArticleGormDao(db)
 	articleCache := cache.NewArticleRedisCache(cmdable)
 	articleRepository := repository.NewCachedArticleRepository(articleDao, articleCache, userRepository)
-	client := InitSaramaClient()
-	syncProducer := InitialProducer(client)
+	jobDao := dao.NewGormJobDao(db)
+	jobExecutionDao := dao.NewGormJobExecutionDao(db)
+	jobRepository := repository.NewPreemptJobRepository(jobDao, jobExecutionDao)
+	jobService := service.NewCronJobService(jobRepository, loggerV1)
+	saramaClient := InitSaramaClient()
+	syncProducer := InitialProducer(saramaClient)
 	producer := InitialSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer, loggerV1)
-	clientv3Client := ioc.InitEtcdClient()
-	interactiveServiceClient := ioc.InitIntrClientV1(clientv3Client)
+	articleService := service.NewArticleService(articleRepository, jobService, producer, loggerV1)
+	interactiveServiceClient := ioc.InitIntrClientV1(client)
 	rankingCache := cache.NewRedisRankingCache(cmdable)
-	rankingRepository := repository.NewCachedRankingRepository(rankingCache, loggerV1)
+	rankingDao := dao.NewGormRankingDao(db)
+	rankingRepository := repository.NewCachedRankingRepository(rankingCache, rankingDao, loggerV1)
 	rankingService := service.NewArticleRankingService(rankingRepository, articleService, interactiveServiceClient, loggerV1)
 	articleHandler := web.NewArticleHandler(articleService, interactiveServiceClient, loggerV1, rankingService)
 	wechatService := InitWechatService()
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
-	engine := ioc.InitWebServer(v, userHandler, articleHandler, oAuth2WechatHandler)
+	searchService := service.NewArticleSearchService(articleService, loggerV1)
+	searchHandler := web.NewSearchHandler(searchService, loggerV1)
+	commentDao := dao.NewGormCommentDao(db)
+	commentRepository := repository.NewDaoCommentRepository(commentDao)
+	commentService := service.NewCommentService(commentRepository, articleRepository, interactiveServiceClient, loggerV1)
+	commentHandler := web.NewCommentHandler(commentService, loggerV1)
+	feedDao := dao.NewGormFeedDao(db)
+	feedRepository := repository.NewDaoFeedRepository(feedDao)
+	feedService := service.NewFeedService(feedRepository, followServiceClient, loggerV1)
+	followHandler := web.NewFollowHandler(followServiceClient, feedService, loggerV1)
+	feedHandler := web.NewFeedHandler(feedService, loggerV1)
+	collectionHandler := web.NewCollectionHandler(interactiveServiceClient, loggerV1)
+	jobHandler := web.NewJobHandler(jobService, loggerV1)
+	engine := ioc.InitWebServer(v, userHandler, articleHandler, oAuth2WechatHandler, searchHandler, commentHandler, followHandler, feedHandler, collectionHandler, jobHandler)
 	return engine
 }
 
@@ -72,15 +91,20 @@ func InitArticleHandler(articleDao dao.ArticleDao) *web.ArticleHandler {
 	userCache := cache.NewRedisUserCache(cmdable)
 	userRepository := repository.NewCacheUserRepository(userDao, profileDao, userCache)
 	articleRepository := repository.NewCachedArticleRepository(articleDao, articleCache, userRepository)
+	jobDao := dao.NewGormJobDao(db)
+	jobExecutionDao := dao.NewGormJobExecutionDao(db)
+	jobRepository := repository.NewPreemptJobRepository(jobDao, jobExecutionDao)
+	loggerV1 := InitLogger()
+	jobService := service.NewCronJobService(jobRepository, loggerV1)
 	client := InitSaramaClient()
 	syncProducer := InitialProducer(client)
 	producer := InitialSaramaSyncProducer(syncProducer)
-	loggerV1 := InitLogger()
-	articleService := service.NewArticleService(articleRepository, producer, loggerV1)
+	articleService := service.NewArticleService(articleRepository, jobService, producer, loggerV1)
 	clientv3Client := ioc.InitEtcdClient()
 	interactiveServiceClient := ioc.InitIntrClientV1(clientv3Client)
 	rankingCache := cache.NewRedisRankingCache(cmdable)
-	rankingRepository := repository.NewCachedRankingRepository(rankingCache, loggerV1)
+	rankingDao := dao.NewGormRankingDao(db)
+	rankingRepository := repository.NewCachedRankingRepository(rankingCache, rankingDao, loggerV1)
 	rankingService := service.NewArticleRankingService(rankingRepository, articleService, interactiveServiceClient, loggerV1)
 	articleHandler := web.NewArticleHandler(articleService, interactiveServiceClient, loggerV1, rankingService)
 	return articleHandler
@@ -89,7 +113,8 @@ func InitArticleHandler(articleDao dao.ArticleDao) *web.ArticleHandler {
 func InitJobScheduler() *job.Scheduler {
 	db := InitDB()
 	jobDao := dao.NewGormJobDao(db)
-	jobRepository := repository.NewPreemptJobRepository(jobDao)
+	jobExecutionDao := dao.NewGormJobExecutionDao(db)
+	jobRepository := repository.NewPreemptJobRepository(jobDao, jobExecutionDao)
 	loggerV1 := InitLogger()
 	jobService := service.NewCronJobService(jobRepository, loggerV1)
 	scheduler := job.NewScheduler(jobService, loggerV1)
@@ -101,7 +126,7 @@ func InitJobScheduler() *job.Scheduler {
 var (
 	thirdPartySet = wire.NewSet(
 		InitRedis, InitDB, InitLogger, InitSaramaClient, InitialProducer)
-	interactiveSvcSet = wire.NewSet(dao2.NewInteractiveGormDao, cache2.NewInteractiveRedisCache, repository2.NewCachedInteractiveRepository, service2.NewInteractiveService)
+	interactiveSvcSet = wire.NewSet(dao2.NewInteractiveGormDao, dao2.NewGormCollectionDao, cache2.NewInteractiveRedisCache, repository2.NewCachedInteractiveRepository, repository2.NewCachedCollectionRepository, service2.NewInteractiveService)
 
-	jobSet = wire.NewSet(dao.NewGormJobDao, repository.NewPreemptJobRepository, service.NewCronJobService)
+	jobSet = wire.NewSet(dao.NewGormJobDao, dao.NewGormJobExecutionDao, repository.NewPreemptJobRepository, service.NewCronJobService)
 )
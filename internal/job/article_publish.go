@@ -0,0 +1,40 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+// ArticlePublishExecutor 到点之后发表作者当前的草稿
+type ArticlePublishExecutor struct {
+	svc service.ArticleService
+	l   logger.LoggerV1
+}
+
+func NewArticlePublishExecutor(svc service.ArticleService, l logger.LoggerV1) *ArticlePublishExecutor {
+	return &ArticlePublishExecutor{svc: svc, l: l}
+}
+
+func (a *ArticlePublishExecutor) Name() string {
+	return service.ArticlePublishExecutor
+}
+
+func (a *ArticlePublishExecutor) Execute(ctx context.Context, job domain.Job) error {
+	var cfg service.ArticlePublishCfg
+	err := json.Unmarshal([]byte(job.Cfg), &cfg)
+	if err != nil {
+		return err
+	}
+	_, err = a.svc.PublishDraft(ctx, cfg.Aid, cfg.Uid)
+	if err != nil {
+		return err
+	}
+	a.l.Info("scheduled article published",
+		logger.Int64("aid", cfg.Aid),
+		logger.Int64("uid", cfg.Uid))
+	return nil
+}
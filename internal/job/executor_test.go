@@ -0,0 +1,157 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/pkg/grpcx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestHttpExecutor_Execute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/ok":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, `{"a":1}`, string(body))
+			assert.Equal(t, "1", r.Header.Get("X-Job-Id"))
+			w.WriteHeader(http.StatusNoContent)
+		case "/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("boom"))
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}))
+	defer server.Close()
+
+	executor := NewHttpExecutor(server.Client(), map[string]string{
+		"ok":   server.URL + "/ok",
+		"fail": server.URL + "/fail",
+		"slow": server.URL + "/slow",
+	}, time.Millisecond*100)
+
+	testCases := []struct {
+		name    string
+		ctx     func() context.Context
+		job     domain.Job
+		wantErr string
+	}{
+		{
+			name: "success",
+			job:  domain.Job{Id: 1, Name: "ok", Cfg: `{"a":1}`},
+		},
+		{
+			name:    "not 2xx",
+			job:     domain.Job{Id: 1, Name: "fail"},
+			wantErr: "http job fail fail, status 500: boom",
+		},
+		{
+			name:    "timeout",
+			job:     domain.Job{Id: 1, Name: "slow"},
+			wantErr: "context deadline exceeded",
+		},
+		{
+			name: "canceled",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			job:     domain.Job{Id: 1, Name: "ok"},
+			wantErr: "context canceled",
+		},
+		{
+			name:    "no endpoint",
+			job:     domain.Job{Id: 1, Name: "unknown"},
+			wantErr: "not config http endpoint for job unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.ctx != nil {
+				ctx = tc.ctx()
+			}
+			err := executor.Execute(ctx, tc.job)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestGrpcExecutor_Execute(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	jobSvr := grpcx.NewJobExecutorServer()
+	jobSvr.RegisterFunc("ok", func(ctx context.Context, cfg string) error {
+		assert.Equal(t, `{"a":1}`, cfg)
+		return nil
+	})
+	jobSvr.RegisterFunc("fail", func(ctx context.Context, cfg string) error {
+		return errors.New("boom")
+	})
+	jobSvr.RegisterFunc("slow", func(ctx context.Context, cfg string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	server := grpc.NewServer()
+	jobSvr.Register(server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	var targets []string
+	executor := &GrpcExecutor{
+		services: map[string]string{"ok": "intr", "fail": "intr", "slow": "intr", "missing": "intr"},
+		timeout:  time.Millisecond * 100,
+		dial: func(target string) (*grpc.ClientConn, error) {
+			targets = append(targets, target)
+			return grpc.Dial("bufnet", grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		},
+		conns: map[string]*grpc.ClientConn{},
+	}
+	defer executor.Close()
+
+	testCases := []struct {
+		name     string
+		job      domain.Job
+		wantCode codes.Code
+	}{
+		{name: "success", job: domain.Job{Id: 1, Name: "ok", Cfg: `{"a":1}`}, wantCode: codes.OK},
+		{name: "fail", job: domain.Job{Id: 1, Name: "fail"}, wantCode: codes.Internal},
+		{name: "timeout", job: domain.Job{Id: 1, Name: "slow"}, wantCode: codes.DeadlineExceeded},
+		{name: "not register", job: domain.Job{Id: 1, Name: "missing"}, wantCode: codes.NotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := executor.Execute(context.Background(), tc.job)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+	// 同一个服务只建一次连接
+	require.Equal(t, []string{"etcd:///service/intr"}, targets)
+}
@@ -0,0 +1,90 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	jobv1 "github.com/misakimei123/redbook/api/proto/gen/job/v1"
+	"github.com/misakimei123/redbook/internal/domain"
+	etcdv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GrpcExecutor 调用注册在 etcd 上的 JobExecutorService，按任务名字配置调用哪个服务
+type GrpcExecutor struct {
+	services map[string]string
+	timeout  time.Duration
+	dial     func(target string) (*grpc.ClientConn, error)
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func NewGrpcExecutor(cli *etcdv3.Client, services map[string]string, timeout time.Duration) (*GrpcExecutor, error) {
+	etcdResolver, err := resolver.NewBuilder(cli)
+	if err != nil {
+		return nil, err
+	}
+	return &GrpcExecutor{
+		services: services,
+		timeout:  timeout,
+		dial: func(target string) (*grpc.ClientConn, error) {
+			return grpc.Dial(target, grpc.WithResolvers(etcdResolver),
+				grpc.WithTransportCredentials(insecure.NewCredentials()))
+		},
+		conns: make(map[string]*grpc.ClientConn),
+	}, nil
+}
+
+func (g *GrpcExecutor) Name() string {
+	return "grpc"
+}
+
+// Execute ctx 被取消或者超时都会中断调用
+func (g *GrpcExecutor) Execute(ctx context.Context, job domain.Job) error {
+	svc, ok := g.services[job.Name]
+	if !ok {
+		return fmt.Errorf("not config grpc service for job %s", job.Name)
+	}
+	cc, err := g.conn(svc)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	_, err = jobv1.NewJobExecutorServiceClient(cc).Execute(ctx, &jobv1.ExecuteRequest{
+		Id:   job.Id,
+		Name: job.Name,
+		Cfg:  job.Cfg,
+	})
+	return err
+}
+
+// conn 同一个服务复用一个连接
+func (g *GrpcExecutor) conn(svc string) (*grpc.ClientConn, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if cc, ok := g.conns[svc]; ok {
+		return cc, nil
+	}
+	cc, err := g.dial("etcd:///service/" + svc)
+	if err != nil {
+		return nil, err
+	}
+	g.conns[svc] = cc
+	return cc, nil
+}
+
+func (g *GrpcExecutor) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for svc, cc := range g.conns {
+		_ = cc.Close()
+		delete(g.conns, svc)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/misakimei123/redbook/internal/domain"
+)
+
+// HttpExecutor 把任务的 Cfg 原样 POST 到按任务名字配置的地址，返回 2xx 算成功
+type HttpExecutor struct {
+	client    *http.Client
+	endpoints map[string]string
+	timeout   time.Duration
+}
+
+func NewHttpExecutor(client *http.Client, endpoints map[string]string, timeout time.Duration) *HttpExecutor {
+	return &HttpExecutor{client: client, endpoints: endpoints, timeout: timeout}
+}
+
+func (h *HttpExecutor) Name() string {
+	return "http"
+}
+
+// Execute ctx 被取消或者超时都会中断请求
+func (h *HttpExecutor) Execute(ctx context.Context, job domain.Job) error {
+	url, ok := h.endpoints[job.Name]
+	if !ok {
+		return fmt.Errorf("not config http endpoint for job %s", job.Name)
+	}
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(job.Cfg))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Job-Id", strconv.FormatInt(job.Id, 10))
+	req.Header.Set("X-Job-Name", job.Name)
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	// 只带上一部分响应，方便在执行记录里面排查
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("http job %s fail, status %d: %s", job.Name, resp.StatusCode, body)
+}
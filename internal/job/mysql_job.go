@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -46,11 +47,16 @@ type Scheduler struct {
 	l         logger.LoggerV1
 	executors map[string]Executor
 	limiter   *semaphore.Weighted
+	// idleInterval 没有任务可以抢占的时候等多久再抢
+	idleInterval time.Duration
+	// maxBackoff 抢占出错的时候从 idleInterval 开始翻倍退避，最多等这么久
+	maxBackoff time.Duration
 }
 
 func NewScheduler(svc service.JobService, l logger.LoggerV1) *Scheduler {
 	return &Scheduler{svc: svc, l: l, dbTimeout: time.Second,
-		limiter: semaphore.NewWeighted(100), executors: make(map[string]Executor)}
+		limiter: semaphore.NewWeighted(100), executors: make(map[string]Executor),
+		idleInterval: time.Second, maxBackoff: time.Second * 30}
 }
 
 func (s *Scheduler) RegisterExecutor(executor Executor) {
@@ -58,6 +64,7 @@ func (s *Scheduler) RegisterExecutor(executor Executor) {
 }
 
 func (s *Scheduler) Schedule(ctx context.Context) error {
+	backoff := s.idleInterval
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
@@ -66,43 +73,117 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		// 续约发现任务被别的节点抢走了，就中断正在执行的任务
+		jobCtx, cancelJob := context.WithCancelCause(ctx)
 		dbCtx, cancelFunc := context.WithTimeout(ctx, s.dbTimeout)
-		job, err := s.svc.Preempt(dbCtx)
+		job, err := s.svc.Preempt(dbCtx, cancelJob)
 		cancelFunc()
 		if err != nil {
+			cancelJob(nil)
+			s.limiter.Release(1)
+			// 没有任务就隔一会儿再抢，数据库出错了就逐步退避
+			wait := s.idleInterval
+			if errors.Is(err, service.ErrNoMoreJob) {
+				backoff = s.idleInterval
+			} else {
+				wait = backoff
+				backoff = min(backoff*2, s.maxBackoff)
+			}
+			if err = s.sleep(ctx, wait); err != nil {
+				return err
+			}
 			continue
 		}
+		backoff = s.idleInterval
 
-		executor, ok := s.executors[job.Executor]
-		if !ok {
-			s.l.Error("could not find executor",
-				logger.Int64("job id", job.Id),
-				logger.String("executor", job.Executor),
-			)
-			continue
-		}
 		go func() {
 			defer func() {
+				cancelJob(nil)
 				s.limiter.Release(1)
 				job.CancelFunc()
 			}()
-			err1 := executor.Execute(ctx, job)
-			if err1 != nil {
-				s.l.Error("executor fail",
-					logger.Error(err),
-					logger.Int64("job id", job.Id),
-					logger.String("executor", job.Executor),
-				)
-				return
-			}
-			err1 = s.svc.ResetNextTime(ctx, job)
-			if err1 != nil {
-				s.l.Error("reset next time fail",
-					logger.Error(err),
-					logger.Int64("job id", job.Id),
-					logger.String("executor", job.Executor),
-				)
-			}
+			s.execute(ctx, jobCtx, job)
 		}()
 	}
 }
+
+func (s *Scheduler) sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// execute 执行一次任务并记录结果，失败了按照任务的重试策略重新安排。
+// 任务在 jobCtx 里面执行，记录结果用的是 ctx，这样任务被中断了也能记下来
+func (s *Scheduler) execute(ctx, jobCtx context.Context, job domain.Job) {
+	dbCtx, cancelFunc := context.WithTimeout(ctx, s.dbTimeout)
+	execution, err := s.svc.StartExecution(dbCtx, job)
+	cancelFunc()
+	if err != nil {
+		// 记录失败不影响执行
+		s.l.Error("start execution fail", logger.Error(err), logger.Int64("job id", job.Id))
+	}
+
+	err = s.run(jobCtx, job)
+
+	if execution.Id > 0 {
+		dbCtx, cancelFunc = context.WithTimeout(ctx, s.dbTimeout)
+		er := s.svc.EndExecution(dbCtx, execution, err)
+		cancelFunc()
+		if er != nil {
+			s.l.Error("end execution fail", logger.Error(er), logger.Int64("job id", job.Id))
+		}
+	}
+
+	if errors.Is(context.Cause(jobCtx), service.ErrJobLost) {
+		// 任务已经归别的节点了，下一次什么时候执行轮不到这里安排
+		s.l.Warn("job lost while executing",
+			logger.Error(err),
+			logger.Int64("job id", job.Id))
+		return
+	}
+
+	dbCtx, cancelFunc = context.WithTimeout(ctx, s.dbTimeout)
+	defer cancelFunc()
+	if err != nil {
+		s.l.Error("executor fail",
+			logger.Error(err),
+			logger.Int64("job id", job.Id),
+			logger.String("executor", job.Executor),
+		)
+		err = s.svc.Retry(dbCtx, job)
+		if errors.Is(err, service.ErrJobLost) {
+			s.l.Warn("job lost before retry", logger.Int64("job id", job.Id))
+			return
+		}
+		if err != nil {
+			s.l.Error("retry job fail", logger.Error(err), logger.Int64("job id", job.Id))
+		}
+		return
+	}
+	err = s.svc.ResetNextTime(dbCtx, job)
+	if errors.Is(err, service.ErrJobLost) {
+		s.l.Warn("job lost before reset next time", logger.Int64("job id", job.Id))
+		return
+	}
+	if err != nil {
+		s.l.Error("reset next time fail",
+			logger.Error(err),
+			logger.Int64("job id", job.Id),
+			logger.String("executor", job.Executor),
+		)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job domain.Job) error {
+	executor, ok := s.executors[job.Executor]
+	if !ok {
+		return fmt.Errorf("could not find executor %s", job.Executor)
+	}
+	return executor.Execute(ctx, job)
+}
@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/service"
+	svcmocks "github.com/misakimei123/redbook/internal/service/mocks"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduler_Schedule(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		maxCall int
+	}{
+		{
+			// 每 50ms 抢一次，250ms 里面最多抢 6 次
+			name:    "没有任务",
+			err:     service.ErrNoMoreJob,
+			maxCall: 6,
+		},
+		{
+			// 50ms、100ms 之后退避到 200ms，250ms 里面最多抢 3 次
+			name:    "数据库出错",
+			err:     errors.New("db error"),
+			maxCall: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := svcmocks.NewMockJobService(ctrl)
+			calls := 0
+			svc.EXPECT().Preempt(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, onLost func(cause error)) (domain.Job, error) {
+					calls++
+					return domain.Job{}, tc.err
+				}).AnyTimes()
+			s := NewScheduler(svc, logger.NewNopLogger())
+			s.idleInterval = time.Millisecond * 50
+			s.maxBackoff = time.Millisecond * 200
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*250)
+			defer cancel()
+			err := s.Schedule(ctx)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.LessOrEqual(t, calls, tc.maxCall)
+			assert.GreaterOrEqual(t, calls, 2)
+		})
+	}
+}
@@ -14,18 +14,19 @@ import (
 )
 
 type RankingJob struct {
-	svc       service.RankingService
-	name      string
-	l         logger.LoggerV1
-	client    lock.Lock
-	key       string
-	ttl       time.Duration
-	lock      *bool
-	localLock *sync.Mutex
-	balancer  balance.LoadBalance[RunNode]
+	svc         service.RankingService
+	name        string
+	l           logger.LoggerV1
+	client      lock.Client
+	key         string
+	ttl         time.Duration
+	mutex       *lock.Mutex
+	stopRefresh context.CancelFunc
+	localLock   *sync.Mutex
+	balancer    balance.LoadBalance[RunNode]
 }
 
-func NewRankingJob(svc service.RankingService, l logger.LoggerV1, client lock.Lock, balancer balance.LoadBalance[RunNode]) *RankingJob {
+func NewRankingJob(svc service.RankingService, l logger.LoggerV1, client lock.Client, balancer balance.LoadBalance[RunNode]) *RankingJob {
 	return &RankingJob{svc: svc, name: "job:ranking", l: l, client: client,
 		key: "Lock:ranking", ttl: time.Minute,
 		localLock: &sync.Mutex{}, balancer: balancer}
@@ -38,38 +39,42 @@ func (r *RankingJob) Name() string {
 func (r *RankingJob) Run() error {
 	r.localLock.Lock()
 	defer r.localLock.Unlock()
-	dLock := r.lock
-	if dLock == nil {
-		locked, err := r.client.AcquireLock(r.key, r.ttl)
+	mutex := r.mutex
+	if mutex == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		m, err := r.client.TryLock(ctx, r.key, r.ttl)
+		cancel()
+		if errors.Is(err, lock.ErrLockPreempted) {
+			r.l.Info("ranking job is locked")
+			return err
+		}
 		if err != nil {
 			r.l.Error("acquire lock fail ", logger.Error(err))
 			return err
 		}
-		if !locked {
-			r.l.Info("ranking job is locked")
-			return errors.New("ranking job is locked")
-		}
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
 		_ = r.balancer.UpdateLoad(ctx, float64(rand.Intn(100)))
 		suited, _ := r.balancer.CurNodeIsSuitable(ctx)
 		cancel()
 		if !suited {
+			r.unlock(m)
 			return errors.New("cur node is not suited")
 		}
-		r.lock = &locked
 		r.l.Info("got lock")
-		go func() {
-			er := r.client.AutoRefresh(r.key, r.ttl, r.ttl/2)
-			if er != nil {
-				r.localLock.Lock()
-				r.lock = nil
-				r.localLock.Unlock()
-			}
-		}()
+		r.hold(m)
+		mutex = m
 	}
 
 	ctx, cancelFunc := context.WithTimeout(context.Background(), r.ttl)
 	defer cancelFunc()
+	// 锁丢了就不用算了，拿到锁的节点会重新算
+	go func() {
+		select {
+		case <-mutex.Lost():
+			cancelFunc()
+		case <-ctx.Done():
+		}
+	}()
 	err := r.svc.Rank(ctx)
 	if err != nil {
 		r.l.Error("do ranking fail", logger.Error(err))
@@ -77,11 +82,30 @@ func (r *RankingJob) Run() error {
 	return err
 }
 
-func (r *RankingJob) Close() error {
-	r.localLock.Lock()
-	r.lock = nil
-	r.localLock.Unlock()
-	er := r.client.ReleaseLock(r.key)
+// hold 持有锁并且在后台续约，续约停下来之后就要重新抢锁
+func (r *RankingJob) hold(m *lock.Mutex) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mutex = m
+	r.stopRefresh = cancel
+	go func() {
+		defer cancel()
+		err := m.AutoRefresh(ctx, r.ttl/2, time.Second)
+		if errors.Is(err, lock.ErrLockNotHold) {
+			r.l.Error("ranking lock lost", logger.String("key", r.key))
+		}
+		r.localLock.Lock()
+		if r.mutex == m {
+			r.mutex = nil
+			r.stopRefresh = nil
+		}
+		r.localLock.Unlock()
+	}()
+}
+
+func (r *RankingJob) unlock(m *lock.Mutex) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	er := m.Unlock(ctx)
 	if er != nil {
 		r.l.Error("release lock fail",
 			logger.String("key", r.key),
@@ -89,3 +113,15 @@ func (r *RankingJob) Close() error {
 	}
 	return er
 }
+
+func (r *RankingJob) Close() error {
+	r.localLock.Lock()
+	m, stop := r.mutex, r.stopRefresh
+	r.mutex, r.stopRefresh = nil, nil
+	r.localLock.Unlock()
+	if m == nil {
+		return nil
+	}
+	stop()
+	return r.unlock(m)
+}
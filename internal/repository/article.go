@@ -2,16 +2,24 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/misakimei123/redbook/internal/domain"
 	"github.com/misakimei123/redbook/internal/repository/cache"
 	"github.com/misakimei123/redbook/internal/repository/dao"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrArticleNotFound  = dao.ErrRecordNotFound
+	ErrRevisionNotFound = dao.ErrRecordNotFound
+)
+
 type ArticleRepository interface {
 	Create(ctx context.Context, article domain.Article) (int64, error)
 	Update(ctx context.Context, article domain.Article) error
@@ -19,10 +27,16 @@ type ArticleRepository interface {
 	SyncV1(ctx context.Context, article domain.Article) (int64, error)
 	SyncV2(ctx context.Context, article domain.Article) (int64, error)
 	SyncStatus(ctx context.Context, id int64, uid int64, articleStatus domain.ArticleStatus) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, uid int64, id int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id int64) (domain.Article, error)
-	GetPubs(ctx context.Context, start int64, end int64, offset int, size int) ([]domain.Article, error)
+	GetPubs(ctx context.Context, start int64, end int64, cursor domain.Cursor, size int) ([]domain.Article, error)
+	GetPubsByTag(ctx context.Context, tag string, cursor domain.Cursor, limit int) ([]domain.Article, error)
+	GetRevisions(ctx context.Context, aid int64, uid int64, cursor domain.Cursor, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, aid int64, uid int64, rid int64) (domain.ArticleRevision, error)
+	// PreloadPubs 写缓存之前按 id 重新查线上库，并且不覆盖已经有的缓存，
+	// 避免把算榜单时读到的旧内容写回刚失效的缓存
+	PreloadPubs(ctx context.Context, ids []int64) error
 }
 
 type CachedArticleRepository struct {
@@ -32,10 +46,12 @@ type CachedArticleRepository struct {
 	readerDao dao.ArticleReaderDao
 	db        *gorm.DB
 	cache     cache.ArticleCache
+	// pubGroup 同一篇文章缓存失效的时候只有一个请求去查数据库
+	pubGroup singleflight.Group
 }
 
-func (c *CachedArticleRepository) GetPubs(ctx context.Context, start int64, end int64, offset int, size int) ([]domain.Article, error) {
-	arts, err := c.dao.GetPubs(ctx, start, end, offset, size)
+func (c *CachedArticleRepository) GetPubs(ctx context.Context, start int64, end int64, cursor domain.Cursor, size int) ([]domain.Article, error) {
+	arts, err := c.dao.GetPubs(ctx, start, end, cursor.Utime, cursor.Id, size)
 	if err != nil {
 		return nil, err
 	}
@@ -45,16 +61,47 @@ func (c *CachedArticleRepository) GetPubs(ctx context.Context, start int64, end
 	return res, nil
 }
 
+func (c *CachedArticleRepository) GetPubsByTag(ctx context.Context, tag string, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.GetPubsByTag(ctx, tag, cursor.Utime, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return c.toDomain(dao.Article(src))
+	}), nil
+}
+
 func (c *CachedArticleRepository) GetPubById(ctx context.Context, id int64) (domain.Article, error) {
 	art, err := c.cache.GetPub(ctx, id)
-	if err == nil {
-		return art, err
+	switch {
+	case err == nil:
+		return art, nil
+	case errors.Is(err, cache.ErrPubNotFound):
+		return domain.Article{}, ErrArticleNotFound
+	}
+	// 不能因为第一个请求取消了，让其它等着的请求一起失败
+	val, err, _ := c.pubGroup.Do(strconv.FormatInt(id, 10), func() (interface{}, error) {
+		return c.loadPub(context.WithoutCancel(ctx), id)
+	})
+	if err != nil {
+		return domain.Article{}, err
 	}
+	return val.(domain.Article), nil
+}
+
+func (c *CachedArticleRepository) loadPub(ctx context.Context, id int64) (domain.Article, error) {
 	pubArt, err := c.dao.GetPubById(ctx, id)
+	if errors.Is(err, ErrArticleNotFound) {
+		er := c.cache.SetPubNotFound(ctx, id)
+		if er != nil {
+			//	TODO: record log
+		}
+		return domain.Article{}, err
+	}
 	if err != nil {
 		return domain.Article{}, err
 	}
-	art = c.toDomain(dao.Article(pubArt))
+	art := c.toDomain(dao.Article(pubArt))
 	user, err := c.userRepo.FindByID(ctx, art.Author.Id)
 	if err != nil {
 		return domain.Article{}, err
@@ -63,13 +110,37 @@ func (c *CachedArticleRepository) GetPubById(ctx context.Context, id int64) (dom
 		Id:   art.Author.Id,
 		Name: user.Nick,
 	}
-	go func() {
-		err := c.cache.SetPub(ctx, art)
+	err = c.cache.SetPub(ctx, art)
+	if err != nil {
+		//	TODO: record log
+	}
+	return art, nil
+}
+
+func (c *CachedArticleRepository) PreloadPubs(ctx context.Context, ids []int64) error {
+	pubArts, err := c.dao.GetPubByIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	names := make(map[int64]string, len(pubArts))
+	for _, pubArt := range pubArts {
+		art := c.toDomain(dao.Article(pubArt))
+		name, ok := names[art.Author.Id]
+		if !ok {
+			user, err := c.userRepo.FindByID(ctx, art.Author.Id)
+			if err != nil {
+				return err
+			}
+			name = user.Nick
+			names[art.Author.Id] = name
+		}
+		art.Author.Name = name
+		err = c.cache.SetPubNX(ctx, art)
 		if err != nil {
-			//	TODO: record log
+			return err
 		}
-	}()
-	return art, err
+	}
+	return nil
 }
 
 func (c *CachedArticleRepository) GetById(ctx context.Context, uid int64, id int64) (domain.Article, error) {
@@ -112,6 +183,12 @@ func (c *CachedArticleRepository) Sync(ctx context.Context, article domain.Artic
 	if err != nil {
 		//	TODO: log the error
 	}
+	// 先删掉，包括缓存的不存在，下面预加载失败了也不会读到旧的
+	article.Id = id
+	err = c.cache.DelPub(ctx, id)
+	if err != nil {
+		//	TODO: log the error
+	}
 	go func() {
 		user, err := c.userRepo.FindByID(ctx, article.Author.Id)
 		if err != nil {
@@ -218,6 +295,7 @@ func (c *CachedArticleRepository) toEntity(article domain.Article) dao.Article {
 		Content:  article.Content,
 		AuthorId: article.Author.Id,
 		Status:   article.Status.ToInt(),
+		Tags:     article.Tags,
 	}
 }
 
@@ -232,6 +310,7 @@ func (c *CachedArticleRepository) toDomain(article dao.Article) domain.Article {
 		Ctime:  time.UnixMilli(article.Ctime),
 		Utime:  time.UnixMilli(article.Utime),
 		Status: domain.ArticleStatus(article.Status),
+		Tags:   article.Tags,
 	}
 }
 
@@ -244,11 +323,15 @@ func (c *CachedArticleRepository) SyncStatus(ctx context.Context, id int64, uid
 	if err != nil {
 		//	TODO: log the error
 	}
+	err = c.cache.DelPub(ctx, id)
+	if err != nil {
+		//	TODO: log the error
+	}
 	return nil
 }
 
-func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
-	if offset == 0 && limit == 100 {
+func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	if cursor.IsZero() && limit == 100 {
 		articles, err := c.cache.GetFirstPage(ctx, uid)
 		if err == nil {
 			return articles, nil
@@ -257,7 +340,7 @@ func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, uid int64, of
 
 		}
 	}
-	articles, err := c.dao.GetByAuthor(ctx, uid, offset, limit)
+	articles, err := c.dao.GetByAuthor(ctx, uid, cursor.Utime, cursor.Id, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -265,7 +348,7 @@ func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, uid int64, of
 		return c.toDomain(src)
 	})
 	go func() {
-		if offset == 0 && limit == 100 {
+		if cursor.IsZero() && limit == 100 {
 			err = c.cache.SetFirstPage(ctx, uid, res)
 			if err != nil {
 				//TODO: record log
@@ -282,6 +365,37 @@ func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, uid int64, of
 	return res, nil
 }
 
+func (c *CachedArticleRepository) GetRevisions(ctx context.Context, aid int64, uid int64, cursor domain.Cursor, limit int) ([]domain.ArticleRevision, error) {
+	revisions, err := c.dao.GetRevisions(ctx, aid, uid, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleRevision, domain.ArticleRevision](revisions, func(idx int, src dao.ArticleRevision) domain.ArticleRevision {
+		return c.revisionToDomain(src)
+	}), nil
+}
+
+func (c *CachedArticleRepository) GetRevision(ctx context.Context, aid int64, uid int64, rid int64) (domain.ArticleRevision, error) {
+	revision, err := c.dao.GetRevision(ctx, aid, uid, rid)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return c.revisionToDomain(revision), nil
+}
+
+func (c *CachedArticleRepository) revisionToDomain(revision dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        revision.Id,
+		ArticleId: revision.ArticleId,
+		AuthorId:  revision.AuthorId,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Status:    domain.ArticleStatus(revision.Status),
+		Tags:      revision.Tags,
+		Ctime:     time.UnixMilli(revision.Ctime),
+	}
+}
+
 func (c *CachedArticleRepository) preCache(ctx context.Context, articles []domain.Article) error {
 	if len(articles) == 0 {
 		return fmt.Errorf("no articles need cached")
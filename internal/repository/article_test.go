@@ -2,11 +2,17 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository/cache"
+	cachemocks "github.com/misakimei123/redbook/internal/repository/cache/mocks"
 	"github.com/misakimei123/redbook/internal/repository/dao"
 	daomocks "github.com/misakimei123/redbook/internal/repository/dao/mocks"
+	repomocks "github.com/misakimei123/redbook/internal/repository/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -84,3 +90,145 @@ func TestCachedArticleRepository_SyncV1(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedArticleRepository_GetPubById(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache, UserRepository)
+		wantArt domain.Article
+		wantErr error
+	}{
+		{
+			name: "cache hit",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache, UserRepository) {
+				articleCache := cachemocks.NewMockArticleCache(ctrl)
+				articleCache.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{Id: 1, Title: "my title"}, nil)
+				return daomocks.NewMockArticleDao(ctrl), articleCache, repomocks.NewMockUserRepository(ctrl)
+			},
+			wantArt: domain.Article{Id: 1, Title: "my title"},
+		},
+		{
+			name: "cached not found",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache, UserRepository) {
+				articleCache := cachemocks.NewMockArticleCache(ctrl)
+				articleCache.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrPubNotFound)
+				return daomocks.NewMockArticleDao(ctrl), articleCache, repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "not found, cache it",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache, UserRepository) {
+				articleCache := cachemocks.NewMockArticleCache(ctrl)
+				articleCache.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, errors.New("redis: nil"))
+				articleCache.EXPECT().SetPubNotFound(gomock.Any(), int64(1)).Return(nil)
+				articleDao := daomocks.NewMockArticleDao(ctrl)
+				articleDao.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(dao.PublishedArticle{}, dao.ErrRecordNotFound)
+				return articleDao, articleCache, repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "load from db and set cache",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDao, cache.ArticleCache, UserRepository) {
+				art := domain.Article{
+					Id:     1,
+					Title:  "my title",
+					Author: domain.Author{Id: 123, Name: "nick"},
+					Ctime:  time.UnixMilli(100),
+					Utime:  time.UnixMilli(200),
+					Status: domain.ArticleStatusPublished,
+				}
+				articleCache := cachemocks.NewMockArticleCache(ctrl)
+				articleCache.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, errors.New("redis: nil"))
+				articleCache.EXPECT().SetPub(gomock.Any(), art).Return(nil)
+				articleDao := daomocks.NewMockArticleDao(ctrl)
+				articleDao.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(dao.PublishedArticle{
+					Id:       1,
+					Title:    "my title",
+					AuthorId: 123,
+					Status:   domain.ArticleStatusPublished,
+					Ctime:    100,
+					Utime:    200,
+				}, nil)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(domain.User{Nick: "nick"}, nil)
+				return articleDao, articleCache, userRepo
+			},
+			wantArt: domain.Article{
+				Id:     1,
+				Title:  "my title",
+				Author: domain.Author{Id: 123, Name: "nick"},
+				Ctime:  time.UnixMilli(100),
+				Utime:  time.UnixMilli(200),
+				Status: domain.ArticleStatusPublished,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := NewCachedArticleRepository(tc.mock(ctrl))
+			art, err := repo.GetPubById(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArt, art)
+		})
+	}
+}
+
+// 缓存失效的时候，并发的请求只查一次数据库
+func TestCachedArticleRepository_GetPubById_SingleFlight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	const n = 10
+	articleCache := cachemocks.NewMockArticleCache(ctrl)
+	articleCache.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, errors.New("redis: nil")).Times(n)
+	articleCache.EXPECT().SetPub(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	articleDao := daomocks.NewMockArticleDao(ctrl)
+	articleDao.EXPECT().GetPubById(gomock.Any(), int64(1)).
+		DoAndReturn(func(ctx context.Context, id int64) (dao.PublishedArticle, error) {
+			time.Sleep(time.Millisecond * 100)
+			return dao.PublishedArticle{Id: 1, AuthorId: 123}, nil
+		}).Times(1)
+	userRepo := repomocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(domain.User{Nick: "nick"}, nil).Times(1)
+	repo := NewCachedArticleRepository(articleDao, articleCache, userRepo)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			art, err := repo.GetPubById(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "nick", art.Author.Name)
+		}()
+	}
+	wg.Wait()
+}
+
+// 预加载要用线上库里最新的内容，并且不能覆盖已经有的缓存
+func TestCachedArticleRepository_PreloadPubs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	articleDao := daomocks.NewMockArticleDao(ctrl)
+	articleDao.EXPECT().GetPubByIds(gomock.Any(), []int64{1, 2}).Return([]dao.PublishedArticle{
+		{Id: 1, Title: "new title", AuthorId: 123, Status: domain.ArticleStatusPublished, Ctime: 100, Utime: 300},
+	}, nil)
+	userRepo := repomocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(domain.User{Nick: "nick"}, nil)
+	articleCache := cachemocks.NewMockArticleCache(ctrl)
+	articleCache.EXPECT().SetPubNX(gomock.Any(), domain.Article{
+		Id:     1,
+		Title:  "new title",
+		Author: domain.Author{Id: 123, Name: "nick"},
+		Ctime:  time.UnixMilli(100),
+		Utime:  time.UnixMilli(300),
+		Status: domain.ArticleStatusPublished,
+	}).Return(nil)
+	repo := NewCachedArticleRepository(articleDao, articleCache, userRepo)
+	err := repo.PreloadPubs(context.Background(), []int64{1, 2})
+	assert.NoError(t, err)
+}
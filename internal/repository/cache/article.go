@@ -3,13 +3,24 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/misakimei123/redbook/internal/domain"
 	"github.com/redis/go-redis/v9"
 )
 
+// ErrPubNotFound 缓存了文章不存在
+var ErrPubNotFound = errors.New("published article not found")
+
+const (
+	pubExpiration = time.Minute * 10
+	// pubNotFound 不存在的文章缓存成空字符串
+	pubNotFound = ""
+)
+
 type ArticleCache interface {
 	GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error)
 	SetFirstPage(ctx context.Context, uid int64, articles []domain.Article) error
@@ -18,22 +29,37 @@ type ArticleCache interface {
 	Set(ctx context.Context, article domain.Article) error
 	GetPub(ctx context.Context, id int64) (domain.Article, error)
 	SetPub(ctx context.Context, art domain.Article) error
+	// SetPubNX 只在缓存里没有这篇文章的时候写，不覆盖别人刚写进去的新数据
+	SetPubNX(ctx context.Context, art domain.Article) error
+	// SetPubNotFound 缓存文章不存在，之后 GetPub 返回 ErrPubNotFound
+	SetPubNotFound(ctx context.Context, id int64) error
+	DelPub(ctx context.Context, id int64) error
 }
 
 type ArticleRedisCache struct {
 	client redis.Cmdable
+	// notFoundExpiration 为 0 就不缓存不存在的文章
+	notFoundExpiration time.Duration
 }
 
 func NewArticleRedisCache(client redis.Cmdable) ArticleCache {
 	return &ArticleRedisCache{client: client}
 }
 
+// NewArticleRedisCacheV1 notFoundExpiration 大于 0 的时候会缓存不存在的文章，防止穿透
+func NewArticleRedisCacheV1(client redis.Cmdable, notFoundExpiration time.Duration) ArticleCache {
+	return &ArticleRedisCache{client: client, notFoundExpiration: notFoundExpiration}
+}
+
 func (a *ArticleRedisCache) GetPub(ctx context.Context, id int64) (domain.Article, error) {
 	key := a.pubKey(id)
 	result, err := a.client.Get(ctx, key).Result()
 	if err != nil {
 		return domain.Article{}, err
 	}
+	if result == pubNotFound {
+		return domain.Article{}, ErrPubNotFound
+	}
 	var art domain.Article
 	err = json.Unmarshal([]byte(result), &art)
 	return art, err
@@ -45,11 +71,36 @@ func (a *ArticleRedisCache) SetPub(ctx context.Context, art domain.Article) erro
 	if err != nil {
 		return err
 	}
-	return a.client.Set(ctx, key, val, time.Second*10).Err()
+	return a.client.Set(ctx, key, val, jitter(pubExpiration)).Err()
+}
+
+func (a *ArticleRedisCache) SetPubNX(ctx context.Context, art domain.Article) error {
+	key := a.pubKey(art.Id)
+	val, err := json.Marshal(art)
+	if err != nil {
+		return err
+	}
+	return a.client.SetNX(ctx, key, val, jitter(pubExpiration)).Err()
+}
+
+func (a *ArticleRedisCache) SetPubNotFound(ctx context.Context, id int64) error {
+	if a.notFoundExpiration <= 0 {
+		return nil
+	}
+	return a.client.Set(ctx, a.pubKey(id), pubNotFound, jitter(a.notFoundExpiration)).Err()
+}
+
+func (a *ArticleRedisCache) DelPub(ctx context.Context, id int64) error {
+	return a.client.Del(ctx, a.pubKey(id)).Err()
 }
 
 func (a *ArticleRedisCache) pubKey(id int64) string {
-	return fmt.Sprintf("article:pub:%d:%d", id)
+	return fmt.Sprintf("article:pub:%d", id)
+}
+
+// jitter 过期时间随机加上 0~20%，避免同时过期
+func jitter(expiration time.Duration) time.Duration {
+	return expiration + time.Duration(rand.Int63n(int64(expiration)/5+1))
 }
 
 func (a *ArticleRedisCache) Get(ctx context.Context, uid int64, id int64) (domain.Article, error) {
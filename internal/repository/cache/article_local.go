@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/misakimei123/redbook/internal/domain"
+)
+
+// ArticleLocalCache 线上库的文章详情在 redis 前面再加一层本地 LRU，其它方法直接用 redis
+type ArticleLocalCache struct {
+	ArticleCache
+	pubs *lru.LRU[int64, domain.Article]
+}
+
+// NewArticleLocalCache expiration 要短，别的实例发布或者撤回的时候只能等本地缓存过期
+func NewArticleLocalCache(redisCache ArticleCache, size int, expiration time.Duration) ArticleCache {
+	return &ArticleLocalCache{
+		ArticleCache: redisCache,
+		pubs:         lru.NewLRU[int64, domain.Article](size, nil, expiration),
+	}
+}
+
+func (a *ArticleLocalCache) GetPub(ctx context.Context, id int64) (domain.Article, error) {
+	art, ok := a.pubs.Get(id)
+	if ok {
+		return art, nil
+	}
+	art, err := a.ArticleCache.GetPub(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	a.pubs.Add(id, art)
+	return art, nil
+}
+
+func (a *ArticleLocalCache) SetPub(ctx context.Context, art domain.Article) error {
+	a.pubs.Add(art.Id, art)
+	return a.ArticleCache.SetPub(ctx, art)
+}
+
+func (a *ArticleLocalCache) SetPubNotFound(ctx context.Context, id int64) error {
+	a.pubs.Remove(id)
+	return a.ArticleCache.SetPubNotFound(ctx, id)
+}
+
+func (a *ArticleLocalCache) DelPub(ctx context.Context, id int64) error {
+	a.pubs.Remove(id)
+	return a.ArticleCache.DelPub(ctx, id)
+}
@@ -0,0 +1,24 @@
+-- KEYS[1] 记录上榜标签 key 的集合，KEYS[2..] 本次上榜的标签 key
+-- ARGV[1] 过期时间（秒），ARGV[2..] 对应的榜单
+local tagsKey = KEYS[1]
+local expiration = tonumber(ARGV[1])
+
+local ranked = {}
+for i = 2, #KEYS do
+    ranked[KEYS[i]] = true
+end
+
+-- 删除已经不在榜上的标签
+local old = redis.call("smembers", tagsKey)
+for _, key in ipairs(old) do
+    if not ranked[key] then
+        redis.call("del", key)
+    end
+end
+
+redis.call("del", tagsKey)
+for i = 2, #KEYS do
+    redis.call("set", KEYS[i], ARGV[i], "EX", expiration)
+    redis.call("sadd", tagsKey, KEYS[i])
+end
+return 0
@@ -2,16 +2,27 @@ package cache
 
 import (
 	"context"
+	_ "embed"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/misakimei123/redbook/internal/domain"
 	"github.com/redis/go-redis/v9"
 )
 
+//go:embed lua/set_tags.lua
+var luaSetTags string
+
 type RankingCache interface {
 	Get(ctx context.Context) ([]domain.Article, error)
 	Set(ctx context.Context, arts []domain.Article) error
+	GetByTag(ctx context.Context, tag string) ([]domain.Article, error)
+	// SetByTags 一次写入所有标签的榜单，并删除已经不在榜上的标签
+	SetByTags(ctx context.Context, byTag map[string][]domain.Article) error
+	// GetBoard 榜单不在缓存里返回 redis.Nil
+	GetBoard(ctx context.Context, board string) ([]domain.Article, error)
+	SetBoards(ctx context.Context, boards map[string][]domain.Article) error
 }
 
 type RedisRankingCache struct {
@@ -21,7 +32,70 @@ type RedisRankingCache struct {
 }
 
 func (r *RedisRankingCache) Get(ctx context.Context) ([]domain.Article, error) {
-	val, err := r.client.Get(ctx, r.key).Bytes()
+	return r.get(ctx, r.key)
+}
+
+func (r *RedisRankingCache) Set(ctx context.Context, arts []domain.Article) error {
+	val, err := r.marshal(arts)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.key, val, r.expiration).Err()
+}
+
+// GetByTag 标签没有上榜的文章时返回空
+func (r *RedisRankingCache) GetByTag(ctx context.Context, tag string) ([]domain.Article, error) {
+	res, err := r.get(ctx, r.tagKey(tag))
+	if errors.Is(err, redis.Nil) {
+		return []domain.Article{}, nil
+	}
+	return res, err
+}
+
+// SetByTags 用 r.tagsKey() 记录上榜的标签，覆盖写入时顺带删掉掉榜标签的 key
+func (r *RedisRankingCache) SetByTags(ctx context.Context, byTag map[string][]domain.Article) error {
+	keys := make([]string, 0, len(byTag)+1)
+	args := make([]any, 0, len(byTag)+1)
+	keys = append(keys, r.tagsKey())
+	args = append(args, int64(r.expiration/time.Second))
+	for tag, arts := range byTag {
+		val, err := r.marshal(arts)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, r.tagKey(tag))
+		args = append(args, val)
+	}
+	return r.client.Eval(ctx, luaSetTags, keys, args...).Err()
+}
+
+func (r *RedisRankingCache) GetBoard(ctx context.Context, board string) ([]domain.Article, error) {
+	return r.get(ctx, r.boardKey(board))
+}
+
+func (r *RedisRankingCache) SetBoards(ctx context.Context, boards map[string][]domain.Article) error {
+	return r.setAll(ctx, boards, r.boardKey)
+}
+
+// setAll 用一个 pipeline 写入多个榜单
+func (r *RedisRankingCache) setAll(ctx context.Context, lists map[string][]domain.Article, key func(string) string) error {
+	if len(lists) == 0 {
+		return nil
+	}
+	pipeline := r.client.Pipeline()
+	for name, arts := range lists {
+		val, err := r.marshal(arts)
+		if err != nil {
+			return err
+		}
+		pipeline.Set(ctx, key(name), val, r.expiration)
+	}
+	_, err := pipeline.Exec(ctx)
+	return err
+}
+
+func (r *RedisRankingCache) get(ctx context.Context, key string) ([]domain.Article, error) {
+	val, err := r.client.Get(ctx, key).Bytes()
 	if err != nil {
 		return nil, err
 	}
@@ -30,15 +104,23 @@ func (r *RedisRankingCache) Get(ctx context.Context) ([]domain.Article, error) {
 	return res, err
 }
 
-func (r *RedisRankingCache) Set(ctx context.Context, arts []domain.Article) error {
+func (r *RedisRankingCache) marshal(arts []domain.Article) ([]byte, error) {
 	for i := range arts {
 		arts[i].Content = arts[i].Abstract()
 	}
-	val, err := json.Marshal(arts)
-	if err != nil {
-		return err
-	}
-	return r.client.Set(ctx, r.key, val, r.expiration).Err()
+	return json.Marshal(arts)
+}
+
+func (r *RedisRankingCache) tagKey(tag string) string {
+	return r.key + ":tag:" + tag
+}
+
+func (r *RedisRankingCache) tagsKey() string {
+	return r.key + ":tags"
+}
+
+func (r *RedisRankingCache) boardKey(board string) string {
+	return r.key + ":board:" + board
 }
 
 func NewRedisRankingCache(client redis.Cmdable) RankingCache {
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository/cache/redismocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRedisRankingCache_SetByTags(t *testing.T) {
+	arts := []domain.Article{{Id: 1, Title: "go"}}
+	val, _ := json.Marshal(arts)
+
+	testCases := []struct {
+		name    string
+		byTag   map[string][]domain.Article
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		wantErr error
+	}{
+		{
+			name:  "写入并清理掉榜标签",
+			byTag: map[string][]domain.Article{"go": arts},
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewCmd(context.Background())
+				res.SetVal(int64(0))
+				cmd.EXPECT().Eval(gomock.Any(), luaSetTags,
+					[]string{"Ranking:Article:tags", "Ranking:Article:tag:go"},
+					int64(180), val).Return(res)
+				return cmd
+			},
+		},
+		{
+			name:  "没有标签上榜",
+			byTag: map[string][]domain.Article{},
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewCmd(context.Background())
+				res.SetVal(int64(0))
+				cmd.EXPECT().Eval(gomock.Any(), luaSetTags,
+					[]string{"Ranking:Article:tags"}, int64(180)).Return(res)
+				return cmd
+			},
+		},
+		{
+			name:  "redis 错误",
+			byTag: map[string][]domain.Article{"go": arts},
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewCmd(context.Background())
+				res.SetErr(errors.New("redis error"))
+				cmd.EXPECT().Eval(gomock.Any(), luaSetTags, gomock.Any(), gomock.Any()).Return(res)
+				return cmd
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewRedisRankingCache(tc.mock(ctrl))
+			err := c.SetByTags(context.Background(), tc.byTag)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
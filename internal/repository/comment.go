@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository/dao"
+	"golang.org/x/sync/errgroup"
+)
+
+var ErrCommentNotFound = dao.ErrCommentNotFound
+
+type CommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	// FindRoots 每条根评论带上回复数和最早的 replyLimit 条回复
+	FindRoots(ctx context.Context, biz string, bizId int64, cursor domain.Cursor, limit int, replyLimit int) ([]domain.Comment, error)
+	FindReplies(ctx context.Context, rootId int64, cursor domain.Cursor, limit int) ([]domain.Comment, error)
+	Delete(ctx context.Context, c domain.Comment) (int64, error)
+}
+
+type DaoCommentRepository struct {
+	dao dao.CommentDao
+}
+
+func NewDaoCommentRepository(dao dao.CommentDao) CommentRepository {
+	return &DaoCommentRepository{dao: dao}
+}
+
+func (d *DaoCommentRepository) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	return d.dao.Insert(ctx, d.toEntity(c))
+}
+
+func (d *DaoCommentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := d.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return d.toDomain(c), nil
+}
+
+func (d *DaoCommentRepository) FindRoots(ctx context.Context, biz string, bizId int64, cursor domain.Cursor, limit int, replyLimit int) ([]domain.Comment, error) {
+	roots, err := d.dao.FindRoots(ctx, biz, bizId, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := slice.Map(roots, func(idx int, src dao.Comment) domain.Comment {
+		return d.toDomain(src)
+	})
+	if len(roots) == 0 {
+		return res, nil
+	}
+	rootIds := slice.Map(roots, func(idx int, src dao.Comment) int64 {
+		return src.Id
+	})
+	var (
+		eg      errgroup.Group
+		cnts    map[int64]int64
+		replies []dao.Comment
+	)
+	eg.Go(func() error {
+		var er error
+		cnts, er = d.dao.CountReplies(ctx, rootIds)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		replies, er = d.dao.FindRepliesByRoots(ctx, rootIds, replyLimit)
+		return er
+	})
+	if err = eg.Wait(); err != nil {
+		return nil, err
+	}
+	byRoot := make(map[int64][]domain.Comment, len(roots))
+	for _, reply := range replies {
+		byRoot[reply.RootId] = append(byRoot[reply.RootId], d.toDomain(reply))
+	}
+	for i := range res {
+		res[i].ReplyCnt = cnts[res[i].Id]
+		res[i].Replies = byRoot[res[i].Id]
+	}
+	return res, nil
+}
+
+func (d *DaoCommentRepository) FindReplies(ctx context.Context, rootId int64, cursor domain.Cursor, limit int) ([]domain.Comment, error) {
+	replies, err := d.dao.FindReplies(ctx, rootId, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(replies, func(idx int, src dao.Comment) domain.Comment {
+		return d.toDomain(src)
+	}), nil
+}
+
+func (d *DaoCommentRepository) Delete(ctx context.Context, c domain.Comment) (int64, error) {
+	return d.dao.Delete(ctx, d.toEntity(c))
+}
+
+func (d *DaoCommentRepository) toEntity(c domain.Comment) dao.Comment {
+	return dao.Comment{
+		Id:       c.Id,
+		Uid:      c.Uid,
+		Biz:      c.Biz,
+		BizId:    c.BizId,
+		RootId:   c.RootId,
+		ParentId: c.ParentId,
+		Content:  c.Content,
+	}
+}
+
+func (d *DaoCommentRepository) toDomain(c dao.Comment) domain.Comment {
+	return domain.Comment{
+		Id:       c.Id,
+		Uid:      c.Uid,
+		Biz:      c.Biz,
+		BizId:    c.BizId,
+		RootId:   c.RootId,
+		ParentId: c.ParentId,
+		Content:  c.Content,
+		Ctime:    time.UnixMilli(c.Ctime),
+		Utime:    time.UnixMilli(c.Utime),
+	}
+}
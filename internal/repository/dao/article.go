@@ -5,6 +5,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/misakimei123/redbook/internal/domain"
+	eventArticle "github.com/misakimei123/redbook/internal/events/article"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -13,10 +15,11 @@ type Article struct {
 	Id       int64  `gorm:"primaryKey, autoIncrement" bson:"id,omitempty"`
 	Title    string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
 	Content  string `gorm:"type=BLOB" bson:"content,omitempty"`
-	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
+	AuthorId int64  `gorm:"index:author_utime,priority:1" bson:"author_id,omitempty"`
 	Status   uint8  `bson:"status,omitempty"`
+	Tags     Tags   `gorm:"type:varchar(1024)" bson:"tags"`
 	Ctime    int64  `bson:"ctime,omitempty"`
-	Utime    int64  `bson:"utime,omitempty"`
+	Utime    int64  `gorm:"index;index:author_utime,priority:2" bson:"utime,omitempty"`
 }
 
 var ErrArticleAuthorNotMatch = errors.New("id or author id is not correct")
@@ -27,10 +30,18 @@ type ArticleDao interface {
 	Sync(ctx context.Context, art Article) (int64, error)
 	SyncV1(ctx context.Context, art Article) (int64, error)
 	SyncStatus(ctx context.Context, id int64, uid int64, status uint8) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error)
+	// GetByAuthor 按 (utime, id) 倒序分页，lastUtime/lastId 是上一页最后一条，lastId 为 0 表示第一页
+	GetByAuthor(ctx context.Context, uid int64, lastUtime int64, lastId int64, limit int) ([]Article, error)
 	GetById(ctx context.Context, uid int64, id int64) (Article, error)
 	GetPubById(ctx context.Context, id int64) (PublishedArticle, error)
-	GetPubs(ctx context.Context, start int64, end int64, offset int, limit int) ([]PublishedArticle, error)
+	// GetPubByIds 不存在的 id 直接跳过
+	GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error)
+	GetPubs(ctx context.Context, start int64, end int64, lastUtime int64, lastId int64, limit int) ([]PublishedArticle, error)
+	// GetPubsByTag 按 (utime, id) 倒序分页，只返回已发表的
+	GetPubsByTag(ctx context.Context, tag string, lastUtime int64, lastId int64, limit int) ([]PublishedArticle, error)
+	// GetRevisions 按 id 倒序分页，lastId 为 0 表示第一页，不返回内容
+	GetRevisions(ctx context.Context, aid int64, uid int64, lastId int64, limit int) ([]ArticleRevision, error)
+	GetRevision(ctx context.Context, aid int64, uid int64, rid int64) (ArticleRevision, error)
 }
 
 type PublishedArticle Article
@@ -45,20 +56,47 @@ func NewArticleGormDao(db *gorm.DB) ArticleDao {
 	}
 }
 
+// Insert 同一个事务里面保存一个版本，写修改事件
 func (a *ArticleGormDao) Insert(ctx context.Context, article Article) (int64, error) {
-	now := time.Now().UnixMilli()
-	article.Ctime = now
-	article.Utime = now
-	err := a.db.WithContext(ctx).Create(&article).Error
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := a.insert(tx, &article, time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+		return saveSyncEvent(ctx, tx, eventArticle.SyncTypeUpdated, article)
+	})
 	return article.Id, err
 }
 
+// UpdateById 同一个事务里面保存一个版本，写修改事件
 func (a *ArticleGormDao) UpdateById(ctx context.Context, article Article) error {
-	res := a.db.WithContext(ctx).Model(&Article{}).Where("id=? and author_id=?", article.Id, article.AuthorId).Updates(map[string]any{
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := a.updateById(tx, &article, time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+		return saveSyncEvent(ctx, tx, eventArticle.SyncTypeUpdated, article)
+	})
+}
+
+func (a *ArticleGormDao) insert(tx *gorm.DB, article *Article, now int64) error {
+	article.Ctime = now
+	article.Utime = now
+	err := tx.Create(article).Error
+	if err != nil {
+		return err
+	}
+	return a.insertRevision(tx, *article, now)
+}
+
+func (a *ArticleGormDao) updateById(tx *gorm.DB, article *Article, now int64) error {
+	article.Utime = now
+	res := tx.Model(&Article{}).Where("id=? and author_id=?", article.Id, article.AuthorId).Updates(map[string]any{
 		"title":   article.Title,
 		"content": article.Content,
 		"status":  article.Status,
-		"utime":   time.Now().UnixMilli(),
+		"tags":    article.Tags,
+		"utime":   now,
 	})
 	if res.Error != nil {
 		return res.Error
@@ -66,26 +104,24 @@ func (a *ArticleGormDao) UpdateById(ctx context.Context, article Article) error
 	if res.RowsAffected == 0 {
 		return ErrArticleAuthorNotMatch
 	}
-	return nil
+	return a.insertRevision(tx, *article, now)
 }
 
+// Sync 制作库、线上库和发表事件在同一个事务里面
 func (a *ArticleGormDao) Sync(ctx context.Context, art Article) (int64, error) {
-	var id = art.Id
 	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		dao := NewArticleGormDao(tx)
+		now := time.Now().UnixMilli()
 		var err error
 		if art.Id > 0 {
-			err = dao.UpdateById(ctx, art)
+			err = a.updateById(tx, &art, now)
 		} else {
-			id, err = dao.Insert(ctx, art)
-			art.Id = id
+			err = a.insert(tx, &art, now)
 		}
 
 		if err != nil {
 			return err
 		}
 
-		now := time.Now().UnixMilli()
 		publishedArticle := PublishedArticle(art)
 		publishedArticle.Ctime = now
 		publishedArticle.Utime = now
@@ -94,16 +130,24 @@ func (a *ArticleGormDao) Sync(ctx context.Context, art Article) (int64, error) {
 				"title":   publishedArticle.Title,
 				"content": publishedArticle.Content,
 				"status":  publishedArticle.Status,
+				"tags":    publishedArticle.Tags,
 				"utime":   now,
 			}),
 		}).Create(&publishedArticle).Error
-		return err
+		if err != nil {
+			return err
+		}
+		err = syncPubTags(tx, art.Id, art.Tags, now)
+		if err != nil {
+			return err
+		}
+		return saveSyncEvent(ctx, tx, eventArticle.SyncTypePublished, art)
 	})
 
 	if err != nil {
 		return 0, err
 	}
-	return id, err
+	return art.Id, nil
 }
 
 func (a *ArticleGormDao) SyncV1(ctx context.Context, art Article) (int64, error) {
@@ -113,23 +157,17 @@ func (a *ArticleGormDao) SyncV1(ctx context.Context, art Article) (int64, error)
 	}
 	defer tx.Rollback()
 
-	dao := NewArticleGormDao(tx)
-	var (
-		err error
-		id  = art.Id
-	)
-
+	now := time.Now().UnixMilli()
+	var err error
 	if art.Id > 0 {
-		err = dao.UpdateById(ctx, art)
+		err = a.updateById(tx, &art, now)
 	} else {
-		id, err = dao.Insert(ctx, art)
-		art.Id = id
+		err = a.insert(tx, &art, now)
 	}
 
 	if err != nil {
 		return 0, err
 	}
-	now := time.Now().UnixMilli()
 	publishedArticle := PublishedArticle(art)
 	publishedArticle.Ctime = now
 	publishedArticle.Utime = now
@@ -138,20 +176,28 @@ func (a *ArticleGormDao) SyncV1(ctx context.Context, art Article) (int64, error)
 			"title":   publishedArticle.Title,
 			"content": publishedArticle.Content,
 			"status":  publishedArticle.Status,
+			"tags":    publishedArticle.Tags,
 			"utime":   now,
 		}),
 	}).Create(&publishedArticle).Error
 	if err != nil {
 		return 0, err
 	}
+	err = syncPubTags(tx, art.Id, art.Tags, now)
+	if err != nil {
+		return 0, err
+	}
+	err = saveSyncEvent(ctx, tx, eventArticle.SyncTypePublished, art)
+	if err != nil {
+		return 0, err
+	}
 	tx.Commit()
-	return id, nil
+	return art.Id, nil
 
 }
 
 func (a *ArticleGormDao) SyncStatus(ctx context.Context, id int64, uid int64, status uint8) error {
 	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		defer tx.Rollback()
 		now := time.Now().UnixMilli()
 
 		res := tx.WithContext(ctx).Model(&Article{}).Where("id=? and author_id=?", id, uid).Updates(map[string]any{
@@ -176,15 +222,25 @@ func (a *ArticleGormDao) SyncStatus(ctx context.Context, id int64, uid int64, st
 		if res.RowsAffected == 0 {
 			return ErrArticleAuthorNotMatch
 		}
-		return nil
+		if status != domain.ArticleStatusPublished {
+			// 撤回之后按标签就查不到了
+			err := deletePubTags(tx, id)
+			if err != nil {
+				return err
+			}
+		}
+		typ := eventArticle.SyncTypeWithdrawn
+		if status == domain.ArticleStatusPublished {
+			typ = eventArticle.SyncTypePublished
+		}
+		return saveSyncEvent(ctx, tx, typ, Article{Id: id, AuthorId: uid, Status: status, Utime: now})
 	})
 }
 
-func (a *ArticleGormDao) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
+func (a *ArticleGormDao) GetByAuthor(ctx context.Context, uid int64, lastUtime int64, lastId int64, limit int) ([]Article, error) {
 	var arts []Article
-	err := a.db.WithContext(ctx).Model(&Article{}).
-		Where("author_id=?", uid).
-		Offset(offset).Limit(limit).Order("utime DESC").
+	err := afterCursor(a.db.WithContext(ctx).Model(&Article{}).Where("author_id=?", uid), lastUtime, lastId).
+		Order("utime DESC, id DESC").Limit(limit).
 		Find(&arts).Error
 	return arts, err
 }
@@ -207,8 +263,24 @@ func (a *ArticleGormDao) GetPubById(ctx context.Context, id int64) (PublishedArt
 	return art, err
 }
 
-func (a *ArticleGormDao) GetPubs(ctx context.Context, start int64, end int64, offset int, limit int) ([]PublishedArticle, error) {
+func (a *ArticleGormDao) GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	var arts []PublishedArticle
+	err := a.db.WithContext(ctx).Model(&PublishedArticle{}).Where("id in ?", ids).Find(&arts).Error
+	return arts, err
+}
+
+func (a *ArticleGormDao) GetPubs(ctx context.Context, start int64, end int64, lastUtime int64, lastId int64, limit int) ([]PublishedArticle, error) {
 	var arts []PublishedArticle
-	err := a.db.WithContext(ctx).Model(&PublishedArticle{}).Where("utime >= ? and utime <= ?", start, end).Offset(offset).Limit(limit).Find(&arts).Error
+	err := afterCursor(a.db.WithContext(ctx).Model(&PublishedArticle{}).Where("utime >= ? and utime <= ?", start, end), lastUtime, lastId).
+		Order("utime DESC, id DESC").Limit(limit).
+		Find(&arts).Error
 	return arts, err
 }
+
+// afterCursor 追加游标条件，只取排在 (lastUtime, lastId) 之后的数据
+func afterCursor(db *gorm.DB, lastUtime int64, lastId int64) *gorm.DB {
+	if lastId <= 0 {
+		return db
+	}
+	return db.Where("(utime < ? or (utime = ? and id < ?))", lastUtime, lastUtime, lastId)
+}
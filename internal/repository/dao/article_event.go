@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+
+	eventArticle "github.com/misakimei123/redbook/internal/events/article"
+	"github.com/misakimei123/redbook/pkg/outbox"
+	"gorm.io/gorm"
+)
+
+// saveSyncEvent 和文章在同一个事务里面写进 outbox，文章改成功了事件就不会丢
+func saveSyncEvent(ctx context.Context, tx *gorm.DB, typ string, art Article) error {
+	return outbox.Save(ctx, tx, eventArticle.TopicSyncEvent, strconv.FormatInt(art.Id, 10), eventArticle.SyncEvent{
+		Version: eventArticle.SyncEventVersion,
+		Type:    typ,
+		Aid:     art.Id,
+		Uid:     art.AuthorId,
+		Title:   art.Title,
+		Content: art.Content,
+		Status:  art.Status,
+		Tags:    art.Tags,
+		Utime:   art.Utime,
+	})
+}
@@ -0,0 +1,56 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ArticleRevision 文章的历史版本，只增不改
+type ArticleRevision struct {
+	Id        int64  `gorm:"primaryKey, autoIncrement" bson:"id,omitempty"`
+	ArticleId int64  `gorm:"index:article_author,priority:1" bson:"article_id,omitempty"`
+	AuthorId  int64  `gorm:"index:article_author,priority:2" bson:"author_id,omitempty"`
+	Title     string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
+	Content   string `gorm:"type=BLOB" bson:"content,omitempty"`
+	Status    uint8  `bson:"status,omitempty"`
+	Tags      Tags   `gorm:"type:varchar(1024)" bson:"tags"`
+	Ctime     int64  `bson:"ctime,omitempty"`
+}
+
+func newArticleRevision(art Article, now int64) ArticleRevision {
+	return ArticleRevision{
+		ArticleId: art.Id,
+		AuthorId:  art.AuthorId,
+		Title:     art.Title,
+		Content:   art.Content,
+		Status:    art.Status,
+		Tags:      art.Tags,
+		Ctime:     now,
+	}
+}
+
+func (a *ArticleGormDao) GetRevisions(ctx context.Context, aid int64, uid int64, lastId int64, limit int) ([]ArticleRevision, error) {
+	var revisions []ArticleRevision
+	db := a.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Select("id", "article_id", "author_id", "title", "status", "tags", "ctime").
+		Where("article_id = ? and author_id = ?", aid, uid)
+	if lastId > 0 {
+		db = db.Where("id < ?", lastId)
+	}
+	err := db.Order("id DESC").Limit(limit).Find(&revisions).Error
+	return revisions, err
+}
+
+func (a *ArticleGormDao) GetRevision(ctx context.Context, aid int64, uid int64, rid int64) (ArticleRevision, error) {
+	var revision ArticleRevision
+	err := a.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Where("id = ? and article_id = ? and author_id = ?", rid, aid, uid).
+		First(&revision).Error
+	return revision, err
+}
+
+func (a *ArticleGormDao) insertRevision(tx *gorm.DB, art Article, now int64) error {
+	revision := newArticleRevision(art, now)
+	return tx.Create(&revision).Error
+}
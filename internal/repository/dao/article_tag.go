@@ -0,0 +1,101 @@
+package dao
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Tags 在 MySQL 里面存成 JSON 字符串，在 MongoDB 里面就是数组
+type Tags []string
+
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	val, err := json.Marshal(t)
+	return string(val), err
+}
+
+func (t *Tags) Scan(src any) error {
+	var val []byte
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		val = v
+	case string:
+		val = []byte(v)
+	default:
+		return errors.New("tags: unsupported type")
+	}
+	return json.Unmarshal(val, t)
+}
+
+// PublishedArticleTag 按标签查线上文章用的索引表，文章撤回之后删掉
+type PublishedArticleTag struct {
+	Id    int64  `gorm:"primaryKey, autoIncrement"`
+	Tag   string `gorm:"type:varchar(128);uniqueIndex:tag_aid,priority:1;index:tag_utime,priority:1"`
+	Aid   int64  `gorm:"uniqueIndex:tag_aid,priority:2;index"`
+	Utime int64  `gorm:"index:tag_utime,priority:2"`
+}
+
+// syncPubTags 用文章现在的标签覆盖掉旧的
+func syncPubTags(tx *gorm.DB, aid int64, tags Tags, now int64) error {
+	err := deletePubTags(tx, aid)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+	rows := make([]PublishedArticleTag, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, PublishedArticleTag{Tag: tag, Aid: aid, Utime: now})
+	}
+	return tx.Create(&rows).Error
+}
+
+func deletePubTags(tx *gorm.DB, aid int64) error {
+	return tx.Where("aid = ?", aid).Delete(&PublishedArticleTag{}).Error
+}
+
+// pubIdsByTag 按 (utime, aid) 倒序分页
+func pubIdsByTag(ctx context.Context, db *gorm.DB, tag string, lastUtime int64, lastId int64, limit int) ([]int64, error) {
+	query := db.WithContext(ctx).Model(&PublishedArticleTag{}).Where("tag = ?", tag)
+	if lastId > 0 {
+		query = query.Where("(utime < ? or (utime = ? and aid < ?))", lastUtime, lastUtime, lastId)
+	}
+	var aids []int64
+	err := query.Order("utime DESC, aid DESC").Limit(limit).Pluck("aid", &aids).Error
+	return aids, err
+}
+
+func (a *ArticleGormDao) GetPubsByTag(ctx context.Context, tag string, lastUtime int64, lastId int64, limit int) ([]PublishedArticle, error) {
+	aids, err := pubIdsByTag(ctx, a.db, tag, lastUtime, lastId, limit)
+	if err != nil || len(aids) == 0 {
+		return nil, err
+	}
+	var arts []PublishedArticle
+	err = a.db.WithContext(ctx).Model(&PublishedArticle{}).Where("id IN ?", aids).Find(&arts).Error
+	if err != nil {
+		return nil, err
+	}
+	return sortByIds(aids, arts, func(art PublishedArticle) int64 { return art.Id }), nil
+}
+
+// sortByIds 按 ids 的顺序排好，IN 查询不保证顺序
+func sortByIds[T any](ids []int64, items []T, idFn func(T) int64) []T {
+	m := make(map[int64]T, len(items))
+	for _, item := range items {
+		m[idFn(item)] = item
+	}
+	res := make([]T, 0, len(items))
+	for _, id := range ids {
+		if item, ok := m[id]; ok {
+			res = append(res, item)
+		}
+	}
+	return res
+}
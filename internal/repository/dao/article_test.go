@@ -0,0 +1,74 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestArticleGormDao_SyncStatus(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "withdraw with events",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` .*").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `published_articles` .*").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM .*").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO `outbox_messages` .*").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "outbox fail, rollback",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` .*").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `published_articles` .*").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM .*").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO `outbox_messages` .*").WillReturnError(errors.New("mock db fail"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("mock db fail"),
+		},
+		{
+			name: "not author, no events",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` .*").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrArticleAuthorNotMatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			tc.mock(mock)
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sqlDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				SkipDefaultTransaction: true,
+				DisableAutomaticPing:   true,
+			})
+			assert.NoError(t, err)
+			dao := NewArticleGormDao(db)
+			err = dao.SyncStatus(context.Background(), 1, 123, domain.ArticleStatusPrivate)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrCommentNotFound = gorm.ErrRecordNotFound
+
+// Comment 根评论的 root_id 是 0，(biz, biz_id, root_id) 用来列根评论，root_id 用来列回复
+type Comment struct {
+	Id       int64  `gorm:"primaryKey, autoIncrement"`
+	Uid      int64  `gorm:"index"`
+	Biz      string `gorm:"type:varchar(128);index:biz_root,priority:1"`
+	BizId    int64  `gorm:"index:biz_root,priority:2"`
+	RootId   int64  `gorm:"index:biz_root,priority:3;index"`
+	ParentId int64
+	Content  string `gorm:"type:text"`
+	Ctime    int64
+	Utime    int64
+}
+
+type CommentDao interface {
+	Insert(ctx context.Context, c Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (Comment, error)
+	// FindRoots 按 id 倒序，lastId 为 0 表示第一页
+	FindRoots(ctx context.Context, biz string, bizId int64, lastId int64, limit int) ([]Comment, error)
+	// FindReplies 按 id 正序，lastId 为 0 表示第一页
+	FindReplies(ctx context.Context, rootId int64, lastId int64, limit int) ([]Comment, error)
+	// FindRepliesByRoots 每个根评论取最早的 limit 条回复，用到了窗口函数，要求 MySQL 8.0 及以上
+	FindRepliesByRoots(ctx context.Context, rootIds []int64, limit int) ([]Comment, error)
+	CountReplies(ctx context.Context, rootIds []int64) (map[int64]int64, error)
+	// Delete 删根评论会连带删掉它下面所有的回复，返回一共删了多少条
+	Delete(ctx context.Context, c Comment) (int64, error)
+}
+
+type GormCommentDao struct {
+	db *gorm.DB
+}
+
+func NewGormCommentDao(db *gorm.DB) CommentDao {
+	return &GormCommentDao{db: db}
+}
+
+func (g *GormCommentDao) Insert(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GormCommentDao) FindById(ctx context.Context, id int64) (Comment, error) {
+	var c Comment
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	return c, err
+}
+
+func (g *GormCommentDao) FindRoots(ctx context.Context, biz string, bizId int64, lastId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	db := g.db.WithContext(ctx).Where("biz = ? AND biz_id = ? AND root_id = 0", biz, bizId)
+	if lastId > 0 {
+		db = db.Where("id < ?", lastId)
+	}
+	err := db.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormCommentDao) FindReplies(ctx context.Context, rootId int64, lastId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).Where("root_id = ? AND id > ?", rootId, lastId).
+		Order("id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+// FindRepliesByRoots ROW_NUMBER 是 MySQL 8.0 才有的，5.7 上要换成按根评论逐个 FindReplies
+func (g *GormCommentDao) FindRepliesByRoots(ctx context.Context, rootIds []int64, limit int) ([]Comment, error) {
+	var res []Comment
+	if len(rootIds) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Raw("SELECT * FROM (SELECT *, ROW_NUMBER() OVER (PARTITION BY root_id ORDER BY id) AS rn "+
+		"FROM comments WHERE root_id IN ?) t WHERE rn <= ? ORDER BY root_id, id", rootIds, limit).
+		Scan(&res).Error
+	return res, err
+}
+
+func (g *GormCommentDao) CountReplies(ctx context.Context, rootIds []int64) (map[int64]int64, error) {
+	type cnt struct {
+		RootId int64
+		Cnt    int64
+	}
+	var cnts []cnt
+	res := make(map[int64]int64, len(rootIds))
+	if len(rootIds) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Model(&Comment{}).Select("root_id, COUNT(*) AS cnt").
+		Where("root_id IN ?", rootIds).Group("root_id").Scan(&cnts).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cnts {
+		res[c.RootId] = c.Cnt
+	}
+	return res, nil
+}
+
+func (g *GormCommentDao) Delete(ctx context.Context, c Comment) (int64, error) {
+	db := g.db.WithContext(ctx).Where("id = ?", c.Id)
+	if c.RootId == 0 {
+		db = db.Or("root_id = ?", c.Id)
+	}
+	res := db.Delete(&Comment{})
+	return res.RowsAffected, res.Error
+}
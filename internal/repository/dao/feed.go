@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeedPushItem 推模式，作者发表的时候写到每个粉丝的收件箱
+type FeedPushItem struct {
+	Id       int64  `gorm:"primaryKey, autoIncrement"`
+	Uid      int64  `gorm:"uniqueIndex:uid_aid;index:uid_ctime,priority:1;index:uid_author,priority:1"`
+	Aid      int64  `gorm:"uniqueIndex:uid_aid;index"`
+	AuthorId int64  `gorm:"index:uid_author,priority:2"`
+	Title    string `gorm:"type:varchar(4096)"`
+	Abstract string `gorm:"type:varchar(1024)"`
+	Ctime    int64  `gorm:"index:uid_ctime,priority:2"`
+}
+
+// FeedPullItem 拉模式，大 V 发表的时候只写自己的发件箱，读的时候再合并
+type FeedPullItem struct {
+	Id       int64  `gorm:"primaryKey, autoIncrement"`
+	Aid      int64  `gorm:"unique"`
+	AuthorId int64  `gorm:"index:author_ctime,priority:1"`
+	Title    string `gorm:"type:varchar(4096)"`
+	Abstract string `gorm:"type:varchar(1024)"`
+	Ctime    int64  `gorm:"index:author_ctime,priority:2"`
+}
+
+type FeedDao interface {
+	// InsertPushItems 重复发表的时候只更新标题和摘要
+	InsertPushItems(ctx context.Context, items []FeedPushItem) error
+	InsertPullItem(ctx context.Context, item FeedPullItem) error
+	// DeleteByAid 两种模式的都删
+	DeleteByAid(ctx context.Context, aid int64) error
+	// DeletePushItemsByAuthor 删掉 uid 收件箱里面 authorId 推过来的
+	DeletePushItemsByAuthor(ctx context.Context, uid int64, authorId int64) error
+	// FindPushItems 按 (ctime, aid) 倒序，lastAid 为 0 表示第一页
+	FindPushItems(ctx context.Context, uid int64, lastCtime int64, lastAid int64, limit int) ([]FeedPushItem, error)
+	FindPullItems(ctx context.Context, authorIds []int64, lastCtime int64, lastAid int64, limit int) ([]FeedPullItem, error)
+}
+
+type GormFeedDao struct {
+	db *gorm.DB
+}
+
+func NewGormFeedDao(db *gorm.DB) FeedDao {
+	return &GormFeedDao{db: db}
+}
+
+func (g *GormFeedDao) InsertPushItems(ctx context.Context, items []FeedPushItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"title", "abstract"}),
+	}).Create(&items).Error
+}
+
+func (g *GormFeedDao) InsertPullItem(ctx context.Context, item FeedPullItem) error {
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"title", "abstract"}),
+	}).Create(&item).Error
+}
+
+func (g *GormFeedDao) DeleteByAid(ctx context.Context, aid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("aid = ?", aid).Delete(&FeedPushItem{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("aid = ?", aid).Delete(&FeedPullItem{}).Error
+	})
+}
+
+func (g *GormFeedDao) DeletePushItemsByAuthor(ctx context.Context, uid int64, authorId int64) error {
+	return g.db.WithContext(ctx).Where("uid = ? AND author_id = ?", uid, authorId).Delete(&FeedPushItem{}).Error
+}
+
+func (g *GormFeedDao) FindPushItems(ctx context.Context, uid int64, lastCtime int64, lastAid int64, limit int) ([]FeedPushItem, error) {
+	var res []FeedPushItem
+	err := afterFeedCursor(g.db.WithContext(ctx).Where("uid = ?", uid), lastCtime, lastAid).
+		Order("ctime DESC, aid DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormFeedDao) FindPullItems(ctx context.Context, authorIds []int64, lastCtime int64, lastAid int64, limit int) ([]FeedPullItem, error) {
+	var res []FeedPullItem
+	if len(authorIds) == 0 {
+		return res, nil
+	}
+	err := afterFeedCursor(g.db.WithContext(ctx).Where("author_id IN ?", authorIds), lastCtime, lastAid).
+		Order("ctime DESC, aid DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func afterFeedCursor(db *gorm.DB, lastCtime int64, lastAid int64) *gorm.DB {
+	if lastAid <= 0 {
+		return db
+	}
+	return db.Where("(ctime < ? or (ctime = ? and aid < ?))", lastCtime, lastCtime, lastAid)
+}
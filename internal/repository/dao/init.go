@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/misakimei123/redbook/pkg/outbox"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -15,7 +16,12 @@ func InitTables(db *gorm.DB) error {
 		&User{}, &Profile{},
 		&Article{},
 		&PublishedArticle{},
-		// &Job{},
+		&ArticleRevision{},
+		&PublishedArticleTag{},
+		&Job{}, &JobExecution{}, &RankingSnapshot{},
+		&Comment{},
+		&FeedPushItem{}, &FeedPullItem{},
+		&outbox.Message{},
 	)
 }
 
@@ -26,6 +32,7 @@ func InitCollection(db *mongo.Database) error {
 	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
 		{Keys: bson.D{bson.E{"id", 1}}, Options: options.Index().SetUnique(true)},
 		{Keys: bson.D{bson.E{"author_id", 1}}},
+		{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "utime", Value: -1}, {Key: "id", Value: -1}}},
 	})
 	if err != nil {
 		return err
@@ -34,6 +41,16 @@ func InitCollection(db *mongo.Database) error {
 	_, err = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
 		{Keys: bson.D{bson.E{"id", 1}}, Options: options.Index().SetUnique(true)},
 		{Keys: bson.D{bson.E{"author_id", 1}}},
+		{Keys: bson.D{{Key: "utime", Value: -1}, {Key: "id", Value: -1}}},
+		{Keys: bson.D{{Key: "tags", Value: 1}, {Key: "utime", Value: -1}, {Key: "id", Value: -1}}},
+	})
+	if err != nil {
+		return err
+	}
+	col = db.Collection("article_revisions")
+	_, err = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "article_id", Value: 1}, {Key: "author_id", Value: 1}, {Key: "id", Value: -1}}},
 	})
 	return err
 }
@@ -2,9 +2,21 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrJobNotFound  = errors.New("job not found or not waiting")
+	ErrDuplicateJob = errors.New("job name duplicated")
+	// ErrJobLost 任务已经被别的节点抢走了，或者执行期间被重新安排了
+	ErrJobLost = errors.New("job is preempted by another node")
+	// ErrNoMoreJob 当前没有可以抢占的任务
+	ErrNoMoreJob = errors.New("no job to preempt")
 )
 
 type Job struct {
@@ -16,6 +28,8 @@ type Job struct {
 	Status     uint8
 	NextTime   int64 `gorm:"index"`
 	Version    int
+	Owner      string `gorm:"type:varchar(128)"`
+	Attempts   int
 	Ctime      int64
 	Utime      int64
 }
@@ -33,22 +47,49 @@ const (
 	jobStatusWaiting = iota
 	jobStatusRunning
 	jobStatusPaused
+	// jobStatusFinished 一次性任务执行完之后不会再被抢占
+	jobStatusFinished
 )
 
 type JobDao interface {
-	Preempt(ctx context.Context) (Job, error)
-	Release(ctx context.Context, jid int64) error
-	UpdateUtime(ctx context.Context, jid int64) error
-	UpdateNextTime(ctx context.Context, jid int64, nextTime time.Time) error
+	Preempt(ctx context.Context, owner string) (Job, error)
+	// Release、UpdateUtime、UpdateNextTime、Retry 和 Finish 都要 owner 和 version 对得上，对不上返回 ErrJobLost
+	Release(ctx context.Context, jid int64, owner string, version int) error
+	UpdateUtime(ctx context.Context, jid int64, owner string, version int) error
+	// UpdateNextTime、Retry 和 Finish 还要 next_time 等于抢占时候的 scheduled，
+	// 执行期间任务被 Upsert 重新安排了也返回 ErrJobLost，释放之后按新的时间执行
+	// UpdateNextTime 执行成功之后调用，同时清零失败次数
+	UpdateNextTime(ctx context.Context, jid int64, owner string, version int, scheduled int64, nextTime time.Time) error
+	// Retry 记下连续失败的次数，到 nextTime 再重试
+	Retry(ctx context.Context, jid int64, owner string, version int, scheduled int64, attempts int, nextTime time.Time) error
+	// Upsert 按 name 插入或者覆盖任务，覆盖之后重新进入等待状态，正在执行的任务保持运行中
+	Upsert(ctx context.Context, j Job) error
+	// UpdateNextTimeByName 只修改还在等待中的任务
+	UpdateNextTimeByName(ctx context.Context, name string, nextTime time.Time) error
+	// DeleteByName 只删除等待中和暂停的任务
+	DeleteByName(ctx context.Context, name string) error
+	Finish(ctx context.Context, jid int64, owner string, version int, scheduled int64) error
+	// Insert 同名的任务已经存在返回 ErrDuplicateJob
+	Insert(ctx context.Context, j Job) error
+	FindByName(ctx context.Context, name string) (Job, error)
+	// Pause 只暂停等待中和运行中的任务，运行中的任务这次执行完之后不会再被调度
+	Pause(ctx context.Context, name string) error
+	// Resume 只恢复暂停的任务
+	Resume(ctx context.Context, name string, nextTime time.Time) error
+	List(ctx context.Context, offset int, limit int) ([]Job, error)
 }
 
-func (g *GormJobDao) Preempt(ctx context.Context) (Job, error) {
+// Preempt 抢占成功之后记录 owner，释放的时候清空，返回的 version 是后面续约和释放的凭证
+func (g *GormJobDao) Preempt(ctx context.Context, owner string) (Job, error) {
 	db := g.db
 	for {
 		var j Job
 		now := time.Now().UnixMilli()
 		err := db.WithContext(ctx).Where("(status = ? and next_time < ?) or (status = ? and utime <= ?)",
 			jobStatusWaiting, now, jobStatusRunning, now-g.jobTimeOut.Milliseconds()).First(&j).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return j, ErrNoMoreJob
+		}
 		if err != nil {
 			return j, err
 		}
@@ -56,6 +97,7 @@ func (g *GormJobDao) Preempt(ctx context.Context) (Job, error) {
 			"status":  jobStatusRunning,
 			"utime":   now,
 			"version": j.Version + 1,
+			"owner":   owner,
 		})
 		if res.Error != nil {
 			return j, res.Error
@@ -63,25 +105,178 @@ func (g *GormJobDao) Preempt(ctx context.Context) (Job, error) {
 		if res.RowsAffected == 0 {
 			continue
 		}
+		j.Owner = owner
+		j.Version++
 		return j, err
 	}
 }
 
-func (g *GormJobDao) Release(ctx context.Context, jid int64) error {
-	return g.db.WithContext(ctx).Model(&Job{}).Where("id = ?", jid).Updates(map[string]any{
-		"status": jobStatusWaiting,
-		"utime":  time.Now().UnixMilli(),
-	}).Error
+// Release 只把运行中的任务改回等待，已经结束或者执行中被暂停的任务保持原来的状态
+func (g *GormJobDao) Release(ctx context.Context, jid int64, owner string, version int) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? and owner = ? and version = ?", jid, owner, version).
+		Updates(map[string]any{
+			"status": gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", jobStatusRunning, jobStatusWaiting),
+			"owner":  "",
+			"utime":  time.Now().UnixMilli(),
+		})
+	return g.fenced(res)
+}
+
+func (g *GormJobDao) UpdateUtime(ctx context.Context, jid int64, owner string, version int) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? and owner = ? and version = ?", jid, owner, version).
+		Updates(map[string]any{
+			"utime": time.Now().UnixMilli(),
+		})
+	return g.fenced(res)
+}
+
+// fenced 没有更新到说明任务已经被别的节点抢走了
+func (g *GormJobDao) fenced(res *gorm.DB) error {
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobLost
+	}
+	return nil
 }
 
-func (g *GormJobDao) UpdateUtime(ctx context.Context, jid int64) error {
-	return g.db.WithContext(ctx).Model(&Job{}).Where("id = ?", jid).Updates(map[string]any{
-		"utime": time.Now().UnixMilli(),
-	}).Error
+func (g *GormJobDao) UpdateNextTime(ctx context.Context, jid int64, owner string, version int, scheduled int64, nextTime time.Time) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? and owner = ? and version = ? and next_time = ?", jid, owner, version, scheduled).
+		Updates(map[string]any{
+			"next_time": nextTime.UnixMilli(),
+			"attempts":  0,
+			"utime":     time.Now().UnixMilli(),
+		})
+	return g.fenced(res)
 }
 
-func (g *GormJobDao) UpdateNextTime(ctx context.Context, jid int64, nextTime time.Time) error {
-	return g.db.WithContext(ctx).Model(&Job{}).Where("id = ?", jid).Updates(map[string]any{
+func (g *GormJobDao) Retry(ctx context.Context, jid int64, owner string, version int, scheduled int64, attempts int, nextTime time.Time) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? and owner = ? and version = ? and next_time = ?", jid, owner, version, scheduled).
+		Updates(map[string]any{
+			"next_time": nextTime.UnixMilli(),
+			"attempts":  attempts,
+			"utime":     time.Now().UnixMilli(),
+		})
+	return g.fenced(res)
+}
+
+func (g *GormJobDao) Upsert(ctx context.Context, j Job) error {
+	now := time.Now().UnixMilli()
+	j.Status = jobStatusWaiting
+	j.Ctime = now
+	j.Utime = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"expression": j.Expression,
+			"executor":   j.Executor,
+			"cfg":        j.Cfg,
+			"status":     gorm.Expr("CASE WHEN status = ? THEN status ELSE ? END", jobStatusRunning, jobStatusWaiting),
+			"next_time":  j.NextTime,
+			"utime":      now,
+		}),
+	}).Create(&j).Error
+}
+
+func (g *GormJobDao) UpdateNextTimeByName(ctx context.Context, name string, nextTime time.Time) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).Where("name = ? and status = ?", name, jobStatusWaiting).Updates(map[string]any{
 		"next_time": nextTime.UnixMilli(),
-	}).Error
+		"utime":     time.Now().UnixMilli(),
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func (g *GormJobDao) DeleteByName(ctx context.Context, name string) error {
+	res := g.db.WithContext(ctx).Where("name = ? and status in ?", name, []uint8{jobStatusWaiting, jobStatusPaused}).Delete(&Job{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+// Finish 不清空 owner，执行完之后还要靠 owner 和 version 释放任务
+func (g *GormJobDao) Finish(ctx context.Context, jid int64, owner string, version int, scheduled int64) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? and owner = ? and version = ? and next_time = ?", jid, owner, version, scheduled).
+		Updates(map[string]any{
+			"status":   jobStatusFinished,
+			"attempts": 0,
+			"utime":    time.Now().UnixMilli(),
+		})
+	return g.fenced(res)
+}
+
+func (g *GormJobDao) Insert(ctx context.Context, j Job) error {
+	now := time.Now().UnixMilli()
+	j.Status = jobStatusWaiting
+	j.Ctime = now
+	j.Utime = now
+	err := g.db.WithContext(ctx).Create(&j).Error
+	if sqlError, ok := err.(*mysql.MySQLError); ok {
+		const duplicateErr uint16 = 1062
+		if sqlError.Number == duplicateErr {
+			return ErrDuplicateJob
+		}
+	}
+	return err
+}
+
+func (g *GormJobDao) FindByName(ctx context.Context, name string) (Job, error) {
+	var j Job
+	err := g.db.WithContext(ctx).Where("name = ?", name).First(&j).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return j, ErrJobNotFound
+	}
+	return j, err
+}
+
+func (g *GormJobDao) Pause(ctx context.Context, name string) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("name = ? and status in ?", name, []uint8{jobStatusWaiting, jobStatusRunning}).
+		Updates(map[string]any{
+			"status": jobStatusPaused,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func (g *GormJobDao) Resume(ctx context.Context, name string, nextTime time.Time) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).Where("name = ? and status = ?", name, jobStatusPaused).Updates(map[string]any{
+		"status":    jobStatusWaiting,
+		"next_time": nextTime.UnixMilli(),
+		"utime":     time.Now().UnixMilli(),
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func (g *GormJobDao) List(ctx context.Context, offset int, limit int) ([]Job, error) {
+	var res []Job
+	err := g.db.WithContext(ctx).Order("id").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
 }
@@ -0,0 +1,56 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// JobExecution 任务每执行一次记录一条
+type JobExecution struct {
+	Id      int64  `gorm:"primaryKey, autoIncrement"`
+	JobId   int64  `gorm:"index"`
+	JobName string `gorm:"type:varchar(128);index"`
+	Node    string `gorm:"type:varchar(128)"`
+	Attempt int
+	Status  uint8
+	Error   string `gorm:"type:text"`
+	Start   int64
+	End     int64
+}
+
+type JobExecutionDao interface {
+	Insert(ctx context.Context, e JobExecution) (int64, error)
+	// Finish 记录执行的结果
+	Finish(ctx context.Context, id int64, status uint8, errMsg string, end int64) error
+	// ListByName 最近的执行记录在前面
+	ListByName(ctx context.Context, name string, offset int, limit int) ([]JobExecution, error)
+}
+
+type GormJobExecutionDao struct {
+	db *gorm.DB
+}
+
+func NewGormJobExecutionDao(db *gorm.DB) JobExecutionDao {
+	return &GormJobExecutionDao{db: db}
+}
+
+func (g *GormJobExecutionDao) Insert(ctx context.Context, e JobExecution) (int64, error) {
+	err := g.db.WithContext(ctx).Create(&e).Error
+	return e.Id, err
+}
+
+func (g *GormJobExecutionDao) Finish(ctx context.Context, id int64, status uint8, errMsg string, end int64) error {
+	return g.db.WithContext(ctx).Model(&JobExecution{}).Where("id = ?", id).Updates(map[string]any{
+		"status": status,
+		"error":  errMsg,
+		"end":    end,
+	}).Error
+}
+
+func (g *GormJobExecutionDao) ListByName(ctx context.Context, name string, offset int, limit int) ([]JobExecution, error) {
+	var res []JobExecution
+	err := g.db.WithContext(ctx).Where("job_name = ?", name).
+		Order("id desc").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGormJobDao_Finish(t *testing.T) {
+	testCases := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "finished", affected: 1},
+		{name: "preempted by others", affected: 0, wantErr: ErrJobLost},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? and owner = \\? and version = \\? and next_time = \\?").
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1), "node-1", 3, int64(1000)).
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sqlDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				SkipDefaultTransaction: true,
+				DisableAutomaticPing:   true,
+			})
+			assert.NoError(t, err)
+			dao := NewGormJobDao(db)
+			err = dao.Finish(context.Background(), 1, "node-1", 3, 1000)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// 覆盖正在执行的任务不能把它改回等待中，不然会被别的节点再抢一次
+func TestGormJobDao_Upsert(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	mock.ExpectExec("INSERT INTO `jobs` .* ON DUPLICATE KEY UPDATE .*`status`=CASE WHEN status = \\? THEN status ELSE \\? END").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	assert.NoError(t, err)
+	dao := NewGormJobDao(db)
+	err = dao.Upsert(context.Background(), Job{Name: "article_publish_1", Executor: "article_publish"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
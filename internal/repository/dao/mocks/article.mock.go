@@ -41,18 +41,18 @@ func (m *MockArticleDao) EXPECT() *MockArticleDaoMockRecorder {
 }
 
 // GetByAuthor mocks base method.
-func (m *MockArticleDao) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]dao.Article, error) {
+func (m *MockArticleDao) GetByAuthor(ctx context.Context, uid, lastUtime, lastId int64, limit int) ([]dao.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, lastUtime, lastId, limit)
 	ret0, _ := ret[0].([]dao.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByAuthor indicates an expected call of GetByAuthor.
-func (mr *MockArticleDaoMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+func (mr *MockArticleDaoMockRecorder) GetByAuthor(ctx, uid, lastUtime, lastId, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleDao)(nil).GetByAuthor), ctx, uid, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleDao)(nil).GetByAuthor), ctx, uid, lastUtime, lastId, limit)
 }
 
 // GetById mocks base method.
@@ -85,19 +85,79 @@ func (mr *MockArticleDaoMockRecorder) GetPubById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleDao)(nil).GetPubById), ctx, id)
 }
 
+// GetPubByIds mocks base method.
+func (m *MockArticleDao) GetPubByIds(ctx context.Context, ids []int64) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByIds", ctx, ids)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByIds indicates an expected call of GetPubByIds.
+func (mr *MockArticleDaoMockRecorder) GetPubByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByIds", reflect.TypeOf((*MockArticleDao)(nil).GetPubByIds), ctx, ids)
+}
+
 // GetPubs mocks base method.
-func (m *MockArticleDao) GetPubs(ctx context.Context, start, end int64, offset, limit int) ([]dao.PublishedArticle, error) {
+func (m *MockArticleDao) GetPubs(ctx context.Context, start, end, lastUtime, lastId int64, limit int) ([]dao.PublishedArticle, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPubs", ctx, start, end, offset, limit)
+	ret := m.ctrl.Call(m, "GetPubs", ctx, start, end, lastUtime, lastId, limit)
 	ret0, _ := ret[0].([]dao.PublishedArticle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPubs indicates an expected call of GetPubs.
-func (mr *MockArticleDaoMockRecorder) GetPubs(ctx, start, end, offset, limit any) *gomock.Call {
+func (mr *MockArticleDaoMockRecorder) GetPubs(ctx, start, end, lastUtime, lastId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubs", reflect.TypeOf((*MockArticleDao)(nil).GetPubs), ctx, start, end, lastUtime, lastId, limit)
+}
+
+// GetPubsByTag mocks base method.
+func (m *MockArticleDao) GetPubsByTag(ctx context.Context, tag string, lastUtime, lastId int64, limit int) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubsByTag", ctx, tag, lastUtime, lastId, limit)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubsByTag indicates an expected call of GetPubsByTag.
+func (mr *MockArticleDaoMockRecorder) GetPubsByTag(ctx, tag, lastUtime, lastId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubsByTag", reflect.TypeOf((*MockArticleDao)(nil).GetPubsByTag), ctx, tag, lastUtime, lastId, limit)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticleDao) GetRevision(ctx context.Context, aid, uid, rid int64) (dao.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, aid, uid, rid)
+	ret0, _ := ret[0].(dao.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleDaoMockRecorder) GetRevision(ctx, aid, uid, rid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleDao)(nil).GetRevision), ctx, aid, uid, rid)
+}
+
+// GetRevisions mocks base method.
+func (m *MockArticleDao) GetRevisions(ctx context.Context, aid, uid, lastId int64, limit int) ([]dao.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, aid, uid, lastId, limit)
+	ret0, _ := ret[0].([]dao.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockArticleDaoMockRecorder) GetRevisions(ctx, aid, uid, lastId, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubs", reflect.TypeOf((*MockArticleDao)(nil).GetPubs), ctx, start, end, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockArticleDao)(nil).GetRevisions), ctx, aid, uid, lastId, limit)
 }
 
 // Insert mocks base method.
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/dao/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/dao/feed.go -package=daomocks -destination=./internal/repository/dao/mocks/feed.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/misakimei123/redbook/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedDao is a mock of FeedDao interface.
+type MockFeedDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedDaoMockRecorder
+}
+
+// MockFeedDaoMockRecorder is the mock recorder for MockFeedDao.
+type MockFeedDaoMockRecorder struct {
+	mock *MockFeedDao
+}
+
+// NewMockFeedDao creates a new mock instance.
+func NewMockFeedDao(ctrl *gomock.Controller) *MockFeedDao {
+	mock := &MockFeedDao{ctrl: ctrl}
+	mock.recorder = &MockFeedDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedDao) EXPECT() *MockFeedDaoMockRecorder {
+	return m.recorder
+}
+
+// DeleteByAid mocks base method.
+func (m *MockFeedDao) DeleteByAid(ctx context.Context, aid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByAid", ctx, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByAid indicates an expected call of DeleteByAid.
+func (mr *MockFeedDaoMockRecorder) DeleteByAid(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByAid", reflect.TypeOf((*MockFeedDao)(nil).DeleteByAid), ctx, aid)
+}
+
+// DeletePushItemsByAuthor mocks base method.
+func (m *MockFeedDao) DeletePushItemsByAuthor(ctx context.Context, uid, authorId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePushItemsByAuthor", ctx, uid, authorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePushItemsByAuthor indicates an expected call of DeletePushItemsByAuthor.
+func (mr *MockFeedDaoMockRecorder) DeletePushItemsByAuthor(ctx, uid, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePushItemsByAuthor", reflect.TypeOf((*MockFeedDao)(nil).DeletePushItemsByAuthor), ctx, uid, authorId)
+}
+
+// FindPullItems mocks base method.
+func (m *MockFeedDao) FindPullItems(ctx context.Context, authorIds []int64, lastCtime, lastAid int64, limit int) ([]dao.FeedPullItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPullItems", ctx, authorIds, lastCtime, lastAid, limit)
+	ret0, _ := ret[0].([]dao.FeedPullItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPullItems indicates an expected call of FindPullItems.
+func (mr *MockFeedDaoMockRecorder) FindPullItems(ctx, authorIds, lastCtime, lastAid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPullItems", reflect.TypeOf((*MockFeedDao)(nil).FindPullItems), ctx, authorIds, lastCtime, lastAid, limit)
+}
+
+// FindPushItems mocks base method.
+func (m *MockFeedDao) FindPushItems(ctx context.Context, uid, lastCtime, lastAid int64, limit int) ([]dao.FeedPushItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPushItems", ctx, uid, lastCtime, lastAid, limit)
+	ret0, _ := ret[0].([]dao.FeedPushItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPushItems indicates an expected call of FindPushItems.
+func (mr *MockFeedDaoMockRecorder) FindPushItems(ctx, uid, lastCtime, lastAid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPushItems", reflect.TypeOf((*MockFeedDao)(nil).FindPushItems), ctx, uid, lastCtime, lastAid, limit)
+}
+
+// InsertPullItem mocks base method.
+func (m *MockFeedDao) InsertPullItem(ctx context.Context, item dao.FeedPullItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPullItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPullItem indicates an expected call of InsertPullItem.
+func (mr *MockFeedDaoMockRecorder) InsertPullItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPullItem", reflect.TypeOf((*MockFeedDao)(nil).InsertPullItem), ctx, item)
+}
+
+// InsertPushItems mocks base method.
+func (m *MockFeedDao) InsertPushItems(ctx context.Context, items []dao.FeedPushItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPushItems", ctx, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPushItems indicates an expected call of InsertPushItems.
+func (mr *MockFeedDaoMockRecorder) InsertPushItems(ctx, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPushItems", reflect.TypeOf((*MockFeedDao)(nil).InsertPushItems), ctx, items)
+}
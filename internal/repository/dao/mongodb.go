@@ -2,7 +2,9 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"github.com/bwmarrin/snowflake"
+	"github.com/misakimei123/redbook/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -13,6 +15,7 @@ type MongoDBArticleDAO struct {
 	db      *mongo.Database
 	col     *mongo.Collection
 	liveCol *mongo.Collection
+	revCol  *mongo.Collection
 	node    *snowflake.Node
 }
 
@@ -21,6 +24,7 @@ func NewMongoDBArticleDAO(db *mongo.Database, node *snowflake.Node) ArticleDao {
 		db:      db,
 		col:     db.Collection("articles"),
 		liveCol: db.Collection("published_articles"),
+		revCol:  db.Collection("article_revisions"),
 		node:    node,
 	}
 }
@@ -37,18 +41,20 @@ func (m *MongoDBArticleDAO) Insert(ctx context.Context, article Article) (int64,
 	if err != nil {
 		return 0, err
 	}
-	return id, nil
+	return id, m.insertRevision(ctx, article, now)
 }
 
 func (m *MongoDBArticleDAO) UpdateById(ctx context.Context, article Article) error {
 	ctx, cancelFunc := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancelFunc()
 	filter := bson.D{bson.E{"id", article.Id}, bson.E{"author_id", article.AuthorId}}
+	now := time.Now().UnixMilli()
 	set := bson.D{bson.E{"$set", bson.M{
 		"title":   article.Title,
 		"content": article.Content,
 		"status":  article.Status,
-		"utime":   time.Now().UnixMilli(),
+		"tags":    article.Tags,
+		"utime":   now,
 	}}}
 	res, err := m.col.UpdateOne(ctx, filter, set)
 	if err != nil {
@@ -57,7 +63,7 @@ func (m *MongoDBArticleDAO) UpdateById(ctx context.Context, article Article) err
 	if res.ModifiedCount == 0 {
 		return ErrArticleAuthorNotMatch
 	}
-	return nil
+	return m.insertRevision(ctx, article, now)
 }
 
 func (m *MongoDBArticleDAO) Sync(ctx context.Context, art Article) (int64, error) {
@@ -115,9 +121,16 @@ func (m *MongoDBArticleDAO) SyncStatus(ctx context.Context, id int64, uid int64,
 	return nil
 }
 
-func (m *MongoDBArticleDAO) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
-	//TODO implement me
-	panic("implement me")
+func (m *MongoDBArticleDAO) GetByAuthor(ctx context.Context, uid int64, lastUtime int64, lastId int64, limit int) ([]Article, error) {
+	filter := bson.M{"author_id": uid}
+	m.afterCursor(filter, lastUtime, lastId)
+	cursor, err := m.col.Find(ctx, filter, m.pageOptions(limit))
+	if err != nil {
+		return nil, err
+	}
+	var arts []Article
+	err = cursor.All(ctx, &arts)
+	return arts, err
 }
 
 func (m *MongoDBArticleDAO) GetById(ctx context.Context, uid int64, id int64) (Article, error) {
@@ -130,7 +143,90 @@ func (m *MongoDBArticleDAO) GetPubById(ctx context.Context, id int64) (Published
 	panic("implement me")
 }
 
-func (m *MongoDBArticleDAO) GetPubs(ctx context.Context, start int64, end int64, offset int, limit int) ([]PublishedArticle, error) {
-	//TODO implement me
-	panic("implement me")
+func (m *MongoDBArticleDAO) GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	cursor, err := m.liveCol.Find(ctx, bson.M{"id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	var arts []PublishedArticle
+	err = cursor.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) GetPubs(ctx context.Context, start int64, end int64, lastUtime int64, lastId int64, limit int) ([]PublishedArticle, error) {
+	filter := bson.M{"utime": bson.M{"$gte": start, "$lte": end}}
+	m.afterCursor(filter, lastUtime, lastId)
+	cursor, err := m.liveCol.Find(ctx, filter, m.pageOptions(limit))
+	if err != nil {
+		return nil, err
+	}
+	var arts []PublishedArticle
+	err = cursor.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) GetPubsByTag(ctx context.Context, tag string, lastUtime int64, lastId int64, limit int) ([]PublishedArticle, error) {
+	filter := bson.M{"tags": tag, "status": domain.ArticleStatusPublished}
+	m.afterCursor(filter, lastUtime, lastId)
+	cursor, err := m.liveCol.Find(ctx, filter, m.pageOptions(limit))
+	if err != nil {
+		return nil, err
+	}
+	var arts []PublishedArticle
+	err = cursor.All(ctx, &arts)
+	return arts, err
+}
+
+// afterCursor 只取排在 (lastUtime, lastId) 之后的文档，和 utime 的范围条件是 and 的关系
+func (m *MongoDBArticleDAO) afterCursor(filter bson.M, lastUtime int64, lastId int64) {
+	if lastId <= 0 {
+		return
+	}
+	filter["$or"] = bson.A{
+		bson.M{"utime": bson.M{"$lt": lastUtime}},
+		bson.M{"utime": lastUtime, "id": bson.M{"$lt": lastId}},
+	}
+}
+
+func (m *MongoDBArticleDAO) pageOptions(limit int) *options.FindOptions {
+	return options.Find().
+		SetSort(bson.D{{Key: "utime", Value: -1}, {Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+}
+
+func (m *MongoDBArticleDAO) GetRevisions(ctx context.Context, aid int64, uid int64, lastId int64, limit int) ([]ArticleRevision, error) {
+	filter := bson.M{"article_id": aid, "author_id": uid}
+	if lastId > 0 {
+		filter["id"] = bson.M{"$lt": lastId}
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"content": 0}).
+		SetSort(bson.D{{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.revCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []ArticleRevision
+	err = cursor.All(ctx, &revisions)
+	return revisions, err
+}
+
+func (m *MongoDBArticleDAO) GetRevision(ctx context.Context, aid int64, uid int64, rid int64) (ArticleRevision, error) {
+	var revision ArticleRevision
+	filter := bson.M{"id": rid, "article_id": aid, "author_id": uid}
+	err := m.revCol.FindOne(ctx, filter).Decode(&revision)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// 和 gorm 的实现返回一样的错误，上层才能认出来
+		return revision, ErrRecordNotFound
+	}
+	return revision, err
+}
+
+// insertRevision mongo 这边没有事务，文章写成功之后再写版本
+func (m *MongoDBArticleDAO) insertRevision(ctx context.Context, art Article, now int64) error {
+	revision := newArticleRevision(art, now)
+	revision.Id = m.node.Generate().Int64()
+	_, err := m.revCol.InsertOne(ctx, revision)
+	return err
 }
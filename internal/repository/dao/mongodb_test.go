@@ -0,0 +1,35 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestMongoDBArticleDAO_GetRevision(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	node, err := snowflake.NewNode(1)
+	require.NoError(t, err)
+
+	mt.Run("found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.article_revisions", mtest.FirstBatch,
+			bson.D{{Key: "id", Value: int64(3)}, {Key: "article_id", Value: int64(1)}, {Key: "author_id", Value: int64(2)}}))
+		dao := NewMongoDBArticleDAO(mt.DB, node)
+		revision, err := dao.GetRevision(context.Background(), 1, 2, 3)
+		assert.NoError(mt, err)
+		assert.Equal(mt, int64(3), revision.Id)
+	})
+
+	// 和 gorm 的实现一样返回 ErrRecordNotFound
+	mt.Run("not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.article_revisions", mtest.FirstBatch))
+		dao := NewMongoDBArticleDAO(mt.DB, node)
+		_, err := dao.GetRevision(context.Background(), 1, 2, 3)
+		assert.Equal(mt, ErrRecordNotFound, err)
+	})
+}
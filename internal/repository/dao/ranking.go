@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RankingSnapshot 榜单每天一条，Articles 是上榜文章的 json
+type RankingSnapshot struct {
+	Id       int64  `gorm:"primaryKey, autoIncrement"`
+	Board    string `gorm:"type:varchar(64);uniqueIndex:board_date"`
+	Date     string `gorm:"type:char(10);uniqueIndex:board_date"`
+	Articles string `gorm:"type:mediumtext"`
+	Ctime    int64
+	Utime    int64
+}
+
+type RankingDao interface {
+	// Upsert 同一个榜单同一天的快照直接覆盖
+	Upsert(ctx context.Context, snapshots []RankingSnapshot) error
+	FindByDate(ctx context.Context, board string, date string) (RankingSnapshot, error)
+	FindLatest(ctx context.Context, board string) (RankingSnapshot, error)
+}
+
+type GormRankingDao struct {
+	db *gorm.DB
+}
+
+func NewGormRankingDao(db *gorm.DB) RankingDao {
+	return &GormRankingDao{db: db}
+}
+
+func (g *GormRankingDao) Upsert(ctx context.Context, snapshots []RankingSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range snapshots {
+		snapshots[i].Ctime = now
+		snapshots[i].Utime = now
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"articles", "utime"}),
+	}).Create(&snapshots).Error
+}
+
+func (g *GormRankingDao) FindByDate(ctx context.Context, board string, date string) (RankingSnapshot, error) {
+	var res RankingSnapshot
+	err := g.db.WithContext(ctx).Where("board = ? and date = ?", board, date).First(&res).Error
+	return res, err
+}
+
+func (g *GormRankingDao) FindLatest(ctx context.Context, board string) (RankingSnapshot, error) {
+	var res RankingSnapshot
+	err := g.db.WithContext(ctx).Where("board = ?", board).Order("date desc").First(&res).Error
+	return res, err
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/ecodeclub/ekit"
 	"github.com/misakimei123/redbook/internal/domain"
+	eventArticle "github.com/misakimei123/redbook/internal/events/article"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -26,29 +27,24 @@ func NewArticleS3DAO(db *gorm.DB, client *s3.S3) ArticleDao {
 }
 
 func (a *ArticleS3dao) Sync(ctx context.Context, art Article) (int64, error) {
-	var id = art.Id
 	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		dao := NewArticleGormDao(tx)
-		var (
-			err error
-		)
-
+		now := time.Now().UnixMilli()
+		var err error
 		if art.Id > 0 {
-			err = dao.UpdateById(ctx, art)
+			err = a.updateById(tx, &art, now)
 		} else {
-			id, err = dao.Insert(ctx, art)
-			art.Id = id
+			err = a.insert(tx, &art, now)
 		}
 
 		if err != nil {
 			return err
 		}
-		now := time.Now().UnixMilli()
 		publishedArticle := &PublishedArticleV2{
 			Id:       art.Id,
 			Title:    art.Title,
 			AuthorId: art.AuthorId,
 			Status:   art.Status,
+			Tags:     art.Tags,
 			Ctime:    now,
 			Utime:    now,
 		}
@@ -56,10 +52,18 @@ func (a *ArticleS3dao) Sync(ctx context.Context, art Article) (int64, error) {
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"title":  publishedArticle.Title,
 				"status": publishedArticle.Status,
+				"tags":   publishedArticle.Tags,
 				"utime":  now,
 			}),
 		}).Create(&publishedArticle).Error
-		return err
+		if err != nil {
+			return err
+		}
+		err = syncPubTags(tx, art.Id, art.Tags, now)
+		if err != nil {
+			return err
+		}
+		return saveSyncEvent(ctx, tx, eventArticle.SyncTypePublished, art)
 	})
 	if err != nil {
 		return 0, err
@@ -70,7 +74,7 @@ func (a *ArticleS3dao) Sync(ctx context.Context, art Article) (int64, error) {
 		Body:        bytes.NewReader([]byte(art.Content)),
 		ContentType: ekit.ToPtr[string]("text/plain;charset=utf-8"),
 	})
-	return id, err
+	return art.Id, err
 }
 
 func (a *ArticleS3dao) SyncStatus(ctx context.Context, id int64, uid int64, status uint8) error {
@@ -101,7 +105,10 @@ func (a *ArticleS3dao) SyncStatus(ctx context.Context, id int64, uid int64, stat
 		if res.RowsAffected == 0 {
 			return ErrArticleAuthorNotMatch
 		}
-		return nil
+		if status == domain.ArticleStatusPublished {
+			return nil
+		}
+		return deletePubTags(tx, id)
 	})
 	if err != nil {
 		return err
@@ -118,11 +125,53 @@ func (a *ArticleS3dao) SyncStatus(ctx context.Context, id int64, uid int64, stat
 	return nil
 }
 
+// GetPubs 线上库只保存元数据，内容在 OSS 上，这里不返回 Content
+func (a *ArticleS3dao) GetPubs(ctx context.Context, start int64, end int64, lastUtime int64, lastId int64, limit int) ([]PublishedArticle, error) {
+	var arts []PublishedArticleV2
+	err := afterCursor(a.db.WithContext(ctx).Model(&PublishedArticleV2{}).Where("utime >= ? and utime <= ?", start, end), lastUtime, lastId).
+		Order("utime DESC, id DESC").Limit(limit).
+		Find(&arts).Error
+	if err != nil {
+		return nil, err
+	}
+	return a.toPublished(arts), nil
+}
+
+func (a *ArticleS3dao) GetPubsByTag(ctx context.Context, tag string, lastUtime int64, lastId int64, limit int) ([]PublishedArticle, error) {
+	aids, err := pubIdsByTag(ctx, a.db, tag, lastUtime, lastId, limit)
+	if err != nil || len(aids) == 0 {
+		return nil, err
+	}
+	var arts []PublishedArticleV2
+	err = a.db.WithContext(ctx).Model(&PublishedArticleV2{}).Where("id IN ?", aids).Find(&arts).Error
+	if err != nil {
+		return nil, err
+	}
+	return a.toPublished(sortByIds(aids, arts, func(art PublishedArticleV2) int64 { return art.Id })), nil
+}
+
+func (a *ArticleS3dao) toPublished(arts []PublishedArticleV2) []PublishedArticle {
+	res := make([]PublishedArticle, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, PublishedArticle{
+			Id:       art.Id,
+			Title:    art.Title,
+			AuthorId: art.AuthorId,
+			Status:   art.Status,
+			Tags:     art.Tags,
+			Ctime:    art.Ctime,
+			Utime:    art.Utime,
+		})
+	}
+	return res
+}
+
 type PublishedArticleV2 struct {
 	Id       int64  `gorm:"primaryKey, autoIncrement" bson:"id,omitempty"`
 	Title    string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
 	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
 	Status   uint8  `bson:"status,omitempty"`
+	Tags     Tags   `gorm:"type:varchar(1024)" bson:"tags"`
 	Ctime    int64  `bson:"ctime,omitempty"`
-	Utime    int64  `bson:"utime,omitempty"`
+	Utime    int64  `gorm:"index" bson:"utime,omitempty"`
 }
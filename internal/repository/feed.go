@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository/dao"
+	"golang.org/x/sync/errgroup"
+)
+
+type FeedRepository interface {
+	// AddPushItems 把 item 写到 uids 每个人的收件箱
+	AddPushItems(ctx context.Context, uids []int64, item domain.FeedItem) error
+	AddPullItem(ctx context.Context, item domain.FeedItem) error
+	DeleteItems(ctx context.Context, aid int64) error
+	// DeletePushItems 删掉 uid 收件箱里面 authorId 的文章
+	DeletePushItems(ctx context.Context, uid int64, authorId int64) error
+	// FindFeed 合并 uid 的收件箱和 pullAuthors 的发件箱，按 (ctime, aid) 倒序
+	FindFeed(ctx context.Context, uid int64, pullAuthors []int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error)
+}
+
+type DaoFeedRepository struct {
+	dao dao.FeedDao
+}
+
+func NewDaoFeedRepository(dao dao.FeedDao) FeedRepository {
+	return &DaoFeedRepository{dao: dao}
+}
+
+func (d *DaoFeedRepository) AddPushItems(ctx context.Context, uids []int64, item domain.FeedItem) error {
+	items := slice.Map(uids, func(idx int, uid int64) dao.FeedPushItem {
+		return dao.FeedPushItem{
+			Uid:      uid,
+			Aid:      item.Aid,
+			AuthorId: item.AuthorId,
+			Title:    item.Title,
+			Abstract: item.Abstract,
+			Ctime:    item.Ctime.UnixMilli(),
+		}
+	})
+	return d.dao.InsertPushItems(ctx, items)
+}
+
+func (d *DaoFeedRepository) AddPullItem(ctx context.Context, item domain.FeedItem) error {
+	return d.dao.InsertPullItem(ctx, dao.FeedPullItem{
+		Aid:      item.Aid,
+		AuthorId: item.AuthorId,
+		Title:    item.Title,
+		Abstract: item.Abstract,
+		Ctime:    item.Ctime.UnixMilli(),
+	})
+}
+
+func (d *DaoFeedRepository) DeleteItems(ctx context.Context, aid int64) error {
+	return d.dao.DeleteByAid(ctx, aid)
+}
+
+func (d *DaoFeedRepository) DeletePushItems(ctx context.Context, uid int64, authorId int64) error {
+	return d.dao.DeletePushItemsByAuthor(ctx, uid, authorId)
+}
+
+func (d *DaoFeedRepository) FindFeed(ctx context.Context, uid int64, pullAuthors []int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error) {
+	var (
+		eg     errgroup.Group
+		pushed []dao.FeedPushItem
+		pulled []dao.FeedPullItem
+	)
+	eg.Go(func() error {
+		var er error
+		pushed, er = d.dao.FindPushItems(ctx, uid, cursor.Utime, cursor.Id, limit)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		pulled, er = d.dao.FindPullItems(ctx, pullAuthors, cursor.Utime, cursor.Id, limit)
+		return er
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	// 作者粉丝数越过阈值之前推过的文章，修改之后会再进发件箱，按 aid 去重
+	res := make([]domain.FeedItem, 0, len(pushed)+len(pulled))
+	seen := make(map[int64]struct{}, len(pushed))
+	for _, item := range pushed {
+		seen[item.Aid] = struct{}{}
+		res = append(res, d.toDomain(item.Aid, item.AuthorId, item.Title, item.Abstract, item.Ctime))
+	}
+	for _, item := range pulled {
+		if _, ok := seen[item.Aid]; ok {
+			continue
+		}
+		res = append(res, d.toDomain(item.Aid, item.AuthorId, item.Title, item.Abstract, item.Ctime))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].Ctime.Equal(res[j].Ctime) {
+			return res[i].Ctime.After(res[j].Ctime)
+		}
+		return res[i].Aid > res[j].Aid
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (d *DaoFeedRepository) toDomain(aid, authorId int64, title, abstract string, ctime int64) domain.FeedItem {
+	return domain.FeedItem{
+		Aid:      aid,
+		AuthorId: authorId,
+		Title:    title,
+		Abstract: abstract,
+		Ctime:    time.UnixMilli(ctime),
+	}
+}
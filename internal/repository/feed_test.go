@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository/dao"
+	daomocks "github.com/misakimei123/redbook/internal/repository/dao/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDaoFeedRepository_FindFeed(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) dao.FeedDao
+		cursor   domain.Cursor
+		limit    int
+		wantAids []int64
+	}{
+		{
+			name: "merge push and pull by ctime",
+			mock: func(ctrl *gomock.Controller) dao.FeedDao {
+				feedDao := daomocks.NewMockFeedDao(ctrl)
+				feedDao.EXPECT().FindPushItems(gomock.Any(), int64(1), int64(0), int64(0), 3).
+					Return([]dao.FeedPushItem{{Aid: 5, Ctime: 500}, {Aid: 3, Ctime: 300}, {Aid: 1, Ctime: 100}}, nil)
+				feedDao.EXPECT().FindPullItems(gomock.Any(), []int64{7, 8}, int64(0), int64(0), 3).
+					Return([]dao.FeedPullItem{{Aid: 4, Ctime: 400}, {Aid: 2, Ctime: 200}}, nil)
+				return feedDao
+			},
+			limit:    3,
+			wantAids: []int64{5, 4, 3},
+		},
+		{
+			name: "same ctime ordered by aid, pushed items win",
+			mock: func(ctrl *gomock.Controller) dao.FeedDao {
+				feedDao := daomocks.NewMockFeedDao(ctrl)
+				feedDao.EXPECT().FindPushItems(gomock.Any(), int64(1), int64(600), int64(9), 3).
+					Return([]dao.FeedPushItem{{Aid: 3, Ctime: 500}}, nil)
+				feedDao.EXPECT().FindPullItems(gomock.Any(), []int64{7, 8}, int64(600), int64(9), 3).
+					Return([]dao.FeedPullItem{{Aid: 6, Ctime: 500}, {Aid: 3, Ctime: 500}}, nil)
+				return feedDao
+			},
+			cursor:   domain.Cursor{Utime: 600, Id: 9},
+			limit:    3,
+			wantAids: []int64{6, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := NewDaoFeedRepository(tc.mock(ctrl))
+			items, err := repo.FindFeed(context.Background(), 1, []int64{7, 8}, tc.cursor, tc.limit)
+			assert.NoError(t, err)
+			aids := make([]int64, 0, len(items))
+			for _, item := range items {
+				aids = append(aids, item.Aid)
+			}
+			assert.Equal(t, tc.wantAids, aids)
+		})
+	}
+}
+
+// 取消关注只删自己收件箱里面这个作者的
+func TestDaoFeedRepository_DeletePushItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	feedDao := daomocks.NewMockFeedDao(ctrl)
+	feedDao.EXPECT().DeletePushItemsByAuthor(gomock.Any(), int64(1), int64(7)).Return(nil)
+	repo := NewDaoFeedRepository(feedDao)
+	err := repo.DeletePushItems(context.Background(), 1, 7)
+	assert.NoError(t, err)
+}
@@ -4,43 +4,177 @@ import (
 	"context"
 	"time"
 
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/misakimei123/redbook/internal/domain"
 	"github.com/misakimei123/redbook/internal/repository/dao"
 )
 
+var (
+	ErrJobNotFound  = dao.ErrJobNotFound
+	ErrDuplicateJob = dao.ErrDuplicateJob
+	ErrJobLost      = dao.ErrJobLost
+	ErrNoMoreJob    = dao.ErrNoMoreJob
+)
+
 type JobRepository interface {
-	Preempt(ctx context.Context) (domain.Job, error)
-	Release(ctx context.Context, jid int64) error
-	UpdateUtime(ctx context.Context, jid int64) error
-	UpdateNextTime(ctx context.Context, jid int64, nextTime time.Time) error
+	Preempt(ctx context.Context, owner string) (domain.Job, error)
+	Release(ctx context.Context, j domain.Job) error
+	UpdateUtime(ctx context.Context, j domain.Job) error
+	UpdateNextTime(ctx context.Context, j domain.Job, nextTime time.Time) error
+	Retry(ctx context.Context, j domain.Job, attempts int, nextTime time.Time) error
+	Upsert(ctx context.Context, j domain.Job, nextTime time.Time) error
+	UpdateNextTimeByName(ctx context.Context, name string, nextTime time.Time) error
+	DeleteByName(ctx context.Context, name string) error
+	Finish(ctx context.Context, j domain.Job) error
+	Create(ctx context.Context, j domain.Job, nextTime time.Time) error
+	FindByName(ctx context.Context, name string) (domain.Job, error)
+	Pause(ctx context.Context, name string) error
+	Resume(ctx context.Context, name string, nextTime time.Time) error
+	List(ctx context.Context, offset int, limit int) ([]domain.Job, error)
+	// StartExecution 返回执行记录的 id
+	StartExecution(ctx context.Context, e domain.JobExecution) (int64, error)
+	EndExecution(ctx context.Context, e domain.JobExecution) error
+	ListExecutions(ctx context.Context, name string, offset int, limit int) ([]domain.JobExecution, error)
 }
 
-func NewPreemptJobRepository(dao dao.JobDao) JobRepository {
-	return &PreemptJobRepository{dao: dao}
+func NewPreemptJobRepository(dao dao.JobDao, execDao dao.JobExecutionDao) JobRepository {
+	return &PreemptJobRepository{dao: dao, execDao: execDao}
 }
 
 type PreemptJobRepository struct {
-	dao dao.JobDao
+	dao     dao.JobDao
+	execDao dao.JobExecutionDao
 }
 
-func (p *PreemptJobRepository) UpdateNextTime(ctx context.Context, jid int64, nextTime time.Time) error {
-	return p.dao.UpdateNextTime(ctx, jid, nextTime)
+func (p *PreemptJobRepository) UpdateNextTime(ctx context.Context, j domain.Job, nextTime time.Time) error {
+	return p.dao.UpdateNextTime(ctx, j.Id, j.Owner, j.Version, j.NextExecTime.UnixMilli(), nextTime)
 }
 
-func (p *PreemptJobRepository) UpdateUtime(ctx context.Context, jid int64) error {
-	return p.dao.UpdateUtime(ctx, jid)
+func (p *PreemptJobRepository) Retry(ctx context.Context, j domain.Job, attempts int, nextTime time.Time) error {
+	return p.dao.Retry(ctx, j.Id, j.Owner, j.Version, j.NextExecTime.UnixMilli(), attempts, nextTime)
 }
 
-func (p *PreemptJobRepository) Preempt(ctx context.Context) (domain.Job, error) {
-	j, err := p.dao.Preempt(ctx)
-	return domain.Job{
-		Id:         j.Id,
+func (p *PreemptJobRepository) UpdateUtime(ctx context.Context, j domain.Job) error {
+	return p.dao.UpdateUtime(ctx, j.Id, j.Owner, j.Version)
+}
+
+func (p *PreemptJobRepository) Preempt(ctx context.Context, owner string) (domain.Job, error) {
+	j, err := p.dao.Preempt(ctx, owner)
+	return p.toDomain(j), err
+}
+
+func (p *PreemptJobRepository) Release(ctx context.Context, j domain.Job) error {
+	return p.dao.Release(ctx, j.Id, j.Owner, j.Version)
+}
+
+func (p *PreemptJobRepository) Upsert(ctx context.Context, j domain.Job, nextTime time.Time) error {
+	return p.dao.Upsert(ctx, dao.Job{
 		Expression: j.Expression,
 		Executor:   j.Executor,
 		Name:       j.Name,
-	}, err
+		Cfg:        j.Cfg,
+		NextTime:   nextTime.UnixMilli(),
+	})
+}
+
+func (p *PreemptJobRepository) UpdateNextTimeByName(ctx context.Context, name string, nextTime time.Time) error {
+	return p.dao.UpdateNextTimeByName(ctx, name, nextTime)
+}
+
+func (p *PreemptJobRepository) DeleteByName(ctx context.Context, name string) error {
+	return p.dao.DeleteByName(ctx, name)
+}
+
+func (p *PreemptJobRepository) Finish(ctx context.Context, j domain.Job) error {
+	return p.dao.Finish(ctx, j.Id, j.Owner, j.Version, j.NextExecTime.UnixMilli())
+}
+
+func (p *PreemptJobRepository) Create(ctx context.Context, j domain.Job, nextTime time.Time) error {
+	return p.dao.Insert(ctx, dao.Job{
+		Expression: j.Expression,
+		Executor:   j.Executor,
+		Name:       j.Name,
+		Cfg:        j.Cfg,
+		NextTime:   nextTime.UnixMilli(),
+	})
+}
+
+func (p *PreemptJobRepository) FindByName(ctx context.Context, name string) (domain.Job, error) {
+	j, err := p.dao.FindByName(ctx, name)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	return p.toDomain(j), nil
+}
+
+func (p *PreemptJobRepository) Pause(ctx context.Context, name string) error {
+	return p.dao.Pause(ctx, name)
+}
+
+func (p *PreemptJobRepository) Resume(ctx context.Context, name string, nextTime time.Time) error {
+	return p.dao.Resume(ctx, name, nextTime)
+}
+
+func (p *PreemptJobRepository) List(ctx context.Context, offset int, limit int) ([]domain.Job, error) {
+	jobs, err := p.dao.List(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(jobs, func(idx int, src dao.Job) domain.Job {
+		return p.toDomain(src)
+	}), nil
+}
+
+func (p *PreemptJobRepository) toDomain(j dao.Job) domain.Job {
+	return domain.Job{
+		Id:           j.Id,
+		Expression:   j.Expression,
+		Executor:     j.Executor,
+		Name:         j.Name,
+		Cfg:          j.Cfg,
+		Status:       domain.JobStatus(j.Status),
+		NextExecTime: time.UnixMilli(j.NextTime),
+		Owner:        j.Owner,
+		Attempts:     j.Attempts,
+		Version:      j.Version,
+		Utime:        time.UnixMilli(j.Utime),
+	}
+}
+
+func (p *PreemptJobRepository) StartExecution(ctx context.Context, e domain.JobExecution) (int64, error) {
+	return p.execDao.Insert(ctx, dao.JobExecution{
+		JobId:   e.JobId,
+		JobName: e.JobName,
+		Node:    e.Node,
+		Attempt: e.Attempt,
+		Status:  domain.JobExecutionStatusRunning.ToInt(),
+		Start:   e.Start.UnixMilli(),
+	})
+}
+
+func (p *PreemptJobRepository) EndExecution(ctx context.Context, e domain.JobExecution) error {
+	return p.execDao.Finish(ctx, e.Id, e.Status.ToInt(), e.Error, e.End.UnixMilli())
 }
 
-func (p *PreemptJobRepository) Release(ctx context.Context, jid int64) error {
-	return p.dao.Release(ctx, jid)
+func (p *PreemptJobRepository) ListExecutions(ctx context.Context, name string, offset int, limit int) ([]domain.JobExecution, error) {
+	executions, err := p.execDao.ListByName(ctx, name, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(executions, func(idx int, src dao.JobExecution) domain.JobExecution {
+		e := domain.JobExecution{
+			Id:      src.Id,
+			JobId:   src.JobId,
+			JobName: src.JobName,
+			Node:    src.Node,
+			Attempt: src.Attempt,
+			Status:  domain.JobExecutionStatus(src.Status),
+			Error:   src.Error,
+			Start:   time.UnixMilli(src.Start),
+		}
+		if src.End > 0 {
+			e.End = time.UnixMilli(src.End)
+		}
+		return e
+	}), nil
 }
@@ -56,18 +56,18 @@ func (mr *MockArticleRepositoryMockRecorder) Create(ctx, article any) *gomock.Ca
 }
 
 // GetByAuthor mocks base method.
-func (m *MockArticleRepository) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+func (m *MockArticleRepository) GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, cursor, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByAuthor indicates an expected call of GetByAuthor.
-func (mr *MockArticleRepositoryMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+func (mr *MockArticleRepositoryMockRecorder) GetByAuthor(ctx, uid, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleRepository)(nil).GetByAuthor), ctx, uid, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleRepository)(nil).GetByAuthor), ctx, uid, cursor, limit)
 }
 
 // GetById mocks base method.
@@ -101,18 +101,77 @@ func (mr *MockArticleRepositoryMockRecorder) GetPubById(ctx, id any) *gomock.Cal
 }
 
 // GetPubs mocks base method.
-func (m *MockArticleRepository) GetPubs(ctx context.Context, start, end int64, offset, size int) ([]domain.Article, error) {
+func (m *MockArticleRepository) GetPubs(ctx context.Context, start, end int64, cursor domain.Cursor, size int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPubs", ctx, start, end, offset, size)
+	ret := m.ctrl.Call(m, "GetPubs", ctx, start, end, cursor, size)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPubs indicates an expected call of GetPubs.
-func (mr *MockArticleRepositoryMockRecorder) GetPubs(ctx, start, end, offset, size any) *gomock.Call {
+func (mr *MockArticleRepositoryMockRecorder) GetPubs(ctx, start, end, cursor, size any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubs", reflect.TypeOf((*MockArticleRepository)(nil).GetPubs), ctx, start, end, offset, size)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubs", reflect.TypeOf((*MockArticleRepository)(nil).GetPubs), ctx, start, end, cursor, size)
+}
+
+// GetPubsByTag mocks base method.
+func (m *MockArticleRepository) GetPubsByTag(ctx context.Context, tag string, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubsByTag", ctx, tag, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubsByTag indicates an expected call of GetPubsByTag.
+func (mr *MockArticleRepositoryMockRecorder) GetPubsByTag(ctx, tag, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubsByTag", reflect.TypeOf((*MockArticleRepository)(nil).GetPubsByTag), ctx, tag, cursor, limit)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticleRepository) GetRevision(ctx context.Context, aid, uid, rid int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, aid, uid, rid)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleRepositoryMockRecorder) GetRevision(ctx, aid, uid, rid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleRepository)(nil).GetRevision), ctx, aid, uid, rid)
+}
+
+// GetRevisions mocks base method.
+func (m *MockArticleRepository) GetRevisions(ctx context.Context, aid, uid int64, cursor domain.Cursor, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, aid, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockArticleRepositoryMockRecorder) GetRevisions(ctx, aid, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockArticleRepository)(nil).GetRevisions), ctx, aid, uid, cursor, limit)
+}
+
+// PreloadPubs mocks base method.
+func (m *MockArticleRepository) PreloadPubs(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreloadPubs", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreloadPubs indicates an expected call of PreloadPubs.
+func (mr *MockArticleRepositoryMockRecorder) PreloadPubs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreloadPubs", reflect.TypeOf((*MockArticleRepository)(nil).PreloadPubs), ctx, ids)
 }
 
 // Sync mocks base method.
@@ -0,0 +1,285 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/job.go -package=repomocks -destination=./internal/repository/mocks/job.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/misakimei123/redbook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockJobRepository) Create(ctx context.Context, j domain.Job, nextTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, j, nextTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockJobRepositoryMockRecorder) Create(ctx, j, nextTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockJobRepository)(nil).Create), ctx, j, nextTime)
+}
+
+// DeleteByName mocks base method.
+func (m *MockJobRepository) DeleteByName(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByName", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByName indicates an expected call of DeleteByName.
+func (mr *MockJobRepositoryMockRecorder) DeleteByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByName", reflect.TypeOf((*MockJobRepository)(nil).DeleteByName), ctx, name)
+}
+
+// EndExecution mocks base method.
+func (m *MockJobRepository) EndExecution(ctx context.Context, e domain.JobExecution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndExecution", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndExecution indicates an expected call of EndExecution.
+func (mr *MockJobRepositoryMockRecorder) EndExecution(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndExecution", reflect.TypeOf((*MockJobRepository)(nil).EndExecution), ctx, e)
+}
+
+// FindByName mocks base method.
+func (m *MockJobRepository) FindByName(ctx context.Context, name string) (domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockJobRepositoryMockRecorder) FindByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockJobRepository)(nil).FindByName), ctx, name)
+}
+
+// Finish mocks base method.
+func (m *MockJobRepository) Finish(ctx context.Context, j domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, j)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockJobRepositoryMockRecorder) Finish(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockJobRepository)(nil).Finish), ctx, j)
+}
+
+// List mocks base method.
+func (m *MockJobRepository) List(ctx context.Context, offset, limit int) ([]domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockJobRepositoryMockRecorder) List(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockJobRepository)(nil).List), ctx, offset, limit)
+}
+
+// ListExecutions mocks base method.
+func (m *MockJobRepository) ListExecutions(ctx context.Context, name string, offset, limit int) ([]domain.JobExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExecutions", ctx, name, offset, limit)
+	ret0, _ := ret[0].([]domain.JobExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExecutions indicates an expected call of ListExecutions.
+func (mr *MockJobRepositoryMockRecorder) ListExecutions(ctx, name, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExecutions", reflect.TypeOf((*MockJobRepository)(nil).ListExecutions), ctx, name, offset, limit)
+}
+
+// Pause mocks base method.
+func (m *MockJobRepository) Pause(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockJobRepositoryMockRecorder) Pause(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockJobRepository)(nil).Pause), ctx, name)
+}
+
+// Preempt mocks base method.
+func (m *MockJobRepository) Preempt(ctx context.Context, owner string) (domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preempt", ctx, owner)
+	ret0, _ := ret[0].(domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preempt indicates an expected call of Preempt.
+func (mr *MockJobRepositoryMockRecorder) Preempt(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preempt", reflect.TypeOf((*MockJobRepository)(nil).Preempt), ctx, owner)
+}
+
+// Release mocks base method.
+func (m *MockJobRepository) Release(ctx context.Context, j domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, j)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockJobRepositoryMockRecorder) Release(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockJobRepository)(nil).Release), ctx, j)
+}
+
+// Resume mocks base method.
+func (m *MockJobRepository) Resume(ctx context.Context, name string, nextTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", ctx, name, nextTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockJobRepositoryMockRecorder) Resume(ctx, name, nextTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockJobRepository)(nil).Resume), ctx, name, nextTime)
+}
+
+// Retry mocks base method.
+func (m *MockJobRepository) Retry(ctx context.Context, j domain.Job, attempts int, nextTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, j, attempts, nextTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockJobRepositoryMockRecorder) Retry(ctx, j, attempts, nextTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockJobRepository)(nil).Retry), ctx, j, attempts, nextTime)
+}
+
+// StartExecution mocks base method.
+func (m *MockJobRepository) StartExecution(ctx context.Context, e domain.JobExecution) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartExecution", ctx, e)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartExecution indicates an expected call of StartExecution.
+func (mr *MockJobRepositoryMockRecorder) StartExecution(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExecution", reflect.TypeOf((*MockJobRepository)(nil).StartExecution), ctx, e)
+}
+
+// UpdateNextTime mocks base method.
+func (m *MockJobRepository) UpdateNextTime(ctx context.Context, j domain.Job, nextTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNextTime", ctx, j, nextTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNextTime indicates an expected call of UpdateNextTime.
+func (mr *MockJobRepositoryMockRecorder) UpdateNextTime(ctx, j, nextTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNextTime", reflect.TypeOf((*MockJobRepository)(nil).UpdateNextTime), ctx, j, nextTime)
+}
+
+// UpdateNextTimeByName mocks base method.
+func (m *MockJobRepository) UpdateNextTimeByName(ctx context.Context, name string, nextTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNextTimeByName", ctx, name, nextTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNextTimeByName indicates an expected call of UpdateNextTimeByName.
+func (mr *MockJobRepositoryMockRecorder) UpdateNextTimeByName(ctx, name, nextTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNextTimeByName", reflect.TypeOf((*MockJobRepository)(nil).UpdateNextTimeByName), ctx, name, nextTime)
+}
+
+// UpdateUtime mocks base method.
+func (m *MockJobRepository) UpdateUtime(ctx context.Context, j domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUtime", ctx, j)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUtime indicates an expected call of UpdateUtime.
+func (mr *MockJobRepositoryMockRecorder) UpdateUtime(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUtime", reflect.TypeOf((*MockJobRepository)(nil).UpdateUtime), ctx, j)
+}
+
+// Upsert mocks base method.
+func (m *MockJobRepository) Upsert(ctx context.Context, j domain.Job, nextTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, j, nextTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockJobRepositoryMockRecorder) Upsert(ctx, j, nextTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockJobRepository)(nil).Upsert), ctx, j, nextTime)
+}
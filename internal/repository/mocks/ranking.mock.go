@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./ranking.go
+// Source: ./internal/repository/ranking.go
 //
 // Generated by this command:
 //
-//	mockgen -source=./ranking.go -package=repomocks -destination=./mocks/ranking.mock.go RankingRepository
+//	mockgen -source=./internal/repository/ranking.go -package=repomocks -destination=./internal/repository/mocks/ranking.mock.go
 //
 
 // Package repomocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package repomocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/misakimei123/redbook/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,36 @@ func (m *MockRankingRepository) EXPECT() *MockRankingRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetBoard mocks base method.
+func (m *MockRankingRepository) GetBoard(ctx context.Context, board string) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", ctx, board)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockRankingRepositoryMockRecorder) GetBoard(ctx, board any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockRankingRepository)(nil).GetBoard), ctx, board)
+}
+
+// GetSnapshot mocks base method.
+func (m *MockRankingRepository) GetSnapshot(ctx context.Context, board string, date time.Time) (domain.RankingSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshot", ctx, board, date)
+	ret0, _ := ret[0].(domain.RankingSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockRankingRepositoryMockRecorder) GetSnapshot(ctx, board, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockRankingRepository)(nil).GetSnapshot), ctx, board, date)
+}
+
 // GetTopN mocks base method.
 func (m *MockRankingRepository) GetTopN(ctx context.Context) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +86,35 @@ func (mr *MockRankingRepositoryMockRecorder) GetTopN(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopN", reflect.TypeOf((*MockRankingRepository)(nil).GetTopN), ctx)
 }
 
+// GetTopNByTag mocks base method.
+func (m *MockRankingRepository) GetTopNByTag(ctx context.Context, tag string) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopNByTag", ctx, tag)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopNByTag indicates an expected call of GetTopNByTag.
+func (mr *MockRankingRepositoryMockRecorder) GetTopNByTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopNByTag", reflect.TypeOf((*MockRankingRepository)(nil).GetTopNByTag), ctx, tag)
+}
+
+// SetBoards mocks base method.
+func (m *MockRankingRepository) SetBoards(ctx context.Context, date time.Time, boards map[string][]domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBoards", ctx, date, boards)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBoards indicates an expected call of SetBoards.
+func (mr *MockRankingRepositoryMockRecorder) SetBoards(ctx, date, boards any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoards", reflect.TypeOf((*MockRankingRepository)(nil).SetBoards), ctx, date, boards)
+}
+
 // SetTopN mocks base method.
 func (m *MockRankingRepository) SetTopN(ctx context.Context, articles []domain.Article) error {
 	m.ctrl.T.Helper()
@@ -68,3 +128,17 @@ func (mr *MockRankingRepositoryMockRecorder) SetTopN(ctx, articles any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopN", reflect.TypeOf((*MockRankingRepository)(nil).SetTopN), ctx, articles)
 }
+
+// SetTopNByTags mocks base method.
+func (m *MockRankingRepository) SetTopNByTags(ctx context.Context, byTag map[string][]domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTopNByTags", ctx, byTag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTopNByTags indicates an expected call of SetTopNByTags.
+func (mr *MockRankingRepositoryMockRecorder) SetTopNByTags(ctx, byTag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopNByTags", reflect.TypeOf((*MockRankingRepository)(nil).SetTopNByTags), ctx, byTag)
+}
@@ -2,20 +2,34 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/misakimei123/redbook/internal/domain"
 	"github.com/misakimei123/redbook/internal/repository/cache"
+	"github.com/misakimei123/redbook/internal/repository/dao"
 	"github.com/misakimei123/redbook/pkg/logger"
 )
 
+var ErrRankingSnapshotNotFound = dao.ErrRecordNotFound
+
 //go:generate mockgen -source=./ranking.go -package=repomocks -destination=./mocks/ranking.mock.go RankingRepository
 type RankingRepository interface {
 	GetTopN(ctx context.Context) ([]domain.Article, error)
 	SetTopN(ctx context.Context, articles []domain.Article) error
+	GetTopNByTag(ctx context.Context, tag string) ([]domain.Article, error)
+	SetTopNByTags(ctx context.Context, byTag map[string][]domain.Article) error
+	// GetBoard 缓存没有就用最近一次的快照，榜单还没算过返回空
+	GetBoard(ctx context.Context, board string) ([]domain.Article, error)
+	// SetBoards 保存当天的快照，再写缓存
+	SetBoards(ctx context.Context, date time.Time, boards map[string][]domain.Article) error
+	GetSnapshot(ctx context.Context, board string, date time.Time) (domain.RankingSnapshot, error)
 }
 
 type CachedRankingRepository struct {
 	cache      cache.RankingCache
+	dao        dao.RankingDao
 	l          logger.LoggerV1
 	redisCache cache.RedisRankingCache
 	localCache cache.RankingLocalCache
@@ -29,8 +43,91 @@ func (c *CachedRankingRepository) SetTopN(ctx context.Context, articles []domain
 	return c.cache.Set(ctx, articles)
 }
 
-func NewCachedRankingRepository(cache cache.RankingCache, l logger.LoggerV1) RankingRepository {
-	return &CachedRankingRepository{cache: cache, l: l}
+func (c *CachedRankingRepository) GetTopNByTag(ctx context.Context, tag string) ([]domain.Article, error) {
+	return c.cache.GetByTag(ctx, tag)
+}
+
+func (c *CachedRankingRepository) SetTopNByTags(ctx context.Context, byTag map[string][]domain.Article) error {
+	return c.cache.SetByTags(ctx, byTag)
+}
+
+func (c *CachedRankingRepository) GetBoard(ctx context.Context, board string) ([]domain.Article, error) {
+	arts, err := c.cache.GetBoard(ctx, board)
+	if err == nil {
+		return arts, nil
+	}
+	snapshot, err := c.dao.FindLatest(ctx, board)
+	if errors.Is(err, dao.ErrRecordNotFound) {
+		return []domain.Article{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	arts, err = c.toArticles(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	er := c.cache.SetBoards(ctx, map[string][]domain.Article{board: arts})
+	if er != nil {
+		c.l.Error("set board cache fail", logger.String("board", board), logger.Error(er))
+	}
+	return arts, nil
+}
+
+func (c *CachedRankingRepository) SetBoards(ctx context.Context, date time.Time, boards map[string][]domain.Article) error {
+	snapshots := make([]dao.RankingSnapshot, 0, len(boards))
+	for board, arts := range boards {
+		snapshot, err := c.toEntity(board, date, arts)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	err := c.dao.Upsert(ctx, snapshots)
+	if err != nil {
+		return err
+	}
+	return c.cache.SetBoards(ctx, boards)
+}
+
+func (c *CachedRankingRepository) GetSnapshot(ctx context.Context, board string, date time.Time) (domain.RankingSnapshot, error) {
+	snapshot, err := c.dao.FindByDate(ctx, board, date.Format(time.DateOnly))
+	if err != nil {
+		return domain.RankingSnapshot{}, err
+	}
+	arts, err := c.toArticles(snapshot)
+	if err != nil {
+		return domain.RankingSnapshot{}, err
+	}
+	return domain.RankingSnapshot{Board: board, Date: date, Articles: arts}, nil
+}
+
+// toEntity 快照和缓存一样只保存摘要
+func (c *CachedRankingRepository) toEntity(board string, date time.Time, arts []domain.Article) (dao.RankingSnapshot, error) {
+	abstracts := make([]domain.Article, len(arts))
+	for i, art := range arts {
+		art.Content = art.Abstract()
+		abstracts[i] = art
+	}
+	val, err := json.Marshal(abstracts)
+	if err != nil {
+		return dao.RankingSnapshot{}, err
+	}
+	return dao.RankingSnapshot{
+		Board:    board,
+		Date:     date.Format(time.DateOnly),
+		Articles: string(val),
+	}, nil
+}
+
+func (c *CachedRankingRepository) toArticles(snapshot dao.RankingSnapshot) ([]domain.Article, error) {
+	var arts []domain.Article
+	err := json.Unmarshal([]byte(snapshot.Articles), &arts)
+	return arts, err
+}
+
+func NewCachedRankingRepository(cache cache.RankingCache, dao dao.RankingDao, l logger.LoggerV1) RankingRepository {
+	return &CachedRankingRepository{cache: cache, dao: dao, l: l}
 }
 
 func NewCachedRankingRepositoryV1(rCache cache.RedisRankingCache, lCache cache.RankingLocalCache, l logger.LoggerV1) *CachedRankingRepository {
@@ -2,39 +2,73 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strconv"
 	"time"
 
 	eventArticle "github.com/misakimei123/redbook/internal/events/article"
 	"github.com/misakimei123/redbook/internal/repository"
 	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/misakimei123/redbook/pkg/textdiff"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/misakimei123/redbook/internal/domain"
 )
 
+var ErrRevisionNotFound = repository.ErrRevisionNotFound
+
 //go:generate mockgen -source=./article.go -package=svcmocks -destination=./mocks/article.mock.go ArticleService
 type ArticleService interface {
 	Save(ctx context.Context, article domain.Article) (int64, error)
 	Publish(ctx context.Context, article domain.Article) (int64, error)
 	PublishV1(ctx context.Context, article domain.Article) (int64, error)
 	Withdraw(ctx context.Context, id int64, uid int64) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, uid int64, id int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id int64, uid int64) (domain.Article, error)
-	ListPub(ctx context.Context, start time.Time, end time.Time, offset int, batchSize int) ([]domain.Article, error)
+	ListPub(ctx context.Context, start time.Time, end time.Time, cursor domain.Cursor, batchSize int) ([]domain.Article, error)
+	ListPubByTag(ctx context.Context, tag string, cursor domain.Cursor, limit int) ([]domain.Article, error)
+	ListRevisions(ctx context.Context, aid int64, uid int64, cursor domain.Cursor, limit int) ([]domain.ArticleRevision, error)
+	DiffRevisions(ctx context.Context, aid int64, uid int64, from int64, to int64) (domain.ArticleRevisionDiff, error)
+	// RestoreRevision 用历史版本覆盖草稿，恢复本身也会生成一个新版本
+	RestoreRevision(ctx context.Context, aid int64, uid int64, rid int64) error
+	// SchedulePublish 先保存草稿，到 at 的时候再发表
+	SchedulePublish(ctx context.Context, article domain.Article, at time.Time) (int64, error)
+	ReschedulePublish(ctx context.Context, aid int64, uid int64, at time.Time) error
+	CancelSchedulePublish(ctx context.Context, aid int64, uid int64) error
+	// PublishDraft 把作者当前的草稿发表出去
+	PublishDraft(ctx context.Context, aid int64, uid int64) (int64, error)
+	// PreloadPubs 把马上会有大量访问的文章提前放进缓存
+	PreloadPubs(ctx context.Context, ids []int64) error
+}
+
+// ArticlePublishExecutor 定时发表任务的执行器名字
+const ArticlePublishExecutor = "article_publish"
+
+// ArticlePublishCfg 定时发表任务的 Cfg
+type ArticlePublishCfg struct {
+	Aid   int64               `json:"aid"`
+	Uid   int64               `json:"uid"`
+	Retry *domain.JobRetryCfg `json:"retry,omitempty"`
 }
 
+// articlePublishRetry 定时发表只执行一次，失败了不重试就直接丢了
+var articlePublishRetry = domain.JobRetryCfg{MaxAttempts: 5, Backoff: "10s", MaxBackoff: "5m"}
+
 type articleService struct {
 	repo       repository.ArticleRepository
+	jobSvc     JobService
 	authorRepo repository.ArticleAuthorRepository
 	readerRepo repository.ArticleReaderRepository
 	producer   eventArticle.Producer
 	l          logger.LoggerV1
 }
 
-func NewArticleService(repo repository.ArticleRepository, producer eventArticle.Producer, log logger.LoggerV1) ArticleService {
+func NewArticleService(repo repository.ArticleRepository, jobSvc JobService, producer eventArticle.Producer, log logger.LoggerV1) ArticleService {
 	return &articleService{
 		repo:     repo,
+		jobSvc:   jobSvc,
 		producer: producer,
 		l:        log,
 	}
@@ -52,19 +86,17 @@ func (a *articleService) GetPubById(ctx context.Context, id int64, uid int64) (d
 	if err != nil {
 		return domain.Article{}, err
 	}
-	// TODO: send message
-	go func() {
-		er := a.producer.ProduceReadEvent(eventArticle.ReadEvent{
-			Aid: id,
-			Uid: uid,
-		})
-		if er != nil {
-			a.l.Error("produce read event fail",
-				logger.Int64("aid", id),
-				logger.Int64("uid", uid),
-				logger.Error(err))
-		}
-	}()
+	// 攒批写进 outbox，不阻塞读，失败了也不影响读
+	er := a.producer.ProduceReadEvent(eventArticle.ReadEvent{
+		Aid: id,
+		Uid: uid,
+	})
+	if er != nil {
+		a.l.Error("produce read event fail",
+			logger.Int64("aid", id),
+			logger.Int64("uid", uid),
+			logger.Error(er))
+	}
 	return article, nil
 }
 
@@ -102,25 +134,131 @@ func (a *articleService) PublishV1(ctx context.Context, article domain.Article)
 
 func (a *articleService) Publish(ctx context.Context, article domain.Article) (int64, error) {
 	article.Status = domain.ArticleStatusPublished
-	return a.repo.Sync(ctx, article)
+	id, err := a.repo.Sync(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
 }
 
 func (a *articleService) Save(ctx context.Context, article domain.Article) (int64, error) {
 	article.Status = domain.ArticleStatusUnpublished
+	var err error
 	if article.Id > 0 {
-		return article.Id, a.repo.Update(ctx, article)
+		err = a.repo.Update(ctx, article)
+	} else {
+		article.Id, err = a.repo.Create(ctx, article)
 	}
-	return a.repo.Create(ctx, article)
+	return article.Id, err
 }
 
 func (a *articleService) Withdraw(ctx context.Context, id int64, uid int64) error {
 	return a.repo.SyncStatus(ctx, id, uid, domain.ArticleStatusPrivate)
 }
 
-func (a *articleService) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
-	return a.repo.GetByAuthor(ctx, uid, offset, limit)
+func (a *articleService) GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	return a.repo.GetByAuthor(ctx, uid, cursor, limit)
+}
+
+func (a *articleService) ListPub(ctx context.Context, start time.Time, end time.Time, cursor domain.Cursor, batchSize int) ([]domain.Article, error) {
+	return a.repo.GetPubs(ctx, start.UnixMilli(), end.UnixMilli(), cursor, batchSize)
+}
+
+func (a *articleService) PreloadPubs(ctx context.Context, ids []int64) error {
+	return a.repo.PreloadPubs(ctx, ids)
+}
+
+func (a *articleService) ListPubByTag(ctx context.Context, tag string, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	return a.repo.GetPubsByTag(ctx, tag, cursor, limit)
+}
+
+func (a *articleService) ListRevisions(ctx context.Context, aid int64, uid int64, cursor domain.Cursor, limit int) ([]domain.ArticleRevision, error) {
+	return a.repo.GetRevisions(ctx, aid, uid, cursor, limit)
+}
+
+func (a *articleService) DiffRevisions(ctx context.Context, aid int64, uid int64, from int64, to int64) (domain.ArticleRevisionDiff, error) {
+	var (
+		eg      errgroup.Group
+		fromRev domain.ArticleRevision
+		toRev   domain.ArticleRevision
+	)
+	eg.Go(func() error {
+		var err error
+		fromRev, err = a.repo.GetRevision(ctx, aid, uid, from)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		toRev, err = a.repo.GetRevision(ctx, aid, uid, to)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	return domain.ArticleRevisionDiff{
+		From:    fromRev,
+		To:      toRev,
+		Title:   textdiff.Lines(fromRev.Title, toRev.Title),
+		Content: textdiff.Lines(fromRev.Content, toRev.Content),
+	}, nil
+}
+
+func (a *articleService) RestoreRevision(ctx context.Context, aid int64, uid int64, rid int64) error {
+	revision, err := a.repo.GetRevision(ctx, aid, uid, rid)
+	if err != nil {
+		return err
+	}
+	_, err = a.Save(ctx, domain.Article{
+		Id:      aid,
+		Title:   revision.Title,
+		Content: revision.Content,
+		Tags:    revision.Tags,
+		Author:  domain.Author{Id: uid},
+	})
+	return err
+}
+
+func (a *articleService) SchedulePublish(ctx context.Context, article domain.Article, at time.Time) (int64, error) {
+	id, err := a.Save(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+	cfg, err := json.Marshal(ArticlePublishCfg{Aid: id, Uid: article.Author.Id, Retry: &articlePublishRetry})
+	if err != nil {
+		return id, err
+	}
+	return id, a.jobSvc.ScheduleOnce(ctx, domain.Job{
+		Name:     a.publishJobName(id),
+		Executor: ArticlePublishExecutor,
+		Cfg:      string(cfg),
+	}, at)
+}
+
+func (a *articleService) ReschedulePublish(ctx context.Context, aid int64, uid int64, at time.Time) error {
+	// 确认是作者本人
+	_, err := a.repo.GetById(ctx, uid, aid)
+	if err != nil {
+		return err
+	}
+	return a.jobSvc.Reschedule(ctx, a.publishJobName(aid), at)
+}
+
+func (a *articleService) CancelSchedulePublish(ctx context.Context, aid int64, uid int64) error {
+	_, err := a.repo.GetById(ctx, uid, aid)
+	if err != nil {
+		return err
+	}
+	return a.jobSvc.Cancel(ctx, a.publishJobName(aid))
+}
+
+func (a *articleService) PublishDraft(ctx context.Context, aid int64, uid int64) (int64, error) {
+	article, err := a.repo.GetById(ctx, uid, aid)
+	if err != nil {
+		return 0, err
+	}
+	return a.Publish(ctx, article)
 }
 
-func (a *articleService) ListPub(ctx context.Context, start time.Time, end time.Time, offset int, batchSize int) ([]domain.Article, error) {
-	return a.repo.GetPubs(ctx, start.UnixMilli(), end.UnixMilli(), offset, batchSize)
+func (a *articleService) publishJobName(aid int64) string {
+	return "article_publish_" + strconv.FormatInt(aid, 10)
 }
@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/misakimei123/redbook/internal/domain"
+	eventArticle "github.com/misakimei123/redbook/internal/events/article"
+	articlemocks "github.com/misakimei123/redbook/internal/events/article/mocks"
 	"github.com/misakimei123/redbook/internal/repository"
 	repomocks "github.com/misakimei123/redbook/internal/repository/mocks"
+	svcmocks "github.com/misakimei123/redbook/internal/service/mocks"
+	"github.com/misakimei123/redbook/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -159,3 +164,78 @@ func TestArticleService_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleService_GetPubById(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.ArticleRepository, eventArticle.Producer)
+		wantArt domain.Article
+		wantErr error
+	}{
+		{
+			name: "produce read event",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, eventArticle.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{Id: 1, Title: "title"}, nil)
+				producer := articlemocks.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceReadEvent(eventArticle.ReadEvent{Aid: 1, Uid: 123}).Return(nil)
+				return repo, producer
+			},
+			wantArt: domain.Article{Id: 1, Title: "title"},
+		},
+		{
+			name: "produce fail is ignored",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, eventArticle.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{Id: 1, Title: "title"}, nil)
+				producer := articlemocks.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceReadEvent(gomock.Any()).Return(errors.New("mock kafka fail"))
+				return repo, producer
+			},
+			wantArt: domain.Article{Id: 1, Title: "title"},
+		},
+		{
+			name: "not found, no event",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, eventArticle.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{}, errors.New("mock db fail"))
+				return repo, articlemocks.NewMockProducer(ctrl)
+			},
+			wantErr: errors.New("mock db fail"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, producer, logger.NewNopLogger())
+			art, err := svc.GetPubById(context.Background(), 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArt, art)
+		})
+	}
+}
+
+// 定时发表是一次性任务，要带上重试策略，失败了不能直接结束
+func TestArticleService_SchedulePublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	at := time.Now().Add(time.Hour)
+	repo := repomocks.NewMockArticleRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	jobSvc := svcmocks.NewMockJobService(ctrl)
+	jobSvc.EXPECT().ScheduleOnce(gomock.Any(), gomock.Any(), at).
+		DoAndReturn(func(ctx context.Context, j domain.Job, at time.Time) error {
+			assert.Equal(t, "article_publish_1", j.Name)
+			policy := j.RetryPolicy()
+			assert.Equal(t, 5, policy.MaxAttempts)
+			assert.Equal(t, 10*time.Second, policy.Backoff)
+			return nil
+		})
+	svc := NewArticleService(repo, jobSvc, nil, logger.NewNopLogger())
+	id, err := svc.SchedulePublish(context.Background(), domain.Article{Title: "title", Author: domain.Author{Id: 123}}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	intrv1 "github.com/misakimei123/redbook/api/proto/gen/intr/v1"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+var (
+	ErrCommentNotFound         = repository.ErrCommentNotFound
+	ErrCommentPermissionDenied = errors.New("only the commenter or the owner of the biz can delete this comment")
+	ErrCommentParentMismatch   = errors.New("parent comment belongs to another biz")
+	ErrCommentBizNotFound      = errors.New("commented biz not found")
+)
+
+// replyPreviewLimit 列根评论的时候每条带几条回复
+const replyPreviewLimit = 3
+
+type CommentService interface {
+	// Create ParentId 不为 0 的时候是回复，会挂到 ParentId 所在的根评论下面
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	// Delete 评论的作者和被评论对象的作者都可以删
+	Delete(ctx context.Context, id int64, uid int64) error
+	ListRoots(ctx context.Context, biz string, bizId int64, cursor domain.Cursor, limit int) ([]domain.Comment, error)
+	ListReplies(ctx context.Context, rootId int64, cursor domain.Cursor, limit int) ([]domain.Comment, error)
+}
+
+type commentService struct {
+	repo    repository.CommentRepository
+	artRepo repository.ArticleRepository
+	intrSvc intrv1.InteractiveServiceClient
+	l       logger.LoggerV1
+}
+
+func NewCommentService(repo repository.CommentRepository, artRepo repository.ArticleRepository,
+	intrSvc intrv1.InteractiveServiceClient, l logger.LoggerV1) CommentService {
+	return &commentService{repo: repo, artRepo: artRepo, intrSvc: intrSvc, l: l}
+}
+
+func (s *commentService) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	c.RootId = 0
+	if c.ParentId > 0 {
+		parent, err := s.repo.FindById(ctx, c.ParentId)
+		if err != nil {
+			return 0, err
+		}
+		if parent.Biz != c.Biz || parent.BizId != c.BizId {
+			return 0, ErrCommentParentMismatch
+		}
+		c.RootId = parent.RootId
+		if parent.IsRoot() {
+			c.RootId = parent.Id
+		}
+	} else {
+		// 确认被评论的对象存在
+		if err := s.checkBiz(ctx, c.Biz, c.BizId); err != nil {
+			return 0, err
+		}
+	}
+	id, err := s.repo.Create(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	s.incrCommentCnt(c.Biz, c.BizId, 1)
+	return id, nil
+}
+
+func (s *commentService) Delete(ctx context.Context, id int64, uid int64) error {
+	c, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		owner, er := s.bizOwner(ctx, c.Biz, c.BizId)
+		if er != nil {
+			return er
+		}
+		if owner != uid {
+			return ErrCommentPermissionDenied
+		}
+	}
+	cnt, err := s.repo.Delete(ctx, c)
+	if err != nil {
+		return err
+	}
+	if cnt > 0 {
+		s.incrCommentCnt(c.Biz, c.BizId, -cnt)
+	}
+	return nil
+}
+
+func (s *commentService) ListRoots(ctx context.Context, biz string, bizId int64, cursor domain.Cursor, limit int) ([]domain.Comment, error) {
+	return s.repo.FindRoots(ctx, biz, bizId, cursor, limit, replyPreviewLimit)
+}
+
+func (s *commentService) ListReplies(ctx context.Context, rootId int64, cursor domain.Cursor, limit int) ([]domain.Comment, error) {
+	return s.repo.FindReplies(ctx, rootId, cursor, limit)
+}
+
+// bizOwner 返回被评论对象的作者，不认识的 biz 返回 0，也就是只有评论的作者能删
+func (s *commentService) bizOwner(ctx context.Context, biz string, bizId int64) (int64, error) {
+	switch biz {
+	case "article":
+		art, err := s.artRepo.GetPubById(ctx, bizId)
+		if err != nil {
+			return 0, err
+		}
+		return art.Author.Id, nil
+	default:
+		return 0, ErrCommentBizNotFound
+	}
+}
+
+// checkBiz 只能评论已经发表的文章，不认识的 biz 也当作不存在
+func (s *commentService) checkBiz(ctx context.Context, biz string, bizId int64) error {
+	switch biz {
+	case "article":
+		art, err := s.artRepo.GetPubById(ctx, bizId)
+		if errors.Is(err, repository.ErrArticleNotFound) {
+			return ErrCommentBizNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if art.Status != domain.ArticleStatusPublished {
+			return ErrCommentBizNotFound
+		}
+		return nil
+	default:
+		return ErrCommentBizNotFound
+	}
+}
+
+// incrCommentCnt 计数失败不影响评论本身
+func (s *commentService) incrCommentCnt(biz string, bizId int64, delta int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := s.intrSvc.IncrCommentCnt(ctx, &intrv1.IncrCommentCntRequest{
+		BizStr: biz,
+		BizId:  bizId,
+		Delta:  delta,
+	})
+	if err != nil {
+		s.l.Error("incr comment cnt fail",
+			logger.String("biz", biz),
+			logger.Int64("bizId", bizId),
+			logger.Int64("delta", delta),
+			logger.Error(err))
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/misakimei123/redbook/internal/client"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository"
+	repomocks "github.com/misakimei123/redbook/internal/repository/mocks"
+	svcmocks "github.com/misakimei123/redbook/internal/service/mocks"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCommentService_Create(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService)
+		comment domain.Comment
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "root comment",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 100}, Status: domain.ArticleStatusPublished}, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{Uid: 2, Biz: "article", BizId: 1, Content: "hi"}).
+					Return(int64(10), nil)
+				intrSvc.EXPECT().IncrCommentCnt(gomock.Any(), "article", int64(1), int64(1)).Return(nil)
+				return repo, artRepo, intrSvc
+			},
+			comment: domain.Comment{Uid: 2, Biz: "article", BizId: 1, Content: "hi"},
+			wantId:  10,
+		},
+		{
+			name: "reply to a reply hangs under the root",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).
+					Return(domain.Comment{Id: 11, Biz: "article", BizId: 1, RootId: 10, ParentId: 10}, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{Uid: 2, Biz: "article", BizId: 1, RootId: 10, ParentId: 11, Content: "hi"}).
+					Return(int64(12), nil)
+				intrSvc.EXPECT().IncrCommentCnt(gomock.Any(), "article", int64(1), int64(1)).Return(nil)
+				return repo, artRepo, intrSvc
+			},
+			comment: domain.Comment{Uid: 2, Biz: "article", BizId: 1, ParentId: 11, Content: "hi"},
+			wantId:  12,
+		},
+		{
+			name: "parent belongs to another biz",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).
+					Return(domain.Comment{Id: 11, Biz: "article", BizId: 2}, nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl), svcmocks.NewMockInteractiveService(ctrl)
+			},
+			comment: domain.Comment{Uid: 2, Biz: "article", BizId: 1, ParentId: 11, Content: "hi"},
+			wantErr: ErrCommentParentMismatch,
+		},
+		{
+			name: "root comment on a withdrawn article",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPrivate}, nil)
+				return repomocks.NewMockCommentRepository(ctrl), artRepo, svcmocks.NewMockInteractiveService(ctrl)
+			},
+			comment: domain.Comment{Uid: 2, Biz: "article", BizId: 1, Content: "hi"},
+			wantErr: ErrCommentBizNotFound,
+		},
+		{
+			name: "root comment on a missing article",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return repomocks.NewMockCommentRepository(ctrl), artRepo, svcmocks.NewMockInteractiveService(ctrl)
+			},
+			comment: domain.Comment{Uid: 2, Biz: "article", BizId: 1, Content: "hi"},
+			wantErr: ErrCommentBizNotFound,
+		},
+		{
+			name: "root comment on an unknown biz",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				return repomocks.NewMockCommentRepository(ctrl), repomocks.NewMockArticleRepository(ctrl), svcmocks.NewMockInteractiveService(ctrl)
+			},
+			comment: domain.Comment{Uid: 2, Biz: "video", BizId: 1, Content: "hi"},
+			wantErr: ErrCommentBizNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo, intrSvc := tc.mock(ctrl)
+			svc := NewCommentService(repo, artRepo, client.NewLocalInteractiveServiceAdapter(intrSvc), logger.NewNopLogger())
+			id, err := svc.Create(context.Background(), tc.comment)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestCommentService_Delete(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService)
+		uid     int64
+		wantErr error
+	}{
+		{
+			name: "commenter deletes a thread",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				c := domain.Comment{Id: 10, Uid: 2, Biz: "article", BizId: 1}
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(c, nil)
+				repo.EXPECT().Delete(gomock.Any(), c).Return(int64(3), nil)
+				intrSvc.EXPECT().IncrCommentCnt(gomock.Any(), "article", int64(1), int64(-3)).Return(nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl), intrSvc
+			},
+			uid: 2,
+		},
+		{
+			name: "article author deletes",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				c := domain.Comment{Id: 10, Uid: 2, Biz: "article", BizId: 1}
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(c, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 100}}, nil)
+				repo.EXPECT().Delete(gomock.Any(), c).Return(int64(1), nil)
+				intrSvc.EXPECT().IncrCommentCnt(gomock.Any(), "article", int64(1), int64(-1)).Return(nil)
+				return repo, artRepo, intrSvc
+			},
+			uid: 100,
+		},
+		{
+			name: "others can not delete",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository, *svcmocks.MockInteractiveService) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{Id: 10, Uid: 2, Biz: "article", BizId: 1}, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 100}}, nil)
+				return repo, artRepo, svcmocks.NewMockInteractiveService(ctrl)
+			},
+			uid:     3,
+			wantErr: ErrCommentPermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo, intrSvc := tc.mock(ctrl)
+			svc := NewCommentService(repo, artRepo, client.NewLocalInteractiveServiceAdapter(intrSvc), logger.NewNopLogger())
+			err := svc.Delete(context.Background(), 10, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
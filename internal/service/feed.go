@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+
+	followv1 "github.com/misakimei123/redbook/api/proto/gen/follow/v1"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+type FeedService interface {
+	// Publish 粉丝少的作者推到粉丝的收件箱，粉丝多的只写发件箱，读的时候再拉
+	Publish(ctx context.Context, item domain.FeedItem) error
+	// Withdraw 文章撤回之后从所有人的关注流里面删掉
+	Withdraw(ctx context.Context, aid int64) error
+	// Unfollow 取消关注之后，之前推到 uid 收件箱里面的 authorId 的文章也要删掉
+	Unfollow(ctx context.Context, uid int64, authorId int64) error
+	List(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error)
+}
+
+type feedService struct {
+	repo      repository.FeedRepository
+	followSvc followv1.FollowServiceClient
+	l         logger.LoggerV1
+	// pushThreshold 粉丝数超过这个值就不推了
+	pushThreshold int64
+	batchSize     int
+	// maxPullFollowees 读的时候最多从最近关注的这么多人的发件箱里面拉
+	maxPullFollowees int
+}
+
+func NewFeedService(repo repository.FeedRepository, followSvc followv1.FollowServiceClient, l logger.LoggerV1) FeedService {
+	return &feedService{
+		repo:             repo,
+		followSvc:        followSvc,
+		l:                l,
+		pushThreshold:    1000,
+		batchSize:        500,
+		maxPullFollowees: 1000,
+	}
+}
+
+func (f *feedService) Publish(ctx context.Context, item domain.FeedItem) error {
+	resp, err := f.followSvc.GetFollowStatic(ctx, &followv1.GetFollowStaticRequest{Uid: item.AuthorId})
+	if err != nil {
+		return err
+	}
+	if resp.GetFollowStatic().GetFollowers() > f.pushThreshold {
+		return f.repo.AddPullItem(ctx, item)
+	}
+	var lastId int64
+	for {
+		followers, er := f.followSvc.GetFollower(ctx, &followv1.GetFollowerRequest{
+			Followee: item.AuthorId,
+			LastId:   lastId,
+			Limit:    int64(f.batchSize),
+		})
+		if er != nil {
+			return er
+		}
+		rels := followers.GetFollowRelations()
+		uids := make([]int64, 0, len(rels))
+		for _, rel := range rels {
+			uids = append(uids, rel.GetFollower())
+		}
+		er = f.repo.AddPushItems(ctx, uids, item)
+		if er != nil {
+			return er
+		}
+		if len(rels) < f.batchSize {
+			return nil
+		}
+		lastId = rels[len(rels)-1].GetId()
+	}
+}
+
+func (f *feedService) Withdraw(ctx context.Context, aid int64) error {
+	return f.repo.DeleteItems(ctx, aid)
+}
+
+func (f *feedService) Unfollow(ctx context.Context, uid int64, authorId int64) error {
+	return f.repo.DeletePushItems(ctx, uid, authorId)
+}
+
+// List 推过来的一定在收件箱里面，只有大 V 的发件箱里面有数据，所以直接拉所有关注的人就可以
+func (f *feedService) List(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error) {
+	followees, err := f.followees(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return f.repo.FindFeed(ctx, uid, followees, cursor, limit)
+}
+
+func (f *feedService) followees(ctx context.Context, uid int64) ([]int64, error) {
+	var (
+		res    []int64
+		lastId int64
+	)
+	for len(res) < f.maxPullFollowees {
+		resp, err := f.followSvc.GetFollowee(ctx, &followv1.GetFolloweeRequest{
+			Follower: uid,
+			LastId:   lastId,
+			Limit:    int64(f.batchSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		rels := resp.GetFollowRelations()
+		for _, rel := range rels {
+			res = append(res, rel.GetFollowee())
+		}
+		if len(rels) < f.batchSize {
+			break
+		}
+		lastId = rels[len(rels)-1].GetId()
+	}
+	return res, nil
+}
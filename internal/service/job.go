@@ -2,6 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/misakimei123/redbook/internal/domain"
@@ -9,56 +13,206 @@ import (
 	"github.com/misakimei123/redbook/pkg/logger"
 )
 
+var (
+	ErrJobNotFound  = repository.ErrJobNotFound
+	ErrDuplicateJob = repository.ErrDuplicateJob
+	ErrJobLost      = repository.ErrJobLost
+	ErrNoMoreJob    = repository.ErrNoMoreJob
+	ErrInvalidCron  = errors.New("invalid cron expression")
+)
+
 type JobService interface {
-	Preempt(ctx context.Context) (domain.Job, error)
+	// Preempt 抢占到的任务会定时续约，续约发现任务已经被别的节点抢走了就用 ErrJobLost 调用 onLost，
+	// 调用方在 onLost 里面中断正在执行的任务。没有可以抢占的任务返回 ErrNoMoreJob
+	Preempt(ctx context.Context, onLost func(cause error)) (domain.Job, error)
+	// ResetNextTime 和 Retry 在任务已经被别的节点抢走，或者执行期间被重新安排了的时候返回 ErrJobLost
 	ResetNextTime(ctx context.Context, j domain.Job) error
+	// ScheduleOnce 在 at 执行一次，同名任务会被覆盖
+	ScheduleOnce(ctx context.Context, j domain.Job, at time.Time) error
+	Reschedule(ctx context.Context, name string, at time.Time) error
+	// Cancel 删除等待中或者暂停的任务
+	Cancel(ctx context.Context, name string) error
+	// Register 注册一个 cron 任务，同名任务已经存在返回 ErrDuplicateJob
+	Register(ctx context.Context, j domain.Job) error
+	Pause(ctx context.Context, name string) error
+	// Resume 按 cron 表达式重新计算下一次执行的时间
+	Resume(ctx context.Context, name string) error
+	// Trigger 让等待中的任务马上执行一次
+	Trigger(ctx context.Context, name string) error
+	List(ctx context.Context, offset int, limit int) ([]domain.Job, error)
+	// StartExecution 记录一次执行的开始，返回的记录要在 EndExecution 的时候带回来
+	StartExecution(ctx context.Context, j domain.Job) (domain.JobExecution, error)
+	// EndExecution execErr 为 nil 说明执行成功
+	EndExecution(ctx context.Context, e domain.JobExecution, execErr error) error
+	// Retry 执行失败之后按照 Cfg 里面的重试策略决定下一次执行的时间，
+	// 重试次数用完了 cron 任务等下一次，一次性任务直接结束
+	Retry(ctx context.Context, j domain.Job) error
+	ListExecutions(ctx context.Context, name string, offset int, limit int) ([]domain.JobExecution, error)
 }
 
 type CronJobService struct {
 	repo            repository.JobRepository
 	l               logger.LoggerV1
 	refreshInterval time.Duration
+	// owner 当前节点的标识，抢占任务的时候记下来
+	owner string
 }
 
-func (c *CronJobService) Preempt(ctx context.Context) (domain.Job, error) {
-	j, err := c.repo.Preempt(ctx)
+func (c *CronJobService) Preempt(ctx context.Context, onLost func(cause error)) (domain.Job, error) {
+	j, err := c.repo.Preempt(ctx, c.owner)
+	if errors.Is(err, ErrNoMoreJob) {
+		return domain.Job{}, err
+	}
 	if err != nil {
 		c.l.Error("Preempt error", logger.Error(err))
 		return domain.Job{}, err
 	}
-	ticker := time.NewTicker(c.refreshInterval)
+	// 续约和释放都用抢占时拿到的 owner 和 version
+	lease := j
+	done := make(chan struct{})
 	go func() {
-		for range ticker.C {
-			c.refresh(j.Id)
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if errors.Is(c.refresh(lease), ErrJobLost) {
+					onLost(ErrJobLost)
+					return
+				}
+			}
 		}
 	}()
+	var once sync.Once
 	j.CancelFunc = func() {
-		ticker.Stop()
-		rCtx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
-		defer cancelFunc()
-		releaseErr := c.repo.Release(rCtx, j.Id)
-		if releaseErr != nil {
-			c.l.Error("release job fail",
-				logger.Error(releaseErr),
-				logger.Int64("job id", j.Id))
-		}
+		once.Do(func() {
+			close(done)
+			rCtx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+			defer cancelFunc()
+			releaseErr := c.repo.Release(rCtx, lease)
+			if errors.Is(releaseErr, ErrJobLost) {
+				c.l.Warn("job lost before release", logger.Int64("job id", j.Id))
+				return
+			}
+			if releaseErr != nil {
+				c.l.Error("release job fail",
+					logger.Error(releaseErr),
+					logger.Int64("job id", j.Id))
+			}
+		})
 	}
 	return j, nil
 }
 
 func NewCronJobService(repo repository.JobRepository, l logger.LoggerV1) JobService {
-	return &CronJobService{repo: repo, l: l, refreshInterval: time.Minute}
+	hostname, _ := os.Hostname()
+	return &CronJobService{repo: repo, l: l, refreshInterval: time.Minute,
+		owner: fmt.Sprintf("%s:%d", hostname, os.Getpid())}
 }
 
 func (c *CronJobService) ResetNextTime(ctx context.Context, j domain.Job) error {
-	return c.repo.UpdateNextTime(ctx, j.Id, j.NextTime())
+	next := j.NextTime()
+	if next.IsZero() {
+		return c.repo.Finish(ctx, j)
+	}
+	return c.repo.UpdateNextTime(ctx, j, next)
+}
+
+func (c *CronJobService) ScheduleOnce(ctx context.Context, j domain.Job, at time.Time) error {
+	j.Expression = ""
+	return c.repo.Upsert(ctx, j, at)
+}
+
+func (c *CronJobService) Reschedule(ctx context.Context, name string, at time.Time) error {
+	return c.repo.UpdateNextTimeByName(ctx, name, at)
+}
+
+func (c *CronJobService) Cancel(ctx context.Context, name string) error {
+	return c.repo.DeleteByName(ctx, name)
+}
+
+func (c *CronJobService) Register(ctx context.Context, j domain.Job) error {
+	if j.Expression == "" || j.CheckExpression() != nil {
+		return ErrInvalidCron
+	}
+	return c.repo.Create(ctx, j, j.NextTime())
+}
+
+func (c *CronJobService) Pause(ctx context.Context, name string) error {
+	return c.repo.Pause(ctx, name)
+}
+
+func (c *CronJobService) Resume(ctx context.Context, name string) error {
+	j, err := c.repo.FindByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	next := j.NextTime()
+	if next.IsZero() {
+		// 一次性任务还是按照原来的时间，已经过了就马上执行
+		next = j.NextExecTime
+	}
+	return c.repo.Resume(ctx, name, next)
+}
+
+func (c *CronJobService) Trigger(ctx context.Context, name string) error {
+	return c.repo.UpdateNextTimeByName(ctx, name, time.Now())
+}
+
+func (c *CronJobService) List(ctx context.Context, offset int, limit int) ([]domain.Job, error) {
+	return c.repo.List(ctx, offset, limit)
+}
+
+func (c *CronJobService) StartExecution(ctx context.Context, j domain.Job) (domain.JobExecution, error) {
+	e := domain.JobExecution{
+		JobId:   j.Id,
+		JobName: j.Name,
+		Node:    c.owner,
+		Attempt: j.Attempts + 1,
+		Status:  domain.JobExecutionStatusRunning,
+		Start:   time.Now(),
+	}
+	id, err := c.repo.StartExecution(ctx, e)
+	e.Id = id
+	return e, err
+}
+
+func (c *CronJobService) EndExecution(ctx context.Context, e domain.JobExecution, execErr error) error {
+	e.End = time.Now()
+	e.Status = domain.JobExecutionStatusSuccess
+	if execErr != nil {
+		e.Status = domain.JobExecutionStatusFailed
+		e.Error = execErr.Error()
+	}
+	return c.repo.EndExecution(ctx, e)
+}
+
+func (c *CronJobService) Retry(ctx context.Context, j domain.Job) error {
+	attempts := j.Attempts + 1
+	policy := j.RetryPolicy()
+	if attempts < policy.MaxAttempts {
+		return c.repo.Retry(ctx, j, attempts, time.Now().Add(policy.NextBackoff(attempts)))
+	}
+	c.l.Warn("job retry exhausted",
+		logger.Int64("job id", j.Id),
+		logger.String("name", j.Name),
+		logger.Int64("attempts", int64(attempts)))
+	return c.ResetNextTime(ctx, j)
+}
+
+func (c *CronJobService) ListExecutions(ctx context.Context, name string, offset int, limit int) ([]domain.JobExecution, error) {
+	return c.repo.ListExecutions(ctx, name, offset, limit)
 }
 
-func (c *CronJobService) refresh(jid int64) {
+// refresh 续约失败了下一次还会再试，只有发现任务被抢走了才放弃
+func (c *CronJobService) refresh(j domain.Job) error {
 	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
 	defer cancelFunc()
-	err := c.repo.UpdateUtime(ctx, jid)
+	err := c.repo.UpdateUtime(ctx, j)
 	if err != nil {
-		c.l.Error("refresh fail", logger.Error(err), logger.Int64("job id", jid))
+		c.l.Error("refresh fail", logger.Error(err), logger.Int64("job id", j.Id))
 	}
+	return err
 }
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/repository"
+	repomocks "github.com/misakimei123/redbook/internal/repository/mocks"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCronJobService_Register(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.JobRepository
+		job     domain.Job
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Job{Name: "ranking", Expression: "0 */3 * * * *", Executor: "local"}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, j domain.Job, nextTime time.Time) error {
+						assert.True(t, nextTime.After(time.Now()))
+						return nil
+					})
+				return repo
+			},
+			job: domain.Job{Name: "ranking", Expression: "0 */3 * * * *", Executor: "local"},
+		},
+		{
+			name: "duplicate",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.ErrDuplicateJob)
+				return repo
+			},
+			job:     domain.Job{Name: "ranking", Expression: "0 */3 * * * *", Executor: "local"},
+			wantErr: ErrDuplicateJob,
+		},
+		{
+			name: "illegal cron",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				return repomocks.NewMockJobRepository(ctrl)
+			},
+			job:     domain.Job{Name: "ranking", Expression: "*/3 * * *", Executor: "local"},
+			wantErr: ErrInvalidCron,
+		},
+		{
+			name: "no cron",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				return repomocks.NewMockJobRepository(ctrl)
+			},
+			job:     domain.Job{Name: "ranking", Executor: "local"},
+			wantErr: ErrInvalidCron,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCronJobService(tc.mock(ctrl), logger.NewNopLogger())
+			err := svc.Register(context.Background(), tc.job)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCronJobService_Resume(t *testing.T) {
+	at := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.JobRepository
+		wantErr error
+	}{
+		{
+			name: "cron job",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().FindByName(gomock.Any(), "job").
+					Return(domain.Job{Name: "job", Expression: "0 */3 * * * *", NextExecTime: at}, nil)
+				repo.EXPECT().Resume(gomock.Any(), "job", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, name string, nextTime time.Time) error {
+						assert.True(t, nextTime.After(time.Now()))
+						return nil
+					})
+				return repo
+			},
+		},
+		{
+			name: "once job keeps next time",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().FindByName(gomock.Any(), "job").
+					Return(domain.Job{Name: "job", NextExecTime: at}, nil)
+				repo.EXPECT().Resume(gomock.Any(), "job", at).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "not found",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().FindByName(gomock.Any(), "job").Return(domain.Job{}, repository.ErrJobNotFound)
+				return repo
+			},
+			wantErr: ErrJobNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCronJobService(tc.mock(ctrl), logger.NewNopLogger())
+			err := svc.Resume(context.Background(), "job")
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCronJobService_Retry(t *testing.T) {
+	const retryCfg = `{"retry": {"maxAttempts": 3, "backoff": "10s", "maxBackoff": "15s"}}`
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.JobRepository
+		job     domain.Job
+		wantErr error
+	}{
+		{
+			name: "first retry",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Retry(gomock.Any(), gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, j domain.Job, attempts int, nextTime time.Time) error {
+						assert.WithinDuration(t, time.Now().Add(10*time.Second), nextTime, time.Second)
+						return nil
+					})
+				return repo
+			},
+			job: domain.Job{Id: 1, Expression: "0 0 * * * *", Cfg: retryCfg},
+		},
+		{
+			name: "backoff capped",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Retry(gomock.Any(), gomock.Any(), 2, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, j domain.Job, attempts int, nextTime time.Time) error {
+						assert.WithinDuration(t, time.Now().Add(15*time.Second), nextTime, time.Second)
+						return nil
+					})
+				return repo
+			},
+			job: domain.Job{Id: 1, Expression: "0 0 * * * *", Cfg: retryCfg, Attempts: 1},
+		},
+		{
+			name: "exhausted cron job waits next time",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().UpdateNextTime(gomock.Any(), domain.Job{Id: 1, Expression: "0 0 * * * *", Cfg: retryCfg, Attempts: 2}, gomock.Any()).Return(nil)
+				return repo
+			},
+			job: domain.Job{Id: 1, Expression: "0 0 * * * *", Cfg: retryCfg, Attempts: 2},
+		},
+		{
+			name: "no retry policy, once job finished",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Finish(gomock.Any(), domain.Job{Id: 1, Cfg: `{"aid": 1, "uid": 2}`}).Return(nil)
+				return repo
+			},
+			job: domain.Job{Id: 1, Cfg: `{"aid": 1, "uid": 2}`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCronJobService(tc.mock(ctrl), logger.NewNopLogger())
+			err := svc.Retry(context.Background(), tc.job)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCronJobService_PreemptLost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	job := domain.Job{Id: 1, Name: "ranking", Owner: "node-1", Version: 3}
+	repo := repomocks.NewMockJobRepository(ctrl)
+	repo.EXPECT().Preempt(gomock.Any(), "node-1").Return(job, nil)
+	repo.EXPECT().UpdateUtime(gomock.Any(), job).Return(repository.ErrJobLost)
+	repo.EXPECT().Release(gomock.Any(), job).Return(repository.ErrJobLost)
+	svc := &CronJobService{repo: repo, l: logger.NewNopLogger(), owner: "node-1",
+		refreshInterval: time.Millisecond * 10}
+
+	lost := make(chan error, 1)
+	j, err := svc.Preempt(context.Background(), func(cause error) {
+		lost <- cause
+	})
+	assert.NoError(t, err)
+	select {
+	case cause := <-lost:
+		assert.Equal(t, ErrJobLost, cause)
+	case <-time.After(time.Second):
+		t.Fatal("onLost not called")
+	}
+	j.CancelFunc()
+	// 重复调用不会再释放一次
+	j.CancelFunc()
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./article.go
+// Source: ./internal/service/article.go
 //
 // Generated by this command:
 //
-//	mockgen -source=./article.go -package=svcmocks -destination=./mocks/article.mock.go ArticleService
+//	mockgen -source=./internal/service/article.go -package=svcmocks -destination=./internal/service/mocks/article.mock.go
 //
 
 // Package svcmocks is a generated GoMock package.
@@ -41,19 +41,48 @@ func (m *MockArticleService) EXPECT() *MockArticleServiceMockRecorder {
 	return m.recorder
 }
 
+// CancelSchedulePublish mocks base method.
+func (m *MockArticleService) CancelSchedulePublish(ctx context.Context, aid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedulePublish", ctx, aid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedulePublish indicates an expected call of CancelSchedulePublish.
+func (mr *MockArticleServiceMockRecorder) CancelSchedulePublish(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedulePublish", reflect.TypeOf((*MockArticleService)(nil).CancelSchedulePublish), ctx, aid, uid)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleService) DiffRevisions(ctx context.Context, aid, uid, from, to int64) (domain.ArticleRevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, aid, uid, from, to)
+	ret0, _ := ret[0].(domain.ArticleRevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceMockRecorder) DiffRevisions(ctx, aid, uid, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleService)(nil).DiffRevisions), ctx, aid, uid, from, to)
+}
+
 // GetByAuthor mocks base method.
-func (m *MockArticleService) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+func (m *MockArticleService) GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, cursor, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByAuthor indicates an expected call of GetByAuthor.
-func (mr *MockArticleServiceMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) GetByAuthor(ctx, uid, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleService)(nil).GetByAuthor), ctx, uid, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleService)(nil).GetByAuthor), ctx, uid, cursor, limit)
 }
 
 // GetById mocks base method.
@@ -87,18 +116,62 @@ func (mr *MockArticleServiceMockRecorder) GetPubById(ctx, id, uid any) *gomock.C
 }
 
 // ListPub mocks base method.
-func (m *MockArticleService) ListPub(ctx context.Context, start, end time.Time, offset, batchSize int) ([]domain.Article, error) {
+func (m *MockArticleService) ListPub(ctx context.Context, start, end time.Time, cursor domain.Cursor, batchSize int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPub", ctx, start, end, offset, batchSize)
+	ret := m.ctrl.Call(m, "ListPub", ctx, start, end, cursor, batchSize)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPub indicates an expected call of ListPub.
-func (mr *MockArticleServiceMockRecorder) ListPub(ctx, start, end, offset, batchSize any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) ListPub(ctx, start, end, cursor, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), ctx, start, end, cursor, batchSize)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleService) ListPubByTag(ctx context.Context, tag string, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleServiceMockRecorder) ListPubByTag(ctx, tag, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), ctx, start, end, offset, batchSize)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleService)(nil).ListPubByTag), ctx, tag, cursor, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleService) ListRevisions(ctx context.Context, aid, uid int64, cursor domain.Cursor, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, aid, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceMockRecorder) ListRevisions(ctx, aid, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleService)(nil).ListRevisions), ctx, aid, uid, cursor, limit)
+}
+
+// PreloadPubs mocks base method.
+func (m *MockArticleService) PreloadPubs(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreloadPubs", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreloadPubs indicates an expected call of PreloadPubs.
+func (mr *MockArticleServiceMockRecorder) PreloadPubs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreloadPubs", reflect.TypeOf((*MockArticleService)(nil).PreloadPubs), ctx, ids)
 }
 
 // Publish mocks base method.
@@ -116,6 +189,21 @@ func (mr *MockArticleServiceMockRecorder) Publish(ctx, article any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleService)(nil).Publish), ctx, article)
 }
 
+// PublishDraft mocks base method.
+func (m *MockArticleService) PublishDraft(ctx context.Context, aid, uid int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDraft", ctx, aid, uid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDraft indicates an expected call of PublishDraft.
+func (mr *MockArticleServiceMockRecorder) PublishDraft(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDraft", reflect.TypeOf((*MockArticleService)(nil).PublishDraft), ctx, aid, uid)
+}
+
 // PublishV1 mocks base method.
 func (m *MockArticleService) PublishV1(ctx context.Context, article domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +219,34 @@ func (mr *MockArticleServiceMockRecorder) PublishV1(ctx, article any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishV1", reflect.TypeOf((*MockArticleService)(nil).PublishV1), ctx, article)
 }
 
+// ReschedulePublish mocks base method.
+func (m *MockArticleService) ReschedulePublish(ctx context.Context, aid, uid int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReschedulePublish", ctx, aid, uid, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReschedulePublish indicates an expected call of ReschedulePublish.
+func (mr *MockArticleServiceMockRecorder) ReschedulePublish(ctx, aid, uid, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReschedulePublish", reflect.TypeOf((*MockArticleService)(nil).ReschedulePublish), ctx, aid, uid, at)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockArticleService) RestoreRevision(ctx context.Context, aid, uid, rid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, aid, uid, rid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServiceMockRecorder) RestoreRevision(ctx, aid, uid, rid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleService)(nil).RestoreRevision), ctx, aid, uid, rid)
+}
+
 // Save mocks base method.
 func (m *MockArticleService) Save(ctx context.Context, article domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +262,21 @@ func (mr *MockArticleServiceMockRecorder) Save(ctx, article any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleService)(nil).Save), ctx, article)
 }
 
+// SchedulePublish mocks base method.
+func (m *MockArticleService) SchedulePublish(ctx context.Context, article domain.Article, at time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePublish", ctx, article, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePublish indicates an expected call of SchedulePublish.
+func (mr *MockArticleServiceMockRecorder) SchedulePublish(ctx, article, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePublish", reflect.TypeOf((*MockArticleService)(nil).SchedulePublish), ctx, article, at)
+}
+
 // Withdraw mocks base method.
 func (m *MockArticleService) Withdraw(ctx context.Context, id, uid int64) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,242 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/service/job.go -package=svcmocks -destination=./internal/service/mocks/job.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/misakimei123/redbook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobService is a mock of JobService interface.
+type MockJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobServiceMockRecorder
+}
+
+// MockJobServiceMockRecorder is the mock recorder for MockJobService.
+type MockJobServiceMockRecorder struct {
+	mock *MockJobService
+}
+
+// NewMockJobService creates a new mock instance.
+func NewMockJobService(ctrl *gomock.Controller) *MockJobService {
+	mock := &MockJobService{ctrl: ctrl}
+	mock.recorder = &MockJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobService) EXPECT() *MockJobServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockJobService) Cancel(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockJobServiceMockRecorder) Cancel(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockJobService)(nil).Cancel), ctx, name)
+}
+
+// EndExecution mocks base method.
+func (m *MockJobService) EndExecution(ctx context.Context, e domain.JobExecution, execErr error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndExecution", ctx, e, execErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndExecution indicates an expected call of EndExecution.
+func (mr *MockJobServiceMockRecorder) EndExecution(ctx, e, execErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndExecution", reflect.TypeOf((*MockJobService)(nil).EndExecution), ctx, e, execErr)
+}
+
+// List mocks base method.
+func (m *MockJobService) List(ctx context.Context, offset, limit int) ([]domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockJobServiceMockRecorder) List(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockJobService)(nil).List), ctx, offset, limit)
+}
+
+// ListExecutions mocks base method.
+func (m *MockJobService) ListExecutions(ctx context.Context, name string, offset, limit int) ([]domain.JobExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExecutions", ctx, name, offset, limit)
+	ret0, _ := ret[0].([]domain.JobExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExecutions indicates an expected call of ListExecutions.
+func (mr *MockJobServiceMockRecorder) ListExecutions(ctx, name, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExecutions", reflect.TypeOf((*MockJobService)(nil).ListExecutions), ctx, name, offset, limit)
+}
+
+// Pause mocks base method.
+func (m *MockJobService) Pause(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockJobServiceMockRecorder) Pause(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockJobService)(nil).Pause), ctx, name)
+}
+
+// Preempt mocks base method.
+func (m *MockJobService) Preempt(ctx context.Context, onLost func(error)) (domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preempt", ctx, onLost)
+	ret0, _ := ret[0].(domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preempt indicates an expected call of Preempt.
+func (mr *MockJobServiceMockRecorder) Preempt(ctx, onLost any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preempt", reflect.TypeOf((*MockJobService)(nil).Preempt), ctx, onLost)
+}
+
+// Register mocks base method.
+func (m *MockJobService) Register(ctx context.Context, j domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, j)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockJobServiceMockRecorder) Register(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockJobService)(nil).Register), ctx, j)
+}
+
+// Reschedule mocks base method.
+func (m *MockJobService) Reschedule(ctx context.Context, name string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, name, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockJobServiceMockRecorder) Reschedule(ctx, name, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockJobService)(nil).Reschedule), ctx, name, at)
+}
+
+// ResetNextTime mocks base method.
+func (m *MockJobService) ResetNextTime(ctx context.Context, j domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetNextTime", ctx, j)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetNextTime indicates an expected call of ResetNextTime.
+func (mr *MockJobServiceMockRecorder) ResetNextTime(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetNextTime", reflect.TypeOf((*MockJobService)(nil).ResetNextTime), ctx, j)
+}
+
+// Resume mocks base method.
+func (m *MockJobService) Resume(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockJobServiceMockRecorder) Resume(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockJobService)(nil).Resume), ctx, name)
+}
+
+// Retry mocks base method.
+func (m *MockJobService) Retry(ctx context.Context, j domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, j)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockJobServiceMockRecorder) Retry(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockJobService)(nil).Retry), ctx, j)
+}
+
+// ScheduleOnce mocks base method.
+func (m *MockJobService) ScheduleOnce(ctx context.Context, j domain.Job, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleOnce", ctx, j, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleOnce indicates an expected call of ScheduleOnce.
+func (mr *MockJobServiceMockRecorder) ScheduleOnce(ctx, j, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleOnce", reflect.TypeOf((*MockJobService)(nil).ScheduleOnce), ctx, j, at)
+}
+
+// StartExecution mocks base method.
+func (m *MockJobService) StartExecution(ctx context.Context, j domain.Job) (domain.JobExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartExecution", ctx, j)
+	ret0, _ := ret[0].(domain.JobExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartExecution indicates an expected call of StartExecution.
+func (mr *MockJobServiceMockRecorder) StartExecution(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExecution", reflect.TypeOf((*MockJobService)(nil).StartExecution), ctx, j)
+}
+
+// Trigger mocks base method.
+func (m *MockJobService) Trigger(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trigger", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trigger indicates an expected call of Trigger.
+func (mr *MockJobServiceMockRecorder) Trigger(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockJobService)(nil).Trigger), ctx, name)
+}
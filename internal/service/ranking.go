@@ -3,7 +3,8 @@ package service
 import (
 	"context"
 	"errors"
-	"math"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ecodeclub/ekit/slice"
@@ -14,36 +15,210 @@ import (
 	"github.com/misakimei123/redbook/pkg/logger"
 )
 
+var (
+	ErrBoardNotFound           = errors.New("ranking board not found")
+	ErrRankingSnapshotNotFound = repository.ErrRankingSnapshotNotFound
+)
+
 type RankingService interface {
 	GetTopN(ctx context.Context) ([]domain.Article, error)
+	GetTopNByTag(ctx context.Context, tag string) ([]domain.Article, error)
+	// GetBoard 没有配置这个榜单返回 ErrBoardNotFound
+	GetBoard(ctx context.Context, board string) ([]domain.Article, error)
+	// GetBoardHistory 返回榜单某一天最后一次计算的结果
+	GetBoardHistory(ctx context.Context, board string, date time.Time) (domain.RankingSnapshot, error)
 	Rank(ctx context.Context) error
 }
 
+// RankingConfig 榜单的配置，可以热更新
+type RankingConfig struct {
+	N int `yaml:"n"`
+	// Before 只看这段时间内发表的文章
+	Before time.Duration `yaml:"before"`
+	Scorer ScorerConfig  `yaml:"scorer"`
+	// Boards 没有配置就用 defaultBoards
+	Boards []BoardConfig `yaml:"boards"`
+}
+
+// BoardConfig 一个命名的榜单，每次计算都会保存当天的快照
+type BoardConfig struct {
+	Name string `yaml:"name"`
+	// Window 必须大于 0 并且不超过 maxBoardWindow，每次计算都要把窗口里的文章扫一遍
+	Window time.Duration `yaml:"window"`
+	N      int           `yaml:"n"`
+	// AllTime 总榜，不配 Window。不能每次都把所有文章扫一遍，
+	// 所以在上一次的结果上增量更新，打分不做时间衰减，见 allTimeScorer
+	AllTime bool `yaml:"allTime"`
+}
+
+var defaultBoards = []BoardConfig{
+	{Name: "daily", Window: time.Hour * 24, N: 10},
+	{Name: "weekly", Window: time.Hour * 24 * 7, N: 10},
+	{Name: "all", AllTime: true, N: 10},
+}
+
+const maxBoardWindow = time.Hour * 24 * 30
+
 type ArticleRankingService struct {
 	repo      repository.RankingRepository
 	artSvc    ArticleService
 	intraSvc  intrv1.InteractiveServiceClient
-	before    time.Duration
-	n         int
 	l         logger.LoggerV1
 	batchSize int
 	bizStr    string
-	scoreFunc func(likeCnt int64, utime time.Time) float64
+
+	// mu 保护下面几个可以热更新的字段
+	mu     sync.RWMutex
+	before time.Duration
+	n      int
+	scorer Scorer
+	// allTimeScorer 总榜用的打分，没有时间衰减
+	allTimeScorer Scorer
+	boards        []BoardConfig
+}
+
+// rankResult 一次遍历算出来的所有榜单
+type rankResult struct {
+	topN   []domain.Article
+	byTag  map[string][]domain.Article
+	boards map[string][]domain.Article
 }
 
 func (a *ArticleRankingService) GetTopN(ctx context.Context) ([]domain.Article, error) {
 	return a.repo.GetTopN(ctx)
 }
 
+func (a *ArticleRankingService) GetTopNByTag(ctx context.Context, tag string) ([]domain.Article, error) {
+	return a.repo.GetTopNByTag(ctx, tag)
+}
+
+func (a *ArticleRankingService) GetBoard(ctx context.Context, board string) ([]domain.Article, error) {
+	if !a.hasBoard(board) {
+		return nil, ErrBoardNotFound
+	}
+	return a.repo.GetBoard(ctx, board)
+}
+
+// GetBoardHistory 榜单下线之后历史快照还能查
+func (a *ArticleRankingService) GetBoardHistory(ctx context.Context, board string, date time.Time) (domain.RankingSnapshot, error) {
+	return a.repo.GetSnapshot(ctx, board, date)
+}
+
+func (a *ArticleRankingService) hasBoard(board string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return slice.ContainsFunc(a.boards, func(src BoardConfig) bool {
+		return src.Name == board
+	})
+}
+
 func (a *ArticleRankingService) Rank(ctx context.Context) error {
-	articles, err := a.doRanking(ctx)
+	now := time.Now()
+	res, err := a.doRanking(ctx)
 	if err != nil {
 		return err
 	}
-	err = a.repo.SetTopN(ctx, articles)
+	// 要在 SetTopN 之前，SetTopN 会把内容换成摘要
+	a.preload(ctx, res.preloadArticles())
+	err = a.repo.SetTopN(ctx, res.topN)
 	if err != nil {
 		a.l.Error("set Top n fail", logger.Error(err))
 	}
+	err = a.repo.SetTopNByTags(ctx, res.byTag)
+	if err != nil {
+		a.l.Error("set tag Top n fail", logger.Error(err))
+	}
+	if len(res.boards) > 0 {
+		err = a.repo.SetBoards(ctx, now, res.boards)
+		if err != nil {
+			a.l.Error("set boards fail", logger.Error(err))
+		}
+	}
+	return nil
+}
+
+// preloadArticles 全局榜单和各个命名榜单去重之后的文章
+func (r rankResult) preloadArticles() []domain.Article {
+	res := r.topN
+	seen := make(map[int64]struct{}, len(r.topN))
+	for _, art := range r.topN {
+		seen[art.Id] = struct{}{}
+	}
+	for _, arts := range r.boards {
+		for _, art := range arts {
+			if _, ok := seen[art.Id]; ok {
+				continue
+			}
+			seen[art.Id] = struct{}{}
+			res = append(res, art)
+		}
+	}
+	return res
+}
+
+// preload 上榜的文章马上会有大量访问，提前把文章和计数放进缓存，失败了也不影响榜单
+func (a *ArticleRankingService) preload(ctx context.Context, articles []domain.Article) {
+	if len(articles) == 0 {
+		return
+	}
+	ids := slice.Map(articles, func(idx int, src domain.Article) int64 {
+		return src.Id
+	})
+	err := a.artSvc.PreloadPubs(ctx, ids)
+	if err != nil {
+		a.l.Error("preload articles fail", logger.Error(err))
+	}
+	_, err = a.intraSvc.Preload(ctx, &intrv1.PreloadRequest{
+		BizStr: a.bizStr,
+		Ids:    ids,
+	})
+	if err != nil {
+		a.l.Error("preload interactives fail", logger.Error(err))
+	}
+}
+
+// UpdateConfig 下一次计算榜单的时候生效，配置不对就返回错误，继续用原来的
+func (a *ArticleRankingService) UpdateConfig(cfg RankingConfig) error {
+	if cfg.N <= 0 || cfg.Before <= 0 {
+		return fmt.Errorf("榜单配置不对 n: %d, before: %s", cfg.N, cfg.Before)
+	}
+	scorer, err := NewScorer(cfg.Scorer)
+	if err != nil {
+		return err
+	}
+	boards := cfg.Boards
+	if len(boards) == 0 {
+		boards = defaultBoards
+	}
+	err = checkBoards(boards)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.n = cfg.N
+	a.before = cfg.Before
+	a.scorer = scorer
+	a.allTimeScorer = newAllTimeScorer(cfg.Scorer)
+	a.boards = boards
+	return nil
+}
+
+func checkBoards(boards []BoardConfig) error {
+	names := make(map[string]struct{}, len(boards))
+	for _, b := range boards {
+		invalidWindow := b.Window <= 0 || b.Window > maxBoardWindow
+		if b.AllTime {
+			invalidWindow = b.Window != 0
+		}
+		if b.Name == "" || b.N <= 0 || invalidWindow {
+			return fmt.Errorf("榜单配置不对 name: %s, n: %d, window: %s", b.Name, b.N, b.Window)
+		}
+		if _, ok := names[b.Name]; ok {
+			return fmt.Errorf("榜单名字重复 %s", b.Name)
+		}
+		names[b.Name] = struct{}{}
+	}
 	return nil
 }
 
@@ -51,33 +226,82 @@ func NewArticleRankingService(repo repository.RankingRepository,
 	artSvc ArticleService,
 	intraSvc intrv1.InteractiveServiceClient,
 	l logger.LoggerV1) RankingService {
+	return NewArticleRankingServiceV1(repo, artSvc, intraSvc, l)
+}
+
+// NewArticleRankingServiceV1 返回具体类型，方便调用 UpdateConfig
+func NewArticleRankingServiceV1(repo repository.RankingRepository,
+	artSvc ArticleService,
+	intraSvc intrv1.InteractiveServiceClient,
+	l logger.LoggerV1) *ArticleRankingService {
 	return &ArticleRankingService{
-		repo:      repo,
-		artSvc:    artSvc,
-		intraSvc:  intraSvc,
-		before:    time.Hour * 24 * 7,
-		n:         10,
-		l:         l,
-		batchSize: 50,
-		bizStr:    "article",
-		scoreFunc: func(likeCnt int64, utime time.Time) float64 {
-			// 时间
-			duration := time.Since(utime).Seconds()
-			return float64(likeCnt-1) / math.Pow(duration+2, 1.5)
-		},
-	}
-}
-
-func (a *ArticleRankingService) doRanking(ctx context.Context) ([]domain.Article, error) {
+		repo:          repo,
+		artSvc:        artSvc,
+		intraSvc:      intraSvc,
+		before:        time.Hour * 24 * 7,
+		n:             10,
+		l:             l,
+		batchSize:     50,
+		bizStr:        "article",
+		scorer:        HackerNewsScorer{Gravity: defaultGravity},
+		allTimeScorer: newAllTimeScorer(ScorerConfig{}),
+		boards:        defaultBoards,
+	}
+}
+
+// newAllTimeScorer 沿用配置里面的权重，没有配就用默认的，不管配的是哪个算法都不做时间衰减
+func newAllTimeScorer(cfg ScorerConfig) Scorer {
+	if cfg.ReadWeight <= 0 && cfg.LikeWeight <= 0 && cfg.CollectWeight <= 0 {
+		return WeightedScorer{ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2}
+	}
+	return WeightedScorer{
+		ReadWeight:    cfg.ReadWeight,
+		LikeWeight:    cfg.LikeWeight,
+		CollectWeight: cfg.CollectWeight,
+	}
+}
+
+// boardHeap 每个命名榜单只收自己时间窗口内的文章，总榜收遍历到的所有文章
+type boardHeap struct {
+	name    string
+	start   time.Time
+	allTime bool
+	heap    heap.MinHeap[domain.Article]
+}
+
+// doRanking 一次遍历同时算出全局的、每个标签的和每个命名榜单的 top N，遍历的范围取所有窗口里最大的
+func (a *ArticleRankingService) doRanking(ctx context.Context) (rankResult, error) {
 	a.l.Info("start ranking")
+	a.mu.RLock()
+	n, before, scorer, allTimeScorer, boards := a.n, a.before, a.scorer, a.allTimeScorer, a.boards
+	a.mu.RUnlock()
 	now := time.Now()
-	start := now.Add(-1 * a.before)
-	minHeap := heap.NewLocalMinHeap[domain.Article](a.n)
-	offset := 0
+	start := now.Add(-1 * before)
+	scanStart := start
+	boardHeaps := make([]boardHeap, 0, len(boards))
+	hasAllTime := false
+	for _, b := range boards {
+		bh := boardHeap{name: b.Name, allTime: b.AllTime, heap: heap.NewLocalMinHeap[domain.Article](b.N)}
+		if b.AllTime {
+			hasAllTime = true
+			boardHeaps = append(boardHeaps, bh)
+			continue
+		}
+		bh.start = now.Add(-1 * b.Window)
+		if bh.start.Before(scanStart) {
+			scanStart = bh.start
+		}
+		boardHeaps = append(boardHeaps, bh)
+	}
+	// scanned 这一次遍历到的文章，总榜不用再从上一次的结果里面补
+	scanned := make(map[int64]struct{})
+	minHeap := heap.NewLocalMinHeap[domain.Article](n)
+	tagHeaps := make(map[string]heap.MinHeap[domain.Article])
+	var cursor domain.Cursor
 	for {
-		arts, err := a.artSvc.ListPub(ctx, start, now, offset, a.batchSize)
+		arts, err := a.artSvc.ListPub(ctx, scanStart, now, cursor, a.batchSize)
 		if err != nil {
-			return nil, err
+			return rankResult{}, err
 		}
 
 		ids := slice.Map(arts, func(idx int, art domain.Article) int64 {
@@ -89,7 +313,7 @@ func (a *ArticleRankingService) doRanking(ctx context.Context) ([]domain.Article
 		})
 
 		if err != nil {
-			return nil, err
+			return rankResult{}, err
 		}
 		intrasMap := resp.Interacs
 
@@ -98,36 +322,128 @@ func (a *ArticleRankingService) doRanking(ctx context.Context) ([]domain.Article
 			if intra == nil {
 				continue
 			}
-			artScore := a.scoreFunc(intra.LikeCnt, art.Utime)
-			er := minHeap.Push(art, artScore)
-			if errors.Is(er, heap.ErrHeapFull) {
-				articleMin, scoreMin, _ := minHeap.Pop()
-				var pushErr error
-				if scoreMin < artScore {
-					pushErr = minHeap.Push(art, artScore)
-				} else {
-					pushErr = minHeap.Push(articleMin, scoreMin)
-				}
-				if pushErr != nil {
+			factors := ScoreFactors{
+				ReadCnt:    intra.ReadCnt,
+				LikeCnt:    intra.LikeCnt,
+				CollectCnt: intra.CollectCnt,
+				Utime:      art.Utime,
+			}
+			artScore := scorer.Score(factors)
+			if hasAllTime {
+				scanned[art.Id] = struct{}{}
+			}
+			for _, bh := range boardHeaps {
+				if bh.allTime {
+					pushTopN(bh.heap, art, allTimeScorer.Score(factors))
 					continue
 				}
+				if !art.Utime.Before(bh.start) {
+					pushTopN(bh.heap, art, artScore)
+				}
+			}
+			if art.Utime.Before(start) {
+				continue
+			}
+			pushTopN(minHeap, art, artScore)
+			for _, tag := range art.Tags {
+				tagHeap, ok := tagHeaps[tag]
+				if !ok {
+					tagHeap = heap.NewLocalMinHeap[domain.Article](n)
+					tagHeaps[tag] = tagHeap
+				}
+				pushTopN(tagHeap, art, artScore)
 			}
 		}
 
-		offset = offset + len(arts)
-
 		if len(arts) < a.batchSize {
 			break
 		}
+		cursor = arts[len(arts)-1].Cursor()
+	}
+	res := rankResult{
+		topN:   drainTopN(minHeap),
+		byTag:  make(map[string][]domain.Article, len(tagHeaps)),
+		boards: make(map[string][]domain.Article, len(boardHeaps)),
+	}
+	for tag, tagHeap := range tagHeaps {
+		res.byTag[tag] = drainTopN(tagHeap)
+	}
+	for _, bh := range boardHeaps {
+		if bh.allTime {
+			err := a.mergeLastAllTime(ctx, bh, scanned, allTimeScorer)
+			if err != nil {
+				// 拿不到上一次的结果，这一次就不更新，免得总榜只剩下最近的文章
+				a.l.Error("merge all time board fail", logger.String("board", bh.name), logger.Error(err))
+				continue
+			}
+		}
+		res.boards[bh.name] = drainTopN(bh.heap)
+	}
+	return res, nil
+}
+
+// mergeLastAllTime 把上一次总榜上的文章按最新的计数重新打分，和这一次遍历到的一起排。
+// 总榜每次只看遍历范围内的新文章，老文章只能靠之前已经上榜留下来
+func (a *ArticleRankingService) mergeLastAllTime(ctx context.Context, bh boardHeap,
+	scanned map[int64]struct{}, allTimeScorer Scorer) error {
+	last, err := a.repo.GetBoard(ctx, bh.name)
+	if err != nil {
+		return err
+	}
+	last = slice.FilterMap(last, func(idx int, src domain.Article) (domain.Article, bool) {
+		_, ok := scanned[src.Id]
+		return src, !ok
+	})
+	if len(last) == 0 {
+		return nil
 	}
+	resp, err := a.intraSvc.GetByIds(ctx, &intrv1.GetByIdsRequest{
+		BizStr: a.bizStr,
+		Ids: slice.Map(last, func(idx int, src domain.Article) int64 {
+			return src.Id
+		}),
+	})
+	if err != nil {
+		return err
+	}
+	for _, art := range last {
+		intra := resp.Interacs[art.Id]
+		if intra == nil {
+			continue
+		}
+		pushTopN(bh.heap, art, allTimeScorer.Score(ScoreFactors{
+			ReadCnt:    intra.ReadCnt,
+			LikeCnt:    intra.LikeCnt,
+			CollectCnt: intra.CollectCnt,
+			Utime:      art.Utime,
+		}))
+	}
+	return nil
+}
+
+// pushTopN 堆满了就和堆顶比较，留下分数高的
+func pushTopN(minHeap heap.MinHeap[domain.Article], art domain.Article, artScore float64) {
+	er := minHeap.Push(art, artScore)
+	if errors.Is(er, heap.ErrHeapFull) {
+		articleMin, scoreMin, _ := minHeap.Pop()
+		if scoreMin < artScore {
+			_ = minHeap.Push(art, artScore)
+		} else {
+			_ = minHeap.Push(articleMin, scoreMin)
+		}
+	}
+}
+
+// drainTopN 按分数从高到低返回
+func drainTopN(minHeap heap.MinHeap[domain.Article]) []domain.Article {
 	length := minHeap.Len()
 	res := make([]domain.Article, length)
 	for i := length - 1; i >= 0; i-- {
 		article, _, err := minHeap.Pop()
 		if err != nil {
-			return res, nil
+			return res
 		}
 		res[i] = article
 	}
-	return res, nil
+	return res
 }
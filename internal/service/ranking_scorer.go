@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	ScorerHackerNews = "hacker_news"
+	ScorerRedditHot  = "reddit_hot"
+	ScorerWeighted   = "weighted"
+	ScorerWilson     = "wilson"
+)
+
+// ScoreFactors 打分用到的数据
+type ScoreFactors struct {
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+	Utime      time.Time
+}
+
+// Scorer 给文章打分，分数越高越靠前
+type Scorer interface {
+	Score(f ScoreFactors) float64
+}
+
+type ScorerFunc func(f ScoreFactors) float64
+
+func (s ScorerFunc) Score(f ScoreFactors) float64 {
+	return s(f)
+}
+
+// ScorerConfig 不同算法用到的参数不一样，没用到的不用配
+type ScorerConfig struct {
+	Name string `yaml:"name"`
+	// Gravity 时间衰减，hacker_news 和 weighted 用，hacker_news 没配就是 defaultGravity
+	Gravity       float64 `yaml:"gravity"`
+	ReadWeight    float64 `yaml:"readWeight"`
+	LikeWeight    float64 `yaml:"likeWeight"`
+	CollectWeight float64 `yaml:"collectWeight"`
+	// Z wilson 的置信度，默认 1.96 也就是 95%
+	Z float64 `yaml:"z"`
+}
+
+func NewScorer(cfg ScorerConfig) (Scorer, error) {
+	switch cfg.Name {
+	case ScorerHackerNews, "":
+		gravity := cfg.Gravity
+		if gravity <= 0 {
+			gravity = defaultGravity
+		}
+		return HackerNewsScorer{Gravity: gravity}, nil
+	case ScorerRedditHot:
+		return RedditHotScorer{}, nil
+	case ScorerWeighted:
+		return WeightedScorer{
+			ReadWeight:    cfg.ReadWeight,
+			LikeWeight:    cfg.LikeWeight,
+			CollectWeight: cfg.CollectWeight,
+			Gravity:       cfg.Gravity,
+		}, nil
+	case ScorerWilson:
+		z := cfg.Z
+		if z <= 0 {
+			z = 1.96
+		}
+		return WilsonScorer{Z: z}, nil
+	default:
+		return nil, fmt.Errorf("未知的打分算法 %s", cfg.Name)
+	}
+}
+
+// defaultGravity 和最早写死的算法保持一致
+const defaultGravity = 1.5
+
+// HackerNewsScorer (点赞数 - 1) / (秒数 + 2) ^ gravity，时间单位沿用最早写死的算法，用的是秒不是小时
+type HackerNewsScorer struct {
+	Gravity float64
+}
+
+func (h HackerNewsScorer) Score(f ScoreFactors) float64 {
+	seconds := time.Since(f.Utime).Seconds()
+	return float64(f.LikeCnt-1) / math.Pow(seconds+2, h.Gravity)
+}
+
+// redditEpoch reddit 算法的起始时间
+var redditEpoch = time.Unix(1134028003, 0)
+
+// RedditHotScorer log10(点赞数) + 秒数 / 45000，没有踩，所以符号总是正的
+type RedditHotScorer struct{}
+
+func (r RedditHotScorer) Score(f ScoreFactors) float64 {
+	order := math.Log10(math.Max(float64(f.LikeCnt), 1))
+	return order + f.Utime.Sub(redditEpoch).Seconds()/45000
+}
+
+// WeightedScorer 阅读、点赞、收藏加权求和，Gravity 大于 0 的时候按 hacker news 的方式衰减
+type WeightedScorer struct {
+	ReadWeight    float64
+	LikeWeight    float64
+	CollectWeight float64
+	Gravity       float64
+}
+
+func (w WeightedScorer) Score(f ScoreFactors) float64 {
+	score := w.ReadWeight*float64(f.ReadCnt) + w.LikeWeight*float64(f.LikeCnt) + w.CollectWeight*float64(f.CollectCnt)
+	if w.Gravity <= 0 {
+		return score
+	}
+	seconds := time.Since(f.Utime).Seconds()
+	return score / math.Pow(seconds+2, w.Gravity)
+}
+
+// WilsonScorer 把点赞和收藏当成好评，阅读当成总数，取 wilson 区间的下界，阅读少的文章不会因为偶然的好评排到前面
+type WilsonScorer struct {
+	Z float64
+}
+
+func (w WilsonScorer) Score(f ScoreFactors) float64 {
+	n := float64(f.ReadCnt)
+	if n <= 0 {
+		return 0
+	}
+	p := math.Min(float64(f.LikeCnt+f.CollectCnt)/n, 1)
+	z2 := w.Z * w.Z
+	return (p + z2/(2*n) - w.Z*math.Sqrt((p*(1-p)+z2/(4*n))/n)) / (1 + z2/n)
+}
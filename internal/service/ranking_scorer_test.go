@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScorer(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cfg        ScorerConfig
+		wantScorer Scorer
+		wantErr    error
+	}{
+		{
+			name:       "default hacker news",
+			wantScorer: HackerNewsScorer{Gravity: 1.5},
+		},
+		{
+			name:       "reddit hot",
+			cfg:        ScorerConfig{Name: ScorerRedditHot},
+			wantScorer: RedditHotScorer{},
+		},
+		{
+			name: "weighted",
+			cfg:  ScorerConfig{Name: ScorerWeighted, ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2},
+			wantScorer: WeightedScorer{
+				ReadWeight:    0.1,
+				LikeWeight:    1,
+				CollectWeight: 2,
+			},
+		},
+		{
+			name:       "wilson",
+			cfg:        ScorerConfig{Name: ScorerWilson},
+			wantScorer: WilsonScorer{Z: 1.96},
+		},
+		{
+			name:    "unknown",
+			cfg:     ScorerConfig{Name: "unknown"},
+			wantErr: errors.New("未知的打分算法 unknown"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scorer, err := NewScorer(tc.cfg)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantScorer, scorer)
+		})
+	}
+}
+
+// 每种算法只验证排序关系，不验证具体的分数
+func TestScorer_Score(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name   string
+		scorer Scorer
+		high   ScoreFactors
+		low    ScoreFactors
+	}{
+		{
+			name:   "hacker news, newer first",
+			scorer: HackerNewsScorer{Gravity: 1.5},
+			high:   ScoreFactors{LikeCnt: 10, Utime: now},
+			low:    ScoreFactors{LikeCnt: 10, Utime: now.Add(-time.Hour * 24)},
+		},
+		{
+			name:   "hacker news, more likes first",
+			scorer: HackerNewsScorer{Gravity: 1.5},
+			high:   ScoreFactors{LikeCnt: 20, Utime: now},
+			low:    ScoreFactors{LikeCnt: 10, Utime: now},
+		},
+		{
+			name:   "reddit hot, 12.5 hours worth 10 times likes",
+			scorer: RedditHotScorer{},
+			high:   ScoreFactors{LikeCnt: 10, Utime: now},
+			low:    ScoreFactors{LikeCnt: 90, Utime: now.Add(-time.Hour * 13)},
+		},
+		{
+			name:   "weighted, collect counts more",
+			scorer: WeightedScorer{ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2},
+			high:   ScoreFactors{ReadCnt: 100, LikeCnt: 5, CollectCnt: 5, Utime: now},
+			low:    ScoreFactors{ReadCnt: 100, LikeCnt: 10, Utime: now},
+		},
+		{
+			name:   "weighted with gravity, newer first",
+			scorer: WeightedScorer{LikeWeight: 1, Gravity: 1.5},
+			high:   ScoreFactors{LikeCnt: 10, Utime: now},
+			low:    ScoreFactors{LikeCnt: 10, Utime: now.Add(-time.Hour)},
+		},
+		{
+			name:   "wilson, more reads more confident",
+			scorer: WilsonScorer{Z: 1.96},
+			high:   ScoreFactors{ReadCnt: 1000, LikeCnt: 500},
+			low:    ScoreFactors{ReadCnt: 2, LikeCnt: 1},
+		},
+		{
+			name:   "wilson, no read",
+			scorer: WilsonScorer{Z: 1.96},
+			high:   ScoreFactors{ReadCnt: 1, LikeCnt: 1},
+			low:    ScoreFactors{LikeCnt: 10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Greater(t, tc.scorer.Score(tc.high), tc.scorer.Score(tc.low))
+		})
+	}
+}
+
+func TestArticleRankingService_UpdateConfig(t *testing.T) {
+	svc := NewArticleRankingServiceV1(nil, nil, nil, nil)
+	err := svc.UpdateConfig(RankingConfig{N: 100, Before: time.Hour, Scorer: ScorerConfig{Name: ScorerRedditHot}})
+	assert.NoError(t, err)
+	assert.Equal(t, 100, svc.n)
+	assert.Equal(t, time.Hour, svc.before)
+	assert.Equal(t, RedditHotScorer{}, svc.scorer)
+	// 总榜不做时间衰减，没配权重用默认的
+	assert.Equal(t, WeightedScorer{ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2}, svc.allTimeScorer)
+
+	// 配置不对的时候保留原来的
+	err = svc.UpdateConfig(RankingConfig{N: 10, Before: time.Hour, Scorer: ScorerConfig{Name: "unknown"}})
+	assert.Error(t, err)
+	err = svc.UpdateConfig(RankingConfig{N: 0, Before: time.Hour})
+	assert.Error(t, err)
+	// 总榜不能配窗口
+	err = svc.UpdateConfig(RankingConfig{N: 10, Before: time.Hour, Boards: []BoardConfig{
+		{Name: "all", AllTime: true, Window: time.Hour, N: 10},
+	}})
+	assert.Error(t, err)
+	assert.Equal(t, 100, svc.n)
+	assert.Equal(t, RedditHotScorer{}, svc.scorer)
+}
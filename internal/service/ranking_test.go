@@ -5,8 +5,9 @@ import (
 	"testing"
 	"time"
 
+	intrv1 "github.com/misakimei123/redbook/api/proto/gen/intr/v1"
 	domain2 "github.com/misakimei123/redbook/interactive/domain"
-	"github.com/misakimei123/redbook/interactive/service"
+	"github.com/misakimei123/redbook/internal/client"
 	"github.com/misakimei123/redbook/internal/domain"
 	"github.com/misakimei123/redbook/internal/repository"
 	repomocks "github.com/misakimei123/redbook/internal/repository/mocks"
@@ -21,51 +22,63 @@ import (
 
 func TestArticleRankingService_DoRanking(t *testing.T) {
 	const batchSize = 2
+	utime := time.UnixMilli(time.Now().Add(-time.Hour).UnixMilli())
 	testCases := []struct {
-		name     string
-		mock     func(controller *gomock.Controller) (ArticleService, service.InteractiveService, repository.RankingRepository)
-		wantArts []domain.Article
-		wantErr  error
+		name      string
+		mock      func(controller *gomock.Controller) (ArticleService, intrv1.InteractiveServiceClient, repository.RankingRepository)
+		wantArts  []domain.Article
+		wantByTag map[string][]domain.Article
+		wantErr   error
 	}{
 		{
 			name: "success generate",
-			mock: func(ctrl *gomock.Controller) (ArticleService, service.InteractiveService, repository.RankingRepository) {
+			mock: func(ctrl *gomock.Controller) (ArticleService, intrv1.InteractiveServiceClient, repository.RankingRepository) {
 				mockArticleService := svcmocks.NewMockArticleService(ctrl)
 				mockInteractiveService := svcmocks.NewMockInteractiveService(ctrl)
 				mockRankingRepository := repomocks.NewMockRankingRepository(ctrl)
 
-				mockArticleService.EXPECT().ListPub(gomock.Any(), gomock.Any(), gomock.Any(), 0, 2).
+				mockArticleService.EXPECT().ListPub(gomock.Any(), gomock.Any(), gomock.Any(), domain.Cursor{}, 2).
 					Return([]domain.Article{
-						{Id: 1},
-						{Id: 2},
+						{Id: 1, Utime: utime, Tags: []string{"go"}},
+						{Id: 2, Utime: utime, Tags: []string{"go", "redis"}},
 					}, nil)
-				mockArticleService.EXPECT().ListPub(gomock.Any(), gomock.Any(), gomock.Any(), 2, 2).
+				mockArticleService.EXPECT().ListPub(gomock.Any(), gomock.Any(), gomock.Any(), domain.Cursor{Utime: utime.UnixMilli(), Id: 2}, 2).
 					Return([]domain.Article{
-						{Id: 3},
-						{Id: 4},
+						{Id: 3, Utime: utime, Tags: []string{"go"}},
+						{Id: 4, Utime: utime},
 					}, nil)
-				mockArticleService.EXPECT().ListPub(gomock.Any(), gomock.Any(), gomock.Any(), 4, 2).
+				mockArticleService.EXPECT().ListPub(gomock.Any(), gomock.Any(), gomock.Any(), domain.Cursor{Utime: utime.UnixMilli(), Id: 4}, 2).
 					Return([]domain.Article{}, nil)
 
-				mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}).
+				mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}, int64(0)).
 					Return(map[int64]domain2.Interactive{
 						1: {LikeCnt: 1},
 						2: {LikeCnt: 2},
 					}, nil)
-				mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{3, 4}).
+				mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{3, 4}, int64(0)).
 					Return(map[int64]domain2.Interactive{
 						3: {LikeCnt: 3},
 						4: {LikeCnt: 4},
 					}, nil)
-				mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{}).
+				mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{}, int64(0)).
 					Return(map[int64]domain2.Interactive{}, nil)
 
-				return mockArticleService, mockInteractiveService, mockRankingRepository
+				return mockArticleService, client.NewLocalInteractiveServiceAdapter(mockInteractiveService), mockRankingRepository
 			},
 			wantArts: []domain.Article{
-				{Id: 4},
-				{Id: 3},
-				{Id: 2},
+				{Id: 4, Utime: utime},
+				{Id: 3, Utime: utime, Tags: []string{"go"}},
+				{Id: 2, Utime: utime, Tags: []string{"go", "redis"}},
+			},
+			wantByTag: map[string][]domain.Article{
+				"go": {
+					{Id: 3, Utime: utime, Tags: []string{"go"}},
+					{Id: 2, Utime: utime, Tags: []string{"go", "redis"}},
+					{Id: 1, Utime: utime, Tags: []string{"go"}},
+				},
+				"redis": {
+					{Id: 2, Utime: utime, Tags: []string{"go", "redis"}},
+				},
 			},
 			wantErr: nil,
 		},
@@ -83,18 +96,132 @@ func TestArticleRankingService_DoRanking(t *testing.T) {
 				l:         InitialLogger(),
 				batchSize: batchSize,
 				bizStr:    "article",
-				scoreFunc: func(likeCnt int64, utime time.Time) float64 {
-					return float64(likeCnt)
-				},
+				scorer: ScorerFunc(func(f ScoreFactors) float64 {
+					return float64(f.LikeCnt)
+				}),
 			}
-			articles, err := rankingSvc.doRanking(context.Background())
-			assert.Equal(t, tc.wantArts, articles)
+			res, err := rankingSvc.doRanking(context.Background())
+			assert.Equal(t, tc.wantArts, res.topN)
+			assert.Equal(t, tc.wantByTag, res.byTag)
 			assert.Equal(t, tc.wantErr, err)
 		})
 	}
 
 }
 
+// 算完榜单之后把上榜的文章和计数预加载到缓存
+func TestArticleRankingService_Rank(t *testing.T) {
+	utime := time.UnixMilli(time.Now().Add(-time.Hour).UnixMilli())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleService := svcmocks.NewMockArticleService(ctrl)
+	mockInteractiveService := svcmocks.NewMockInteractiveService(ctrl)
+	mockRankingRepository := repomocks.NewMockRankingRepository(ctrl)
+
+	arts := []domain.Article{
+		{Id: 1, Content: "content 1", Utime: utime, Tags: []string{"go"}},
+		{Id: 2, Content: "content 2", Utime: utime},
+	}
+	mockArticleService.EXPECT().ListPub(gomock.Any(), gomock.Any(), gomock.Any(), domain.Cursor{}, 3).
+		Return(arts, nil)
+	mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}, int64(0)).
+		Return(map[int64]domain2.Interactive{
+			1: {LikeCnt: 1},
+			2: {LikeCnt: 2},
+		}, nil)
+	wantArts := []domain.Article{arts[1], arts[0]}
+	mockArticleService.EXPECT().PreloadPubs(gomock.Any(), []int64{2, 1}).Return(nil)
+	mockInteractiveService.EXPECT().Preload(gomock.Any(), "article", []int64{2, 1}).Return(nil)
+	mockRankingRepository.EXPECT().SetTopN(gomock.Any(), wantArts).Return(nil)
+	mockRankingRepository.EXPECT().SetTopNByTags(gomock.Any(), map[string][]domain.Article{
+		"go": {arts[0]},
+	}).Return(nil)
+
+	rankingSvc := &ArticleRankingService{repo: mockRankingRepository,
+		artSvc:    mockArticleService,
+		intraSvc:  client.NewLocalInteractiveServiceAdapter(mockInteractiveService),
+		before:    7 * 24 * time.Hour,
+		n:         3,
+		l:         InitialLogger(),
+		batchSize: 3,
+		bizStr:    "article",
+		scorer: ScorerFunc(func(f ScoreFactors) float64 {
+			return float64(f.LikeCnt)
+		}),
+	}
+	err := rankingSvc.Rank(context.Background())
+	assert.NoError(t, err)
+}
+
+// 命名榜单只收自己窗口内的文章，总榜在上一次的结果上增量更新，最后保存快照
+func TestArticleRankingService_RankBoards(t *testing.T) {
+	now := time.Now()
+	today := now.Add(-time.Hour)
+	lastMonth := now.Add(-30 * 24 * time.Hour)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleService := svcmocks.NewMockArticleService(ctrl)
+	mockInteractiveService := svcmocks.NewMockInteractiveService(ctrl)
+	mockRankingRepository := repomocks.NewMockRankingRepository(ctrl)
+
+	arts := []domain.Article{
+		{Id: 1, Utime: today},
+		{Id: 2, Utime: lastMonth},
+		{Id: 3, Utime: today},
+	}
+	// 上一次的总榜，4 已经不在遍历范围里面了，3 这一次遍历到了不用再补
+	lastAll := []domain.Article{{Id: 4, Utime: lastMonth}, arts[2]}
+	mockRankingRepository.EXPECT().GetBoard(gomock.Any(), "all").Return(lastAll, nil)
+	mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{4}, int64(0)).
+		Return(map[int64]domain2.Interactive{
+			4: {LikeCnt: 10},
+		}, nil)
+	mockArticleService.EXPECT().ListPub(gomock.Any(), gomock.Any(), gomock.Any(), domain.Cursor{}, 5).
+		Return(arts, nil)
+	mockInteractiveService.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2, 3}, int64(0)).
+		Return(map[int64]domain2.Interactive{
+			1: {LikeCnt: 1},
+			2: {LikeCnt: 5},
+			3: {LikeCnt: 3},
+		}, nil)
+	wantTopN := []domain.Article{arts[2], arts[0]}
+	mockArticleService.EXPECT().PreloadPubs(gomock.Any(), []int64{3, 1, 4, 2}).Return(nil)
+	mockInteractiveService.EXPECT().Preload(gomock.Any(), "article", []int64{3, 1, 4, 2}).Return(nil)
+	mockRankingRepository.EXPECT().SetTopN(gomock.Any(), wantTopN).Return(nil)
+	mockRankingRepository.EXPECT().SetTopNByTags(gomock.Any(), map[string][]domain.Article{}).Return(nil)
+	mockRankingRepository.EXPECT().SetBoards(gomock.Any(), gomock.Any(), map[string][]domain.Article{
+		"daily":  {arts[2]},
+		"weekly": {arts[2], arts[0]},
+		"all":    {lastAll[0], arts[1]},
+	}).Return(nil)
+
+	rankingSvc := &ArticleRankingService{repo: mockRankingRepository,
+		artSvc:    mockArticleService,
+		intraSvc:  client.NewLocalInteractiveServiceAdapter(mockInteractiveService),
+		before:    7 * 24 * time.Hour,
+		n:         3,
+		l:         InitialLogger(),
+		batchSize: 5,
+		bizStr:    "article",
+		scorer: ScorerFunc(func(f ScoreFactors) float64 {
+			return float64(f.LikeCnt)
+		}),
+		allTimeScorer: ScorerFunc(func(f ScoreFactors) float64 {
+			return float64(f.LikeCnt)
+		}),
+		boards: []BoardConfig{
+			{Name: "daily", Window: 24 * time.Hour, N: 1},
+			{Name: "weekly", Window: 7 * 24 * time.Hour, N: 2},
+			{Name: "all", AllTime: true, N: 2},
+		},
+	}
+	err := rankingSvc.Rank(context.Background())
+	assert.NoError(t, err)
+
+	_, err = rankingSvc.GetBoard(context.Background(), "monthly")
+	assert.Equal(t, ErrBoardNotFound, err)
+}
+
 func InitialLogger() logger.LoggerV1 {
 	cfg := zap.NewDevelopmentConfig()
 	err := viper.UnmarshalKey("log", &cfg)
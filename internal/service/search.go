@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/misakimei123/redbook/pkg/search"
+)
+
+type SearchService interface {
+	// Search 按相关度排序，返回当前页和命中总数
+	Search(ctx context.Context, query string, offset int, limit int) ([]domain.Article, int, error)
+	// Index 已发表的文章写入索引，其它状态从索引里面删掉
+	Index(ctx context.Context, article domain.Article) error
+	// Rebuild 从线上库全量加载
+	Rebuild(ctx context.Context) error
+}
+
+type articleSearchService struct {
+	artSvc    ArticleService
+	index     *search.Index[domain.Article]
+	l         logger.LoggerV1
+	batchSize int
+}
+
+func NewArticleSearchService(artSvc ArticleService, l logger.LoggerV1) SearchService {
+	return &articleSearchService{
+		artSvc:    artSvc,
+		index:     search.NewIndex[domain.Article](),
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (a *articleSearchService) Search(ctx context.Context, query string, offset int, limit int) ([]domain.Article, int, error) {
+	hits, total := a.index.Search(query, offset, limit)
+	res := make([]domain.Article, 0, len(hits))
+	for _, hit := range hits {
+		res = append(res, hit.Doc)
+	}
+	return res, total, nil
+}
+
+func (a *articleSearchService) Index(ctx context.Context, article domain.Article) error {
+	version := article.Utime.UnixMilli()
+	if article.Status != domain.ArticleStatusPublished {
+		a.index.Delete(article.Id, version)
+		return nil
+	}
+	fields := []search.Field{
+		{Text: article.Title, Weight: 3},
+		{Text: strings.Join(article.Tags, " "), Weight: 2},
+		{Text: article.Content, Weight: 1},
+	}
+	// 索引里面只留摘要，省内存
+	article.Content = article.Abstract()
+	a.index.Upsert(article.Id, version, fields, article)
+	return nil
+}
+
+func (a *articleSearchService) Rebuild(ctx context.Context) error {
+	now := time.Now()
+	var cursor domain.Cursor
+	for {
+		arts, err := a.artSvc.ListPub(ctx, time.UnixMilli(0), now, cursor, a.batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			_ = a.Index(ctx, art)
+		}
+		if len(arts) < a.batchSize {
+			break
+		}
+		cursor = arts[len(arts)-1].Cursor()
+	}
+	a.l.Info("search index rebuilt", logger.Int64("docs", int64(a.index.Len())))
+	return nil
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -14,8 +15,12 @@ import (
 	"github.com/misakimei123/redbook/internal/web/result"
 	"github.com/misakimei123/redbook/pkg/logger"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const maxPageSize = 100
+
 type ArticleHandler struct {
 	svc            service.ArticleService
 	interactiveSvc intrv1.InteractiveServiceClient //service2.InteractiveService
@@ -39,21 +44,32 @@ func (a *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	group.POST("edit", a.Edit)
 	group.POST("publish", a.Publish)
 	group.POST("withdraw", a.Withdraw)
+	group.POST("schedule", a.SchedulePublish)
+	group.POST("reschedule", a.ReschedulePublish)
+	group.POST("schedule/cancel", a.CancelSchedulePublish)
 	group.POST("list", a.List)
 	group.GET("detail/:id", a.Detail)
 	group.GET("rank", a.Rank)
+	group.GET("rank/:board", a.RankBoard)
+	group.GET("rank/:board/history", a.RankBoardHistory)
+	group.GET(":id/revisions", a.Revisions)
+	group.GET(":id/revisions/diff", a.DiffRevisions)
+	group.POST(":id/revisions/:rid/restore", a.RestoreRevision)
 
 	pub := server.Group("/pub")
 	pub.GET("/:id", a.PubDetail)
+	pub.GET("/tags/:tag", a.ListByTag)
 	pub.POST("/like", a.Like)
 	pub.POST("/collect", a.Collect)
+	pub.POST("/uncollect", a.Uncollect)
 }
 
 func (a *ArticleHandler) Edit(ctx *gin.Context) {
 	type Req struct {
-		Id      int64  `json:"id"`
-		Title   string `json:"title"`
-		Content string `json:"content"`
+		Id      int64    `json:"id"`
+		Title   string   `json:"title"`
+		Content string   `json:"content"`
+		Tags    []string `json:"tags"`
 	}
 	var req Req
 	err := ctx.Bind(&req)
@@ -61,11 +77,17 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, result.RetSystemError)
 		return
 	}
+	tags, err := domain.NormalizeTags(req.Tags)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalTags)
+		return
+	}
 	uc := ctx.MustGet("user").(jwt.UserClaims)
 	id, err := a.svc.Save(ctx, domain.Article{
 		Id:      req.Id,
 		Title:   req.Title,
 		Content: req.Content,
+		Tags:    tags,
 		Author:  domain.Author{Id: uc.Uid},
 	})
 	if err != nil {
@@ -79,9 +101,10 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 
 func (a *ArticleHandler) Publish(ctx *gin.Context) {
 	type Req struct {
-		Id      int64  `json:"id"`
-		Title   string `json:"title"`
-		Content string `json:"content"`
+		Id      int64    `json:"id"`
+		Title   string   `json:"title"`
+		Content string   `json:"content"`
+		Tags    []string `json:"tags"`
 	}
 	var req Req
 	err := ctx.Bind(&req)
@@ -89,11 +112,17 @@ func (a *ArticleHandler) Publish(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, result.RetSystemError)
 		return
 	}
+	tags, err := domain.NormalizeTags(req.Tags)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalTags)
+		return
+	}
 	uc := ctx.MustGet("user").(jwt.UserClaims)
 	id, err := a.svc.Publish(ctx, domain.Article{
 		Id:      req.Id,
 		Title:   req.Title,
 		Content: req.Content,
+		Tags:    tags,
 		Author:  domain.Author{Id: uc.Uid},
 	})
 	if err != nil {
@@ -105,6 +134,101 @@ func (a *ArticleHandler) Publish(ctx *gin.Context) {
 	})
 }
 
+// SchedulePublish 保存草稿并在 publishAt（毫秒时间戳）发表
+func (a *ArticleHandler) SchedulePublish(ctx *gin.Context) {
+	type Req struct {
+		Id        int64    `json:"id"`
+		Title     string   `json:"title"`
+		Content   string   `json:"content"`
+		Tags      []string `json:"tags"`
+		PublishAt int64    `json:"publishAt"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	tags, err := domain.NormalizeTags(req.Tags)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalTags)
+		return
+	}
+	at := time.UnixMilli(req.PublishAt)
+	if !at.After(time.Now()) {
+		ctx.JSON(http.StatusOK, result.RetIllegalPublishTime)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	id, err := a.svc.SchedulePublish(ctx, domain.Article{
+		Id:      req.Id,
+		Title:   req.Title,
+		Content: req.Content,
+		Tags:    tags,
+		Author:  domain.Author{Id: uc.Uid},
+	}, at)
+	if err != nil {
+		a.l.Error("schedule publish fail", logger.Int64("aid", req.Id), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{
+		Data: id,
+	})
+}
+
+func (a *ArticleHandler) ReschedulePublish(ctx *gin.Context) {
+	type Req struct {
+		Id        int64 `json:"id"`
+		PublishAt int64 `json:"publishAt"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	at := time.UnixMilli(req.PublishAt)
+	if !at.After(time.Now()) {
+		ctx.JSON(http.StatusOK, result.RetIllegalPublishTime)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err = a.svc.ReschedulePublish(ctx, req.Id, uc.Uid, at)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, result.RetSuccess)
+	case errors.Is(err, service.ErrJobNotFound):
+		ctx.JSON(http.StatusOK, result.RetScheduleNotFound)
+	default:
+		a.l.Error("reschedule publish fail", logger.Int64("aid", req.Id), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+	}
+}
+
+func (a *ArticleHandler) CancelSchedulePublish(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err = a.svc.CancelSchedulePublish(ctx, req.Id, uc.Uid)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, result.RetSuccess)
+	case errors.Is(err, service.ErrJobNotFound):
+		ctx.JSON(http.StatusOK, result.RetScheduleNotFound)
+	default:
+		a.l.Error("cancel schedule publish fail", logger.Int64("aid", req.Id), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+	}
+}
+
 func (a *ArticleHandler) Withdraw(ctx *gin.Context) {
 	type Req struct {
 		Id int64 `json:"id"`
@@ -124,45 +248,114 @@ func (a *ArticleHandler) Withdraw(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, result.RetSuccess)
 }
 
+// Rank 带上 tag 参数就返回这个标签的榜单
 func (a *ArticleHandler) Rank(ctx *gin.Context) {
-	articles, err := a.rankingSvc.GetTopN(ctx.Request.Context())
+	var (
+		articles []domain.Article
+		err      error
+	)
+	if tag := ctx.Query("tag"); tag != "" {
+		tags, er := domain.NormalizeTags([]string{tag})
+		if er != nil || len(tags) == 0 {
+			ctx.JSON(http.StatusOK, result.RetIllegalTags)
+			return
+		}
+		articles, err = a.rankingSvc.GetTopNByTag(ctx.Request.Context(), tags[0])
+	} else {
+		articles, err = a.rankingSvc.GetTopN(ctx.Request.Context())
+	}
 	if err != nil {
 		return
 	}
+	ctx.JSON(http.StatusOK, result.Result{Data: a.toRankVos(ctx, articles)})
+}
 
-	ctx.JSON(http.StatusOK, result.Result{Data: slice.Map[domain.Article, ArticleVo](articles, func(idx int, src domain.Article) ArticleVo {
+// RankBoard 命名榜单，比如 daily、weekly 和总榜 all
+func (a *ArticleHandler) RankBoard(ctx *gin.Context) {
+	articles, err := a.rankingSvc.GetBoard(ctx.Request.Context(), ctx.Param("board"))
+	if errors.Is(err, service.ErrBoardNotFound) {
+		ctx.JSON(http.StatusOK, result.RetBoardNotFound)
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: a.toRankVos(ctx, articles)})
+}
+
+// RankBoardHistory 查询榜单某一天的快照，date 的格式是 2006-01-02
+func (a *ArticleHandler) RankBoardHistory(ctx *gin.Context) {
+	date, err := time.ParseInLocation(time.DateOnly, ctx.Query("date"), time.Local)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalDate)
+		return
+	}
+	snapshot, err := a.rankingSvc.GetBoardHistory(ctx.Request.Context(), ctx.Param("board"), date)
+	if errors.Is(err, service.ErrRankingSnapshotNotFound) {
+		ctx.JSON(http.StatusOK, result.RetRankingSnapshotNotFound)
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: a.toRankVos(ctx, snapshot.Articles)})
+}
+
+// toRankVos 榜单只返回摘要，计数一次批量查出来
+func (a *ArticleHandler) toRankVos(ctx *gin.Context, articles []domain.Article) []ArticleVo {
+	vos := slice.Map[domain.Article, ArticleVo](articles, func(idx int, src domain.Article) ArticleVo {
 		return ArticleVo{
 			Id:       src.Id,
 			Title:    src.Title,
 			Abstract: src.Abstract(),
 			Status:   src.Status.ToInt(),
+			Tags:     src.Tags,
 			Ctime:    src.Ctime.Format(time.DateTime),
 			Utime:    src.Utime.Format(time.DateTime),
 		}
-	})})
+	})
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	a.fillInteractives(ctx, uc.Uid, vos)
+	return vos
 }
 
 func (a *ArticleHandler) List(ctx *gin.Context) {
 	var page Page
 	if err := ctx.Bind(&page); err != nil {
 		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	cursor, err := domain.DecodeCursor(page.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalCursor)
+		return
+	}
+	if page.Limit <= 0 || page.Limit > maxPageSize {
+		page.Limit = maxPageSize
 	}
 	userClaims := ctx.MustGet("user").(jwt.UserClaims)
-	articles, err := a.svc.GetByAuthor(ctx, userClaims.Uid, page.Offset, page.Limit)
+	articles, err := a.svc.GetByAuthor(ctx, userClaims.Uid, cursor, page.Limit)
 	if err != nil {
 		ctx.JSON(http.StatusOK, result.RetSystemError)
 		return
 	}
-	ctx.JSON(http.StatusOK, result.Result{Data: slice.Map[domain.Article, ArticleVo](articles, func(idx int, src domain.Article) ArticleVo {
+	vo := ListVo[ArticleVo]{List: slice.Map[domain.Article, ArticleVo](articles, func(idx int, src domain.Article) ArticleVo {
 		return ArticleVo{
 			Id:       src.Id,
 			Title:    src.Title,
 			Abstract: src.Abstract(),
 			Status:   src.Status.ToInt(),
+			Tags:     src.Tags,
 			Ctime:    src.Ctime.Format(time.DateTime),
 			Utime:    src.Utime.Format(time.DateTime),
 		}
-	})})
+	})}
+	if len(articles) == page.Limit {
+		vo.Cursor = articles[len(articles)-1].Cursor().Encode()
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: vo})
 }
 
 func (a *ArticleHandler) Detail(ctx *gin.Context) {
@@ -184,6 +377,7 @@ func (a *ArticleHandler) Detail(ctx *gin.Context) {
 		Content:  article.Content,
 		AuthorId: article.Author.Id,
 		Status:   article.Status.ToInt(),
+		Tags:     article.Tags,
 		Ctime:    article.Ctime.Format(time.DateTime),
 		Utime:    article.Utime.Format(time.DateTime),
 	}})
@@ -239,6 +433,7 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 			AuthorId:   article.Author.Id,
 			AuthorName: article.Author.Name,
 			Status:     article.Status.ToInt(),
+			Tags:       article.Tags,
 			Ctime:      article.Ctime.Format(time.DateTime),
 			Utime:      article.Utime.Format(time.DateTime),
 			ReadCnt:    0,
@@ -267,11 +462,14 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 		AuthorId:   article.Author.Id,
 		AuthorName: article.Author.Name,
 		Status:     article.Status.ToInt(),
+		Tags:       article.Tags,
 		Ctime:      article.Ctime.Format(time.DateTime),
 		Utime:      article.Utime.Format(time.DateTime),
 		ReadCnt:    interactive.ReadCnt,
+		UvCnt:      interactive.UvCnt,
 		LikeCnt:    interactive.LikeCnt,
 		CollectCnt: interactive.CollectCnt,
+		CommentCnt: interactive.CommentCnt,
 		Liked:      interactive.Liked,
 		Collected:  interactive.Collected,
 	}})
@@ -320,9 +518,206 @@ func (a *ArticleHandler) Collect(ctx *gin.Context) {
 		Uid:    userClaims.Uid,
 		Cid:    req.Cid,
 	}) //a.bizStr, req.Id, req.Cid, userClaims.Uid)
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, result.RetCollectionNotFound)
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.RetSuccess)
+}
+
+func (a *ArticleHandler) Uncollect(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id,omitempty"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	userClaims := ctx.MustGet("user").(jwt.UserClaims)
+	_, err = a.interactiveSvc.Uncollect(ctx.Request.Context(), &intrv1.UncollectRequest{
+		BizStr: a.bizStr,
+		BizId:  req.Id,
+		Uid:    userClaims.Uid,
+	})
+	if err != nil {
+		a.l.Error("uncollect fail", logger.Int64("id", req.Id), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.RetSuccess)
+}
+
+func (a *ArticleHandler) Revisions(ctx *gin.Context) {
+	aid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	var page Page
+	if err = ctx.BindQuery(&page); err != nil {
+		return
+	}
+	cursor, err := domain.DecodeCursor(page.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalCursor)
+		return
+	}
+	if page.Limit <= 0 || page.Limit > maxPageSize {
+		page.Limit = maxPageSize
+	}
+	userClaims := ctx.MustGet("user").(jwt.UserClaims)
+	revisions, err := a.svc.ListRevisions(ctx.Request.Context(), aid, userClaims.Uid, cursor, page.Limit)
+	if err != nil {
+		a.l.Error("list revisions fail", logger.Int64("aid", aid), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	vo := ListVo[RevisionVo]{List: slice.Map[domain.ArticleRevision, RevisionVo](revisions, func(idx int, src domain.ArticleRevision) RevisionVo {
+		return toRevisionVo(src)
+	})}
+	if len(revisions) == page.Limit {
+		vo.Cursor = revisions[len(revisions)-1].Cursor().Encode()
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: vo})
+}
+
+func (a *ArticleHandler) DiffRevisions(ctx *gin.Context) {
+	aid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	from, err := strconv.ParseInt(ctx.Query("from"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	to, err := strconv.ParseInt(ctx.Query("to"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	userClaims := ctx.MustGet("user").(jwt.UserClaims)
+	diff, err := a.svc.DiffRevisions(ctx.Request.Context(), aid, userClaims.Uid, from, to)
+	if errors.Is(err, service.ErrRevisionNotFound) {
+		ctx.JSON(http.StatusOK, result.RetRevisionNotFound)
+		return
+	}
+	if err != nil {
+		a.l.Error("diff revisions fail", logger.Int64("aid", aid), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: RevisionDiffVo{
+		From:    toRevisionVo(diff.From),
+		To:      toRevisionVo(diff.To),
+		Title:   toDiffLineVos(diff.Title),
+		Content: toDiffLineVos(diff.Content),
+	}})
+}
+
+func (a *ArticleHandler) RestoreRevision(ctx *gin.Context) {
+	aid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	rid, err := strconv.ParseInt(ctx.Param("rid"), 10, 64)
 	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	userClaims := ctx.MustGet("user").(jwt.UserClaims)
+	err = a.svc.RestoreRevision(ctx.Request.Context(), aid, userClaims.Uid, rid)
+	if errors.Is(err, service.ErrRevisionNotFound) {
+		ctx.JSON(http.StatusOK, result.RetRevisionNotFound)
+		return
+	}
+	if err != nil {
+		a.l.Error("restore revision fail", logger.Int64("aid", aid), logger.Int64("rid", rid), logger.Error(err))
 		ctx.JSON(http.StatusOK, result.RetSystemError)
 		return
 	}
 	ctx.JSON(http.StatusOK, result.RetSuccess)
 }
+
+func (a *ArticleHandler) ListByTag(ctx *gin.Context) {
+	tags, err := domain.NormalizeTags([]string{ctx.Param("tag")})
+	if err != nil || len(tags) == 0 {
+		ctx.JSON(http.StatusOK, result.RetIllegalTags)
+		return
+	}
+	var page Page
+	if err = ctx.BindQuery(&page); err != nil {
+		return
+	}
+	cursor, err := domain.DecodeCursor(page.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalCursor)
+		return
+	}
+	if page.Limit <= 0 || page.Limit > maxPageSize {
+		page.Limit = maxPageSize
+	}
+	articles, err := a.svc.ListPubByTag(ctx.Request.Context(), tags[0], cursor, page.Limit)
+	if err != nil {
+		a.l.Error("list by tag fail", logger.String("tag", tags[0]), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	vo := ListVo[ArticleVo]{List: slice.Map[domain.Article, ArticleVo](articles, func(idx int, src domain.Article) ArticleVo {
+		return ArticleVo{
+			Id:       src.Id,
+			Title:    src.Title,
+			Abstract: src.Abstract(),
+			AuthorId: src.Author.Id,
+			Status:   src.Status.ToInt(),
+			Tags:     src.Tags,
+			Ctime:    src.Ctime.Format(time.DateTime),
+			Utime:    src.Utime.Format(time.DateTime),
+		}
+	})}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	a.fillInteractives(ctx, uc.Uid, vo.List)
+	if len(articles) == page.Limit {
+		vo.Cursor = articles[len(articles)-1].Cursor().Encode()
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: vo})
+}
+
+// fillInteractives 一次查出列表里面文章的计数和当前用户的点赞收藏状态，查不到就只返回文章
+func (a *ArticleHandler) fillInteractives(ctx *gin.Context, uid int64, vos []ArticleVo) {
+	if len(vos) == 0 {
+		return
+	}
+	resp, err := a.interactiveSvc.GetByIds(ctx.Request.Context(), &intrv1.GetByIdsRequest{
+		BizStr: a.bizStr,
+		Ids: slice.Map(vos, func(idx int, src ArticleVo) int64 {
+			return src.Id
+		}),
+		Uid: uid,
+	})
+	if err != nil {
+		a.l.Error("get interactives fail", logger.String("biz", a.bizStr), logger.Error(err))
+		return
+	}
+	for i := range vos {
+		intr, ok := resp.GetInteracs()[vos[i].Id]
+		if !ok {
+			continue
+		}
+		vos[i].ReadCnt = intr.GetReadCnt()
+		vos[i].UvCnt = intr.GetUvCnt()
+		vos[i].LikeCnt = intr.GetLikeCnt()
+		vos[i].CollectCnt = intr.GetCollectCnt()
+		vos[i].CommentCnt = intr.GetCommentCnt()
+		vos[i].Liked = intr.GetLiked()
+		vos[i].Collected = intr.GetCollected()
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/misakimei123/redbook/internal/domain"
@@ -121,7 +122,7 @@ func TestNewArticleHandler_Publish(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			handler := NewArticleHandler(articleService, logger.NewZapLogger(l))
+			handler := NewArticleHandler(articleService, nil, logger.NewZapLogger(l), nil)
 			server := gin.Default()
 			server.Use(func(ctx *gin.Context) {
 				ctx.Set("user", jwt.UserClaims{Uid: 123})
@@ -143,3 +144,75 @@ func TestNewArticleHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleHandler_List(t *testing.T) {
+	utime := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name    string
+		mock    func(controller *gomock.Controller) service.ArticleService
+		reqBody string
+		wantRes result.Result
+	}{
+		{
+			name: "first page, has next",
+			mock: func(controller *gomock.Controller) service.ArticleService {
+				articleService := svcmocks.NewMockArticleService(controller)
+				articleService.EXPECT().GetByAuthor(gomock.Any(), int64(123), domain.Cursor{}, 2).
+					Return([]domain.Article{
+						{Id: 2, Utime: utime},
+						{Id: 1, Utime: utime},
+					}, nil)
+				return articleService
+			},
+			reqBody: `{"limit": 2}`,
+			wantRes: result.Result{Data: map[string]any{
+				"list": []any{
+					map[string]any{"id": float64(2), "ctime": time.Time{}.Format(time.DateTime), "utime": utime.Format(time.DateTime)},
+					map[string]any{"id": float64(1), "ctime": time.Time{}.Format(time.DateTime), "utime": utime.Format(time.DateTime)},
+				},
+				"cursor": domain.Cursor{Utime: utime.UnixMilli(), Id: 1}.Encode(),
+			}},
+		},
+		{
+			name: "last page",
+			mock: func(controller *gomock.Controller) service.ArticleService {
+				articleService := svcmocks.NewMockArticleService(controller)
+				articleService.EXPECT().GetByAuthor(gomock.Any(), int64(123), domain.Cursor{Utime: 10, Id: 3}, 100).
+					Return([]domain.Article{}, nil)
+				return articleService
+			},
+			reqBody: `{"limit": 1000, "cursor": "` + domain.Cursor{Utime: 10, Id: 3}.Encode() + `"}`,
+			wantRes: result.Result{Data: map[string]any{"list": []any{}}},
+		},
+		{
+			name: "illegal cursor",
+			mock: func(controller *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(controller)
+			},
+			reqBody: `{"limit": 10, "cursor": "bad"}`,
+			wantRes: result.RetIllegalCursor,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			handler := NewArticleHandler(tc.mock(ctrl), nil, logger.NewNopLogger(), nil)
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", jwt.UserClaims{Uid: 123})
+			})
+			handler.RegisterRoutes(server)
+			request, err := http.NewRequest(http.MethodPost, "/articles/list", bytes.NewBufferString(tc.reqBody))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			var res result.Result
+			err = json.NewDecoder(recorder.Body).Decode(&res)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	intrv1 "github.com/misakimei123/redbook/api/proto/gen/intr/v1"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/web/jwt"
+	"github.com/misakimei123/redbook/internal/web/result"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxCollectionNameLen = 32
+
+type CollectionHandler struct {
+	svc intrv1.InteractiveServiceClient
+	l   logger.LoggerV1
+}
+
+func NewCollectionHandler(svc intrv1.InteractiveServiceClient, l logger.LoggerV1) *CollectionHandler {
+	return &CollectionHandler{svc: svc, l: l}
+}
+
+// RegisterRoutes 收藏夹 id 为 0 的是默认收藏夹，只能查看里面的内容
+func (c *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	group := server.Group("/pub/collections")
+	group.GET("", c.List)
+	group.POST("", c.Create)
+	group.PUT("/:id", c.Rename)
+	group.DELETE("/:id", c.Delete)
+	group.GET("/:id/items", c.Items)
+}
+
+func (c *CollectionHandler) List(ctx *gin.Context) {
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	resp, err := c.svc.ListCollections(ctx.Request.Context(), &intrv1.ListCollectionsRequest{Uid: uc.Uid})
+	if err != nil {
+		c.l.Error("list collections fail", logger.Int64("uid", uc.Uid), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: slice.Map(resp.GetCollections(), func(idx int, src *intrv1.Collection) CollectionVo {
+		return CollectionVo{
+			Id:    src.GetId(),
+			Name:  src.GetName(),
+			Ctime: time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+			Utime: time.UnixMilli(src.GetUtime()).Format(time.DateTime),
+		}
+	})})
+}
+
+func (c *CollectionHandler) Create(ctx *gin.Context) {
+	type Req struct {
+		Name string `json:"name"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	name, ok := c.normalizeName(req.Name)
+	if !ok {
+		ctx.JSON(http.StatusOK, result.RetIllegalCollectionName)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	resp, err := c.svc.CreateCollection(ctx.Request.Context(), &intrv1.CreateCollectionRequest{
+		Uid:  uc.Uid,
+		Name: name,
+	})
+	if err != nil {
+		c.l.Error("create collection fail", logger.Int64("uid", uc.Uid), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: resp.GetId()})
+}
+
+func (c *CollectionHandler) Rename(ctx *gin.Context) {
+	type Req struct {
+		Name string `json:"name"`
+	}
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	var req Req
+	if err = ctx.Bind(&req); err != nil {
+		return
+	}
+	name, ok := c.normalizeName(req.Name)
+	if !ok {
+		ctx.JSON(http.StatusOK, result.RetIllegalCollectionName)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	_, err = c.svc.RenameCollection(ctx.Request.Context(), &intrv1.RenameCollectionRequest{
+		Id:   id,
+		Uid:  uc.Uid,
+		Name: name,
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, result.RetCollectionNotFound)
+		return
+	}
+	if err != nil {
+		c.l.Error("rename collection fail", logger.Int64("cid", id), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.RetSuccess)
+}
+
+// Delete 收藏夹里面的内容一起取消收藏
+func (c *CollectionHandler) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	_, err = c.svc.DeleteCollection(ctx.Request.Context(), &intrv1.DeleteCollectionRequest{
+		Id:  id,
+		Uid: uc.Uid,
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, result.RetCollectionNotFound)
+		return
+	}
+	if err != nil {
+		c.l.Error("delete collection fail", logger.Int64("cid", id), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.RetSuccess)
+}
+
+// Items 收藏夹里面的内容，最近收藏的在前
+func (c *CollectionHandler) Items(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	var page Page
+	if err = ctx.BindQuery(&page); err != nil {
+		return
+	}
+	cursor, err := domain.DecodeCursor(page.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalCursor)
+		return
+	}
+	if page.Limit <= 0 || page.Limit > maxPageSize {
+		page.Limit = maxPageSize
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	resp, err := c.svc.ListCollectionItems(ctx.Request.Context(), &intrv1.ListCollectionItemsRequest{
+		Cid:    id,
+		Uid:    uc.Uid,
+		LastId: cursor.Id,
+		Limit:  int64(page.Limit),
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, result.RetCollectionNotFound)
+		return
+	}
+	if err != nil {
+		c.l.Error("list collection items fail", logger.Int64("cid", id), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	items := resp.GetItems()
+	vo := ListVo[CollectionItemVo]{List: slice.Map(items, func(idx int, src *intrv1.CollectionItem) CollectionItemVo {
+		return CollectionItemVo{
+			BizId: src.GetBizId(),
+			Ctime: time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+		}
+	})}
+	if len(items) == page.Limit {
+		last := items[len(items)-1]
+		vo.Cursor = domain.Cursor{Utime: last.GetCtime(), Id: last.GetId()}.Encode()
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: vo})
+}
+
+func (c *CollectionHandler) normalizeName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	return name, name != "" && utf8.RuneCountInString(name) <= maxCollectionNameLen
+}
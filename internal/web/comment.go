@@ -0,0 +1,176 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/internal/web/jwt"
+	"github.com/misakimei123/redbook/internal/web/result"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+const maxCommentLen = 1000
+
+type CommentHandler struct {
+	svc service.CommentService
+	l   logger.LoggerV1
+}
+
+func NewCommentHandler(svc service.CommentService, l logger.LoggerV1) *CommentHandler {
+	return &CommentHandler{svc: svc, l: l}
+}
+
+func (c *CommentHandler) RegisterRoutes(server *gin.Engine) {
+	group := server.Group("/comments")
+	group.POST("", c.Create)
+	group.POST("/delete", c.Delete)
+	group.GET("", c.List)
+	group.GET("/:id/replies", c.Replies)
+}
+
+func (c *CommentHandler) Create(ctx *gin.Context) {
+	type Req struct {
+		Biz      string `json:"biz"`
+		BizId    int64  `json:"bizId"`
+		ParentId int64  `json:"parentId"`
+		Content  string `json:"content"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	req.Content = strings.TrimSpace(req.Content)
+	if req.Biz == "" || req.BizId <= 0 || req.ParentId < 0 {
+		ctx.JSON(http.StatusOK, result.RetIllegalRequest)
+		return
+	}
+	if req.Content == "" || utf8.RuneCountInString(req.Content) > maxCommentLen {
+		ctx.JSON(http.StatusOK, result.RetIllegalComment)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	id, err := c.svc.Create(ctx.Request.Context(), domain.Comment{
+		Uid:      uc.Uid,
+		Biz:      req.Biz,
+		BizId:    req.BizId,
+		ParentId: req.ParentId,
+		Content:  req.Content,
+	})
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, result.Result{Data: id})
+	case errors.Is(err, service.ErrCommentNotFound):
+		// 回复的评论不存在
+		ctx.JSON(http.StatusOK, result.RetCommentNotFound)
+	case errors.Is(err, service.ErrCommentParentMismatch):
+		ctx.JSON(http.StatusOK, result.RetIllegalRequest)
+	case errors.Is(err, service.ErrCommentBizNotFound):
+		ctx.JSON(http.StatusOK, result.RetCommentBizNotFound)
+	default:
+		c.l.Error("create comment fail",
+			logger.String("biz", req.Biz),
+			logger.Int64("bizId", req.BizId),
+			logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+	}
+}
+
+func (c *CommentHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	err := c.svc.Delete(ctx.Request.Context(), req.Id, uc.Uid)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, result.RetSuccess)
+	case errors.Is(err, service.ErrCommentNotFound):
+		ctx.JSON(http.StatusOK, result.RetCommentNotFound)
+	case errors.Is(err, service.ErrCommentPermissionDenied):
+		ctx.JSON(http.StatusOK, result.RetCommentPermissionDenied)
+	default:
+		c.l.Error("delete comment fail", logger.Int64("id", req.Id), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+	}
+}
+
+// List 根评论按时间倒序，每条带上回复数和最早的几条回复
+func (c *CommentHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Page
+		Biz   string `form:"biz"`
+		BizId int64  `form:"bizId"`
+	}
+	var req Req
+	if err := ctx.BindQuery(&req); err != nil {
+		return
+	}
+	if req.Biz == "" || req.BizId <= 0 {
+		ctx.JSON(http.StatusOK, result.RetIllegalRequest)
+		return
+	}
+	cursor, err := domain.DecodeCursor(req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalCursor)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	comments, err := c.svc.ListRoots(ctx.Request.Context(), req.Biz, req.BizId, cursor, req.Limit)
+	if err != nil {
+		c.l.Error("list comments fail",
+			logger.String("biz", req.Biz),
+			logger.Int64("bizId", req.BizId),
+			logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: c.toListVo(comments, req.Limit)})
+}
+
+// Replies 某条根评论下面的回复，按时间正序
+func (c *CommentHandler) Replies(ctx *gin.Context) {
+	rootId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	var page Page
+	if err = ctx.BindQuery(&page); err != nil {
+		return
+	}
+	cursor, err := domain.DecodeCursor(page.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalCursor)
+		return
+	}
+	if page.Limit <= 0 || page.Limit > maxPageSize {
+		page.Limit = maxPageSize
+	}
+	replies, err := c.svc.ListReplies(ctx.Request.Context(), rootId, cursor, page.Limit)
+	if err != nil {
+		c.l.Error("list replies fail", logger.Int64("rootId", rootId), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: c.toListVo(replies, page.Limit)})
+}
+
+func (c *CommentHandler) toListVo(comments []domain.Comment, limit int) ListVo[CommentVo] {
+	vo := ListVo[CommentVo]{List: toCommentVos(comments)}
+	if len(comments) == limit {
+		vo.Cursor = comments[len(comments)-1].Cursor().Encode()
+	}
+	return vo
+}
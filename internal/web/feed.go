@@ -0,0 +1,63 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/internal/web/jwt"
+	"github.com/misakimei123/redbook/internal/web/result"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+type FeedHandler struct {
+	svc service.FeedService
+	l   logger.LoggerV1
+}
+
+func NewFeedHandler(svc service.FeedService, l logger.LoggerV1) *FeedHandler {
+	return &FeedHandler{svc: svc, l: l}
+}
+
+func (f *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/feed", f.List)
+}
+
+// List 当前用户关注的人发表的文章，最新的在前
+func (f *FeedHandler) List(ctx *gin.Context) {
+	var page Page
+	if err := ctx.BindQuery(&page); err != nil {
+		return
+	}
+	cursor, err := domain.DecodeCursor(page.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalCursor)
+		return
+	}
+	if page.Limit <= 0 || page.Limit > maxPageSize {
+		page.Limit = maxPageSize
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	items, err := f.svc.List(ctx.Request.Context(), uc.Uid, cursor, page.Limit)
+	if err != nil {
+		f.l.Error("list feed fail", logger.Int64("uid", uc.Uid), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	vo := ListVo[ArticleVo]{List: slice.Map[domain.FeedItem, ArticleVo](items, func(idx int, src domain.FeedItem) ArticleVo {
+		return ArticleVo{
+			Id:       src.Aid,
+			Title:    src.Title,
+			Abstract: src.Abstract,
+			AuthorId: src.AuthorId,
+			Ctime:    src.Ctime.Format(time.DateTime),
+		}
+	})}
+	if len(items) == page.Limit {
+		vo.Cursor = items[len(items)-1].Cursor().Encode()
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: vo})
+}
@@ -0,0 +1,185 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	followv1 "github.com/misakimei123/redbook/api/proto/gen/follow/v1"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/internal/web/jwt"
+	"github.com/misakimei123/redbook/internal/web/result"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type FollowHandler struct {
+	svc     followv1.FollowServiceClient
+	feedSvc service.FeedService
+	l       logger.LoggerV1
+}
+
+func NewFollowHandler(svc followv1.FollowServiceClient, feedSvc service.FeedService, l logger.LoggerV1) *FollowHandler {
+	return &FollowHandler{svc: svc, feedSvc: feedSvc, l: l}
+}
+
+func (f *FollowHandler) RegisterRoutes(server *gin.Engine) {
+	group := server.Group("/users")
+	group.GET("/:id/follow", f.Info)
+	group.POST("/:id/follow", f.Follow)
+	group.DELETE("/:id/follow", f.CancelFollow)
+	group.GET("/:id/followers", f.Followers)
+	group.GET("/:id/followees", f.Followees)
+}
+
+// Follow 当前用户关注 :id
+func (f *FollowHandler) Follow(ctx *gin.Context) {
+	followee, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	_, err = f.svc.Follow(ctx.Request.Context(), &followv1.FollowRequest{
+		Follower: uc.Uid,
+		Followee: followee,
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		ctx.JSON(http.StatusOK, result.RetIllegalFollow)
+		return
+	}
+	if err != nil {
+		f.l.Error("follow fail", logger.Int64("follower", uc.Uid), logger.Int64("followee", followee), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.RetSuccess)
+}
+
+func (f *FollowHandler) CancelFollow(ctx *gin.Context) {
+	followee, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	_, err = f.svc.CancelFollow(ctx.Request.Context(), &followv1.CancelFollowRequest{
+		Follower: uc.Uid,
+		Followee: followee,
+	})
+	if err != nil {
+		f.l.Error("cancel follow fail", logger.Int64("follower", uc.Uid), logger.Int64("followee", followee), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	// 取消关注是幂等的，清理收件箱失败了让用户重试
+	err = f.feedSvc.Unfollow(ctx.Request.Context(), uc.Uid, followee)
+	if err != nil {
+		f.l.Error("clean feed after cancel follow fail", logger.Int64("follower", uc.Uid), logger.Int64("followee", followee), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.RetSuccess)
+}
+
+// Info :id 的粉丝数、关注数，以及当前用户有没有关注 :id
+func (f *FollowHandler) Info(ctx *gin.Context) {
+	uid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaims)
+	staticResp, err := f.svc.GetFollowStatic(ctx.Request.Context(), &followv1.GetFollowStaticRequest{Uid: uid})
+	if err != nil {
+		f.l.Error("get follow static fail", logger.Int64("uid", uid), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	infoResp, err := f.svc.FollowInfo(ctx.Request.Context(), &followv1.FollowInfoRequest{
+		Follower: uc.Uid,
+		Followee: uid,
+	})
+	if err != nil {
+		f.l.Error("get follow info fail", logger.Int64("uid", uid), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: FollowInfoVo{
+		Followers: staticResp.GetFollowStatic().GetFollowers(),
+		Followees: staticResp.GetFollowStatic().GetFollowees(),
+		Followed:  infoResp.GetFollowed(),
+	}})
+}
+
+// Followers 关注了 :id 的人，最近关注的在前
+func (f *FollowHandler) Followers(ctx *gin.Context) {
+	f.list(ctx, func(uid int64, cursor domain.Cursor, limit int) ([]*followv1.FollowRelation, error) {
+		resp, err := f.svc.GetFollower(ctx.Request.Context(), &followv1.GetFollowerRequest{
+			Followee: uid,
+			LastId:   cursor.Id,
+			Limit:    int64(limit),
+		})
+		return resp.GetFollowRelations(), err
+	}, func(rel *followv1.FollowRelation) int64 {
+		return rel.GetFollower()
+	})
+}
+
+// Followees :id 关注的人，最近关注的在前
+func (f *FollowHandler) Followees(ctx *gin.Context) {
+	f.list(ctx, func(uid int64, cursor domain.Cursor, limit int) ([]*followv1.FollowRelation, error) {
+		resp, err := f.svc.GetFollowee(ctx.Request.Context(), &followv1.GetFolloweeRequest{
+			Follower: uid,
+			LastId:   cursor.Id,
+			Limit:    int64(limit),
+		})
+		return resp.GetFollowRelations(), err
+	}, func(rel *followv1.FollowRelation) int64 {
+		return rel.GetFollowee()
+	})
+}
+
+// list 粉丝和关注列表共用的翻页逻辑，other 取出关系里面对方的 uid
+func (f *FollowHandler) list(ctx *gin.Context,
+	find func(uid int64, cursor domain.Cursor, limit int) ([]*followv1.FollowRelation, error),
+	other func(rel *followv1.FollowRelation) int64) {
+	uid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalNumberFormat)
+		return
+	}
+	var page Page
+	if err = ctx.BindQuery(&page); err != nil {
+		return
+	}
+	cursor, err := domain.DecodeCursor(page.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusOK, result.RetIllegalCursor)
+		return
+	}
+	if page.Limit <= 0 || page.Limit > maxPageSize {
+		page.Limit = maxPageSize
+	}
+	rels, err := find(uid, cursor, page.Limit)
+	if err != nil {
+		f.l.Error("list follow relations fail", logger.Int64("uid", uid), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	vo := ListVo[FollowVo]{List: slice.Map(rels, func(idx int, src *followv1.FollowRelation) FollowVo {
+		return FollowVo{
+			Uid:   other(src),
+			Ctime: time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+		}
+	})}
+	if len(rels) == page.Limit {
+		last := rels[len(rels)-1]
+		vo.Cursor = domain.Cursor{Utime: last.GetCtime(), Id: last.GetId()}.Encode()
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: vo})
+}
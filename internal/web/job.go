@@ -0,0 +1,161 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/internal/web/result"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+// JobHandler 管理 MySQL 抢占式调度的任务
+type JobHandler struct {
+	svc service.JobService
+	l   logger.LoggerV1
+}
+
+func NewJobHandler(svc service.JobService, l logger.LoggerV1) *JobHandler {
+	return &JobHandler{svc: svc, l: l}
+}
+
+// RegisterRoutes 任务管理接口能操作所有人的任务，mdls 里面要带上管理员校验
+func (j *JobHandler) RegisterRoutes(server *gin.Engine, mdls ...gin.HandlerFunc) {
+	group := server.Group("/jobs", mdls...)
+	group.POST("", j.Register)
+	group.GET("", j.List)
+	group.POST("/:name/pause", j.Pause)
+	group.POST("/:name/resume", j.Resume)
+	group.POST("/:name/trigger", j.Trigger)
+	group.POST("/:name/delete", j.Delete)
+	group.GET("/:name/executions", j.Executions)
+}
+
+func (j *JobHandler) Register(ctx *gin.Context) {
+	type Req struct {
+		Name       string `json:"name"`
+		Expression string `json:"expression"`
+		Executor   string `json:"executor"`
+		Cfg        string `json:"cfg"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || req.Executor == "" {
+		ctx.JSON(http.StatusOK, result.RetIllegalRequest)
+		return
+	}
+	err := j.svc.Register(ctx.Request.Context(), domain.Job{
+		Name:       req.Name,
+		Expression: req.Expression,
+		Executor:   req.Executor,
+		Cfg:        req.Cfg,
+	})
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, result.RetSuccess)
+	case errors.Is(err, service.ErrInvalidCron):
+		ctx.JSON(http.StatusOK, result.RetIllegalCron)
+	case errors.Is(err, service.ErrDuplicateJob):
+		ctx.JSON(http.StatusOK, result.RetDuplicateJob)
+	default:
+		j.l.Error("register job fail", logger.String("name", req.Name), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+	}
+}
+
+func (j *JobHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Offset int `form:"offset"`
+		Limit  int `form:"limit"`
+	}
+	var req Req
+	if err := ctx.BindQuery(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 {
+		ctx.JSON(http.StatusOK, result.RetIllegalRequest)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	jobs, err := j.svc.List(ctx.Request.Context(), req.Offset, req.Limit)
+	if err != nil {
+		j.l.Error("list job fail", logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: slice.Map[domain.Job, JobVo](jobs, func(idx int, src domain.Job) JobVo {
+		return toJobVo(src)
+	})})
+}
+
+// Executions 任务的执行历史，最近的在前面
+func (j *JobHandler) Executions(ctx *gin.Context) {
+	type Req struct {
+		Offset int `form:"offset"`
+		Limit  int `form:"limit"`
+	}
+	var req Req
+	if err := ctx.BindQuery(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 {
+		ctx.JSON(http.StatusOK, result.RetIllegalRequest)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	name := ctx.Param("name")
+	executions, err := j.svc.ListExecutions(ctx.Request.Context(), name, req.Offset, req.Limit)
+	if err != nil {
+		j.l.Error("list job executions fail", logger.String("name", name), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: slice.Map[domain.JobExecution, JobExecutionVo](executions,
+		func(idx int, src domain.JobExecution) JobExecutionVo {
+			return toJobExecutionVo(src)
+		})})
+}
+
+func (j *JobHandler) Pause(ctx *gin.Context) {
+	j.handleByName(ctx, "pause", j.svc.Pause)
+}
+
+func (j *JobHandler) Resume(ctx *gin.Context) {
+	j.handleByName(ctx, "resume", j.svc.Resume)
+}
+
+// Trigger 只能触发等待中的任务
+func (j *JobHandler) Trigger(ctx *gin.Context) {
+	j.handleByName(ctx, "trigger", j.svc.Trigger)
+}
+
+func (j *JobHandler) Delete(ctx *gin.Context) {
+	j.handleByName(ctx, "delete", j.svc.Cancel)
+}
+
+// handleByName 按名字操作任务，任务不存在或者状态不对都返回 RetJobNotFound
+func (j *JobHandler) handleByName(ctx *gin.Context, action string, fn func(ctx context.Context, name string) error) {
+	name := ctx.Param("name")
+	err := fn(ctx.Request.Context(), name)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, result.RetSuccess)
+	case errors.Is(err, service.ErrJobNotFound):
+		ctx.JSON(http.StatusOK, result.RetJobNotFound)
+	default:
+		j.l.Error(action+" job fail", logger.String("name", name), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+	}
+}
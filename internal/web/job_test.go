@@ -0,0 +1,61 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/misakimei123/redbook/internal/service"
+	svcmocks "github.com/misakimei123/redbook/internal/service/mocks"
+	"github.com/misakimei123/redbook/internal/web/jwt"
+	"github.com/misakimei123/redbook/internal/web/middleware"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestJobHandler_Admin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.JobService
+		uid      int64
+		wantCode int
+	}{
+		{
+			name: "admin",
+			mock: func(ctrl *gomock.Controller) service.JobService {
+				svc := svcmocks.NewMockJobService(ctrl)
+				svc.EXPECT().Pause(gomock.Any(), "ranking").Return(nil)
+				return svc
+			},
+			uid:      1,
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "not admin",
+			mock: func(ctrl *gomock.Controller) service.JobService {
+				return svcmocks.NewMockJobService(ctrl)
+			},
+			uid:      123,
+			wantCode: http.StatusForbidden,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			handler := NewJobHandler(tc.mock(ctrl), logger.NewNopLogger())
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", jwt.UserClaims{Uid: tc.uid})
+			})
+			handler.RegisterRoutes(server, middleware.NewAdminMiddlewareBuilder([]int64{1}).CheckAdmin())
+			request, err := http.NewRequest(http.MethodPost, "/jobs/ranking/pause", nil)
+			assert.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+			assert.Equal(t, tc.wantCode, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	ijwt "github.com/misakimei123/redbook/internal/web/jwt"
+)
+
+// AdminMiddlewareBuilder 只放行配置里面的管理员，要放在登录校验后面
+type AdminMiddlewareBuilder struct {
+	admins map[int64]struct{}
+}
+
+func NewAdminMiddlewareBuilder(uids []int64) *AdminMiddlewareBuilder {
+	admins := make(map[int64]struct{}, len(uids))
+	for _, uid := range uids {
+		admins[uid] = struct{}{}
+	}
+	return &AdminMiddlewareBuilder{admins: admins}
+}
+
+func (a *AdminMiddlewareBuilder) CheckAdmin() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		val, ok := ctx.Get("user")
+		if !ok {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		uc, ok := val.(ijwt.UserClaims)
+		if !ok {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		if _, ok = a.admins[uc.Uid]; !ok {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+}
@@ -44,6 +44,78 @@ var (
 		Msg:  "illegal number format",
 		Code: UserInvalidInput,
 	}
+	RetIllegalCursor = Result{
+		Msg:  "illegal cursor",
+		Code: ArticleInvalidInput,
+	}
+	RetIllegalPublishTime = Result{
+		Msg:  "publish time must be in the future",
+		Code: ArticleInvalidInput,
+	}
+	RetIllegalTags = Result{
+		Msg:  "at most 10 tags, each no longer than 32 characters",
+		Code: ArticleInvalidInput,
+	}
+	RetScheduleNotFound = Result{
+		Msg:  "no pending scheduled publish",
+		Code: ArticleInvalidInput,
+	}
+	RetIllegalCollectionName = Result{
+		Msg:  "collection name must not be empty or longer than 32 characters",
+		Code: ArticleInvalidInput,
+	}
+	RetCollectionNotFound = Result{
+		Msg:  "collection not found",
+		Code: ArticleInvalidInput,
+	}
+	RetIllegalComment = Result{
+		Msg:  "comment must not be empty or longer than 1000 characters",
+		Code: CommentInvalidInput,
+	}
+	RetCommentNotFound = Result{
+		Msg:  "comment not found",
+		Code: CommentInvalidInput,
+	}
+	RetCommentBizNotFound = Result{
+		Msg:  "commented object not found",
+		Code: CommentInvalidInput,
+	}
+	RetCommentPermissionDenied = Result{
+		Msg:  "no permission to delete this comment",
+		Code: CommentInvalidInput,
+	}
+	RetIllegalFollow = Result{
+		Msg:  "can not follow yourself",
+		Code: UserInvalidInput,
+	}
+	RetBoardNotFound = Result{
+		Msg:  "ranking board not found",
+		Code: ArticleInvalidInput,
+	}
+	RetIllegalDate = Result{
+		Msg:  "date must be in the format 2006-01-02",
+		Code: ArticleInvalidInput,
+	}
+	RetRankingSnapshotNotFound = Result{
+		Msg:  "no ranking snapshot on this date",
+		Code: ArticleInvalidInput,
+	}
+	RetRevisionNotFound = Result{
+		Msg:  "revision not found",
+		Code: ArticleInvalidInput,
+	}
+	RetIllegalCron = Result{
+		Msg:  "illegal cron expression",
+		Code: JobInvalidInput,
+	}
+	RetDuplicateJob = Result{
+		Msg:  "job name duplicated",
+		Code: JobInvalidInput,
+	}
+	RetJobNotFound = Result{
+		Msg:  "job not found or status not allowed",
+		Code: JobInvalidInput,
+	}
 )
 
 const (
@@ -62,3 +134,13 @@ const (
 	ArticleInvalidInput        = 402001
 	ArticleInternalServerError = 502001
 )
+
+const (
+	// CommentInvalidInput 评论模块的统一的错误码
+	CommentInvalidInput = 403001
+)
+
+const (
+	// JobInvalidInput 任务管理的统一的错误码
+	JobInvalidInput = 404001
+)
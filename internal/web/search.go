@@ -0,0 +1,65 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/internal/web/result"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+type SearchHandler struct {
+	svc service.SearchService
+	l   logger.LoggerV1
+}
+
+func NewSearchHandler(svc service.SearchService, l logger.LoggerV1) *SearchHandler {
+	return &SearchHandler{svc: svc, l: l}
+}
+
+func (s *SearchHandler) RegisterRoutes(server *gin.Engine) {
+	pub := server.Group("/pub")
+	pub.GET("/search", s.Search)
+}
+
+func (s *SearchHandler) Search(ctx *gin.Context) {
+	type Req struct {
+		Query  string `form:"q"`
+		Offset int    `form:"offset"`
+		Limit  int    `form:"limit"`
+	}
+	var req Req
+	if err := ctx.BindQuery(&req); err != nil {
+		return
+	}
+	if req.Query == "" || req.Offset < 0 {
+		ctx.JSON(http.StatusOK, result.RetIllegalRequest)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	arts, total, err := s.svc.Search(ctx.Request.Context(), req.Query, req.Offset, req.Limit)
+	if err != nil {
+		s.l.Error("search fail", logger.String("query", req.Query), logger.Error(err))
+		ctx.JSON(http.StatusOK, result.RetSystemError)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Result{Data: SearchVo{
+		Total: total,
+		List: slice.Map[domain.Article, ArticleVo](arts, func(idx int, src domain.Article) ArticleVo {
+			return ArticleVo{
+				Id:       src.Id,
+				Title:    src.Title,
+				Abstract: src.Abstract(),
+				AuthorId: src.Author.Id,
+				Tags:     src.Tags,
+				Utime:    src.Utime.Format(time.DateTime),
+			}
+		}),
+	}})
+}
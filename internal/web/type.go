@@ -1,23 +1,197 @@
 package web
 
+import (
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/misakimei123/redbook/internal/domain"
+	"github.com/misakimei123/redbook/pkg/textdiff"
+)
+
 type Page struct {
-	Limit  int `json:"limit,omitempty"`
-	Offset int `json:"offset,omitempty"`
+	Limit  int    `json:"limit,omitempty" form:"limit"`
+	Cursor string `json:"cursor,omitempty" form:"cursor"`
+}
+
+// ListVo 游标分页的返回，Cursor 为空说明没有下一页了
+type ListVo[T any] struct {
+	List   []T    `json:"list"`
+	Cursor string `json:"cursor,omitempty"`
 }
 
 type ArticleVo struct {
-	Id         int64  `json:"id,omitempty"`
-	Title      string `json:"title,omitempty"`
-	Abstract   string `json:"abstract,omitempty"`
-	Content    string `json:"content,omitempty"`
-	AuthorId   int64  `json:"authorId,omitempty"`
-	AuthorName string `json:"authorName,omitempty"`
-	Status     uint8  `json:"status,omitempty"`
-	Ctime      string `json:"ctime,omitempty"`
-	Utime      string `json:"utime,omitempty"`
-	ReadCnt    int64  `json:"readCnt,omitempty"`
-	LikeCnt    int64  `json:"likeCnt,omitempty"`
-	CollectCnt int64  `json:"collectCnt,omitempty"`
-	Liked      bool   `json:"liked,omitempty"`
-	Collected  bool   `json:"collected,omitempty"`
+	Id         int64    `json:"id,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Abstract   string   `json:"abstract,omitempty"`
+	Content    string   `json:"content,omitempty"`
+	AuthorId   int64    `json:"authorId,omitempty"`
+	AuthorName string   `json:"authorName,omitempty"`
+	Status     uint8    `json:"status,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Ctime      string   `json:"ctime,omitempty"`
+	Utime      string   `json:"utime,omitempty"`
+	ReadCnt    int64    `json:"readCnt,omitempty"`
+	UvCnt      int64    `json:"uvCnt,omitempty"`
+	LikeCnt    int64    `json:"likeCnt,omitempty"`
+	CollectCnt int64    `json:"collectCnt,omitempty"`
+	CommentCnt int64    `json:"commentCnt,omitempty"`
+	Liked      bool     `json:"liked,omitempty"`
+	Collected  bool     `json:"collected,omitempty"`
+}
+
+type RevisionVo struct {
+	Id        int64  `json:"id,omitempty"`
+	ArticleId int64  `json:"articleId,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Status    uint8  `json:"status,omitempty"`
+	Ctime     string `json:"ctime,omitempty"`
+}
+
+type DiffLineVo struct {
+	// Op 取值 equal、insert、delete
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type RevisionDiffVo struct {
+	From    RevisionVo   `json:"from"`
+	To      RevisionVo   `json:"to"`
+	Title   []DiffLineVo `json:"title"`
+	Content []DiffLineVo `json:"content"`
+}
+
+func toRevisionVo(revision domain.ArticleRevision) RevisionVo {
+	return RevisionVo{
+		Id:        revision.Id,
+		ArticleId: revision.ArticleId,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Status:    revision.Status.ToInt(),
+		Ctime:     revision.Ctime.Format(time.DateTime),
+	}
+}
+
+func toDiffLineVos(lines []textdiff.Line) []DiffLineVo {
+	return slice.Map[textdiff.Line, DiffLineVo](lines, func(idx int, src textdiff.Line) DiffLineVo {
+		return DiffLineVo{Op: src.Op.String(), Text: src.Text}
+	})
+}
+
+type SearchVo struct {
+	List  []ArticleVo `json:"list"`
+	Total int         `json:"total"`
+}
+
+type CommentVo struct {
+	Id       int64       `json:"id"`
+	Uid      int64       `json:"uid"`
+	Biz      string      `json:"biz"`
+	BizId    int64       `json:"bizId"`
+	RootId   int64       `json:"rootId,omitempty"`
+	ParentId int64       `json:"parentId,omitempty"`
+	Content  string      `json:"content"`
+	ReplyCnt int64       `json:"replyCnt,omitempty"`
+	Replies  []CommentVo `json:"replies,omitempty"`
+	Ctime    string      `json:"ctime"`
+}
+
+func toCommentVo(c domain.Comment) CommentVo {
+	return CommentVo{
+		Id:       c.Id,
+		Uid:      c.Uid,
+		Biz:      c.Biz,
+		BizId:    c.BizId,
+		RootId:   c.RootId,
+		ParentId: c.ParentId,
+		Content:  c.Content,
+		ReplyCnt: c.ReplyCnt,
+		Replies:  toCommentVos(c.Replies),
+		Ctime:    c.Ctime.Format(time.DateTime),
+	}
+}
+
+func toCommentVos(cs []domain.Comment) []CommentVo {
+	return slice.Map[domain.Comment, CommentVo](cs, func(idx int, src domain.Comment) CommentVo {
+		return toCommentVo(src)
+	})
+}
+
+// FollowVo 列表里面的对方，Ctime 是关注的时间
+type FollowVo struct {
+	Uid   int64  `json:"uid"`
+	Ctime string `json:"ctime"`
+}
+
+type CollectionVo struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Ctime string `json:"ctime"`
+	Utime string `json:"utime"`
+}
+
+// CollectionItemVo 收藏的文章，Ctime 是收藏的时间
+type CollectionItemVo struct {
+	BizId int64  `json:"bizId"`
+	Ctime string `json:"ctime"`
+}
+
+type FollowInfoVo struct {
+	Followers int64 `json:"followers"`
+	Followees int64 `json:"followees"`
+	// Followed 当前用户是否关注了
+	Followed bool `json:"followed"`
+}
+
+// JobVo 任务列表，Owner 是正在执行的节点
+type JobVo struct {
+	Id         int64  `json:"id"`
+	Name       string `json:"name"`
+	Expression string `json:"expression,omitempty"`
+	Executor   string `json:"executor"`
+	Cfg        string `json:"cfg,omitempty"`
+	Status     string `json:"status"`
+	NextTime   string `json:"nextTime"`
+	Owner      string `json:"owner,omitempty"`
+	Utime      string `json:"utime"`
+}
+
+func toJobVo(j domain.Job) JobVo {
+	return JobVo{
+		Id:         j.Id,
+		Name:       j.Name,
+		Expression: j.Expression,
+		Executor:   j.Executor,
+		Cfg:        j.Cfg,
+		Status:     j.Status.String(),
+		NextTime:   j.NextExecTime.Format(time.DateTime),
+		Owner:      j.Owner,
+		Utime:      j.Utime.Format(time.DateTime),
+	}
+}
+
+// JobExecutionVo 没有结束的执行 End 为空
+type JobExecutionVo struct {
+	Id      int64  `json:"id"`
+	Node    string `json:"node"`
+	Attempt int    `json:"attempt"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Start   string `json:"start"`
+	End     string `json:"end,omitempty"`
+}
+
+func toJobExecutionVo(e domain.JobExecution) JobExecutionVo {
+	vo := JobExecutionVo{
+		Id:      e.Id,
+		Node:    e.Node,
+		Attempt: e.Attempt,
+		Status:  e.Status.String(),
+		Error:   e.Error,
+		Start:   e.Start.Format(time.DateTime),
+	}
+	if !e.End.IsZero() {
+		vo.End = e.End.Format(time.DateTime)
+	}
+	return vo
 }
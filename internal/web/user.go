@@ -9,6 +9,7 @@ import (
 	regexp "github.com/dlclark/regexp2"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	followv1 "github.com/misakimei123/redbook/api/proto/gen/follow/v1"
 	"github.com/misakimei123/redbook/internal/domain"
 	"github.com/misakimei123/redbook/internal/service"
 	ginCtx "github.com/misakimei123/redbook/internal/web/ginadaptor"
@@ -33,9 +34,11 @@ type UserHandler struct {
 	code           service.CodeService
 	jwtHandler     ijwt.Handler
 	logContext     ginCtx.Context
+	followSvc      followv1.FollowServiceClient
 }
 
-func NewUserHandler(svc service.UserService, code service.CodeService, handler ijwt.Handler, logContext ginCtx.Context) *UserHandler {
+func NewUserHandler(svc service.UserService, code service.CodeService, handler ijwt.Handler, logContext ginCtx.Context,
+	followSvc followv1.FollowServiceClient) *UserHandler {
 	return &UserHandler{
 		emailRexExp:    regexp.MustCompile(emailPattern, regexp.None),
 		passwordRexExp: regexp.MustCompile(passwordPattern, regexp.None),
@@ -44,6 +47,7 @@ func NewUserHandler(svc service.UserService, code service.CodeService, handler i
 		code:           code,
 		jwtHandler:     handler,
 		logContext:     logContext,
+		followSvc:      followSvc,
 	}
 }
 
@@ -168,13 +172,20 @@ func (h *UserHandler) Login(ctx *ginCtx.LogContext) {
 }
 
 func (h *UserHandler) Profile(ctx *ginCtx.LogContext) {
-	user, err := h.svc.Profile(ctx.Request.Context(),
-		GetUserId(ctx),
-	)
+	uid := GetUserId(ctx)
+	user, err := h.svc.Profile(ctx.Request.Context(), uid)
 	if err != nil {
 		ctx.String(http.StatusOK, "system error %s", err)
+		return
+	}
+	// 关注服务不可用的时候计数返回 0，不影响资料本身
+	var static *followv1.FollowStatic
+	resp, err := h.followSvc.GetFollowStatic(ctx.Request.Context(), &followv1.GetFollowStaticRequest{Uid: uid})
+	if err == nil {
+		static = resp.GetFollowStatic()
 	}
-	ctx.JSON(http.StatusOK, gin.H{"Nick": user.Nick, "AboutMe": user.AboutMe, "Birthday": user.Birthday.Format("2006-01-02")})
+	ctx.JSON(http.StatusOK, gin.H{"Nick": user.Nick, "AboutMe": user.AboutMe, "Birthday": user.Birthday.Format("2006-01-02"),
+		"Followers": static.GetFollowers(), "Followees": static.GetFollowees()})
 }
 
 func (h *UserHandler) Edit(ctx *ginCtx.LogContext) {
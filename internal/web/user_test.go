@@ -211,7 +211,7 @@ func TestUserHandler_SignUp(t *testing.T) {
 			controller := gomock.NewController(t)
 			defer controller.Finish()
 			userService, codeService, jwtHandler, logContext := tc.mock(controller)
-			handler := NewUserHandler(userService, codeService, jwtHandler, logContext)
+			handler := NewUserHandler(userService, codeService, jwtHandler, logContext, nil)
 			server := gin.Default()
 			handler.RegisterRoutes(server)
 			req := tc.reqBuilder(t)
@@ -240,7 +240,7 @@ func TestEmailPattern(t *testing.T) {
 			match: false,
 		},
 	}
-	userHandler := NewUserHandler(nil, nil, nil, nil)
+	userHandler := NewUserHandler(nil, nil, nil, nil, nil)
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			match, err := userHandler.emailRexExp.MatchString(tc.email)
@@ -0,0 +1,36 @@
+package ioc
+
+import (
+	followv1 "github.com/misakimei123/redbook/api/proto/gen/follow/v1"
+	"github.com/spf13/viper"
+	etcdv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func InitFollowClient(cli *etcdv3.Client) followv1.FollowServiceClient {
+	type Config struct {
+		Addr   string `yaml:"addr"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.client.follow", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	var opts []grpc.DialOption
+	if !cfg.Secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	etcdResolver, err := resolver.NewBuilder(cli)
+	if err != nil {
+		panic(err)
+	}
+	opts = append(opts, grpc.WithResolvers(etcdResolver))
+	cc, err := grpc.Dial(cfg.Addr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return followv1.NewFollowServiceClient(cc)
+}
@@ -5,6 +5,7 @@ import (
 	intrv1 "github.com/misakimei123/redbook/api/proto/gen/intr/v1"
 	"github.com/misakimei123/redbook/interactive/service"
 	"github.com/misakimei123/redbook/internal/client"
+	"github.com/misakimei123/redbook/pkg/viperx"
 	"github.com/spf13/viper"
 	etcdv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/naming/resolver"
@@ -35,7 +36,7 @@ func InitIntrClient(svc service.InteractiveService) intrv1.InteractiveServiceCli
 	local := client.NewLocalInteractiveServiceAdapter(svc)
 	intrClient := client.NewInteractiveClient(remote, local)
 	intrClient.UpdateThreshold(cfg.Threshold)
-	viper.OnConfigChange(func(in fsnotify.Event) {
+	viperx.OnConfigChange(func(in fsnotify.Event) {
 		var cfg Config
 		err := viper.UnmarshalKey("grpc.client.intr", &cfg)
 		if err != nil {
@@ -1,6 +1,9 @@
 package ioc
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/misakimei123/redbook/internal/job"
 	"github.com/misakimei123/redbook/internal/service"
 	"github.com/misakimei123/redbook/pkg/distribute/balance"
@@ -8,9 +11,11 @@ import (
 	"github.com/misakimei123/redbook/pkg/logger"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/robfig/cron/v3"
+	"github.com/spf13/viper"
+	etcdv3 "go.etcd.io/etcd/client/v3"
 )
 
-func InitRankingJob(svc service.RankingService, l logger.LoggerV1, dLock lock.Lock,
+func InitRankingJob(svc service.RankingService, l logger.LoggerV1, dLock lock.Client,
 	balancer balance.LoadBalance[job.RunNode]) job.Job {
 	return job.NewRankingJob(svc, l, dLock, balancer)
 }
@@ -35,3 +40,33 @@ func InitJobs(runJob job.Job, l logger.LoggerV1) *cron.Cron {
 	}
 	return c
 }
+
+// InitScheduler http 和 grpc 执行器按任务名字配置调用的地址和服务，任务可以放在别的服务里面执行
+func InitScheduler(svc service.JobService, artSvc service.ArticleService, cli *etcdv3.Client, l logger.LoggerV1) *job.Scheduler {
+	type Config struct {
+		Http struct {
+			Timeout   time.Duration     `yaml:"timeout"`
+			Endpoints map[string]string `yaml:"endpoints"`
+		} `yaml:"http"`
+		Grpc struct {
+			Timeout  time.Duration     `yaml:"timeout"`
+			Services map[string]string `yaml:"services"`
+		} `yaml:"grpc"`
+	}
+	cfg := Config{}
+	cfg.Http.Timeout = time.Second * 10
+	cfg.Grpc.Timeout = time.Second * 10
+	err := viper.UnmarshalKey("job.executors", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	grpcExecutor, err := job.NewGrpcExecutor(cli, cfg.Grpc.Services, cfg.Grpc.Timeout)
+	if err != nil {
+		panic(err)
+	}
+	scheduler := job.NewScheduler(svc, l)
+	scheduler.RegisterExecutor(job.NewArticlePublishExecutor(artSvc, l))
+	scheduler.RegisterExecutor(job.NewHttpExecutor(http.DefaultClient, cfg.Http.Endpoints, cfg.Http.Timeout))
+	scheduler.RegisterExecutor(grpcExecutor)
+	return scheduler
+}
@@ -5,9 +5,14 @@ import (
 	events2 "github.com/misakimei123/redbook/interactive/events"
 	"github.com/misakimei123/redbook/interactive/repository"
 	"github.com/misakimei123/redbook/internal/events/article"
+	"github.com/misakimei123/redbook/internal/events/feed"
+	"github.com/misakimei123/redbook/internal/events/search"
+	"github.com/misakimei123/redbook/pkg/distribute/lock"
 	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/misakimei123/redbook/pkg/outbox"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/viper"
+	"gorm.io/gorm"
 )
 
 func InitSaramaClient() sarama.Client {
@@ -37,19 +42,38 @@ func InitialProducer(client sarama.Client) sarama.SyncProducer {
 	return producer
 }
 
-func InitialSaramaSyncProducer(producer sarama.SyncProducer) article.Producer {
-	return article.NewSaramaSyncProducer(producer, prometheus.GaugeOpts{
+// InitArticleProducer 只发阅读事件，发表、撤回的事件在 dao 的事务里面写进 outbox，
+// 两种事件都由 InitOutboxRelay 投递
+func InitArticleProducer(writer *outbox.BatchWriter) article.Producer {
+	return article.NewOutboxProducer(writer)
+}
+
+// InitOutboxWriter 退出之前要 Close，把缓冲的事件写完
+func InitOutboxWriter(db *gorm.DB, l logger.LoggerV1) *outbox.BatchWriter {
+	return outbox.NewBatchWriter(db, l, prometheus.CounterOpts{
+		Namespace: "misakimei123",
+		Subsystem: "redbook",
+		Name:      "outbox_writer",
+	})
+}
+
+func InitOutboxRelay(db *gorm.DB, producer sarama.SyncProducer, dLock lock.Client, l logger.LoggerV1) *outbox.Relay {
+	return outbox.NewRelay(db, producer, dLock, l, prometheus.CounterOpts{
 		Namespace: "misakimei123",
 		Subsystem: "redbook",
-		Name:      "kafka_producer",
+		Name:      "outbox_relay",
 	})
 }
 
 func InitConsumers(
-// consumer *events2.InteractiveReadEventBatchConsumer,
+	// consumer *events2.InteractiveReadEventBatchConsumer,
+	searchConsumer *search.SyncEventConsumer,
+	feedConsumer *feed.SyncEventConsumer,
 ) []events2.Consumer {
 	return []events2.Consumer{
 		// consumer,
+		searchConsumer,
+		feedConsumer,
 	}
 }
 
@@ -59,5 +83,5 @@ func InitialInteractiveReadEventBatchConsumer(repo repository.InteractiveReposit
 		Namespace: "misakimei123",
 		Subsystem: "redbook",
 		Name:      "kafka_consumer",
-	})
+	}, 0)
 }
@@ -0,0 +1,41 @@
+package ioc
+
+import (
+	"github.com/fsnotify/fsnotify"
+	intrv1 "github.com/misakimei123/redbook/api/proto/gen/intr/v1"
+	"github.com/misakimei123/redbook/internal/repository"
+	"github.com/misakimei123/redbook/internal/service"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/misakimei123/redbook/pkg/viperx"
+	"github.com/spf13/viper"
+)
+
+// InitRankingService 榜单的算法、n、before 和命名榜单都可以热更新，没有配置就用默认的
+func InitRankingService(repo repository.RankingRepository, artSvc service.ArticleService,
+	intrSvc intrv1.InteractiveServiceClient, l logger.LoggerV1) service.RankingService {
+	svc := service.NewArticleRankingServiceV1(repo, artSvc, intrSvc, l)
+	if !viper.IsSet("ranking") {
+		return svc
+	}
+	var cfg service.RankingConfig
+	err := viper.UnmarshalKey("ranking", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	err = svc.UpdateConfig(cfg)
+	if err != nil {
+		panic(err)
+	}
+	viperx.OnConfigChange(func(in fsnotify.Event) {
+		var cfg service.RankingConfig
+		err := viper.UnmarshalKey("ranking", &cfg)
+		if err == nil {
+			err = svc.UpdateConfig(cfg)
+		}
+		if err != nil {
+			// 配置改错了不能让服务挂掉，继续用原来的
+			l.Error("reload ranking config fail", logger.Error(err))
+		}
+	})
+	return svc
+}
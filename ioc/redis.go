@@ -1,7 +1,10 @@
 package ioc
 
 import (
+	"time"
+
 	"github.com/misakimei123/redbook/config"
+	"github.com/misakimei123/redbook/internal/repository/cache"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -10,3 +13,8 @@ func InitRedis() redis.Cmdable {
 	//viper.GetString("redis.addr")})
 	config.Config.Redis.Addr})
 }
+
+// InitArticleCache 文章详情先查本地，再查 redis，不存在的文章缓存一分钟
+func InitArticleCache(client redis.Cmdable) cache.ArticleCache {
+	return cache.NewArticleLocalCache(cache.NewArticleRedisCacheV1(client, time.Minute), 1024, time.Second*5)
+}
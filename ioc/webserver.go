@@ -15,18 +15,37 @@ import (
 	"github.com/misakimei123/redbook/pkg/limiter"
 	"github.com/misakimei123/redbook/pkg/logger"
 	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
 	otelgin "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-func InitWebServer(mdls []gin.HandlerFunc, userHdl *web.UserHandler, articleHdl *web.ArticleHandler, wechatHdl *web.OAuth2WechatHandler) *gin.Engine {
+func InitWebServer(mdls []gin.HandlerFunc, userHdl *web.UserHandler, articleHdl *web.ArticleHandler, wechatHdl *web.OAuth2WechatHandler,
+	searchHdl *web.SearchHandler, commentHdl *web.CommentHandler, followHdl *web.FollowHandler,
+	feedHdl *web.FeedHandler, collectionHdl *web.CollectionHandler, jobHdl *web.JobHandler) *gin.Engine {
 	server := gin.Default()
 	server.Use(mdls...)
 	userHdl.RegisterRoutes(server)
 	articleHdl.RegisterRoutes(server)
+	searchHdl.RegisterRoutes(server)
+	commentHdl.RegisterRoutes(server)
+	followHdl.RegisterRoutes(server)
+	feedHdl.RegisterRoutes(server)
+	collectionHdl.RegisterRoutes(server)
+	jobHdl.RegisterRoutes(server, initAdminMiddleware())
 	wechatHdl.RegisterRotes(server)
 	return server
 }
 
+// initAdminMiddleware 管理员在配置里面按用户 id 指定，没有配置就谁都不能用管理接口
+func initAdminMiddleware() gin.HandlerFunc {
+	var uids []int64
+	err := viper.UnmarshalKey("admin.uids", &uids)
+	if err != nil {
+		panic(err)
+	}
+	return middleware.NewAdminMiddlewareBuilder(uids).CheckAdmin()
+}
+
 func InitGinMiddlewares(redisClient redis.Cmdable, ijwtHdl ijwt.Handler, l logger.LoggerV1) []gin.HandlerFunc {
 	prometheusBuilder := &prometheus.Builder{
 		Namespace: "misakimei123",
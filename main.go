@@ -2,11 +2,16 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/misakimei123/redbook/internal/web/middleware"
+	"github.com/misakimei123/redbook/pkg/viperx"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/gin-contrib/sessions"
@@ -30,21 +35,54 @@ func main() {
 	// }()
 	initPrometheus()
 	app := InitWebServer()
-	// for _, consumer := range app.consumers {
-	// 	err := consumer.Start()
-	// 	if err != nil {
-	// 		panic(err)
-	// 	}
-	// }
+	for _, consumer := range app.consumers {
+		err := consumer.Start()
+		if err != nil {
+			panic(err)
+		}
+	}
 	// app.cron.Start()
 	// defer func() {
 	// 	<-app.cron.Stop().Done()
 	// }()
 	// zap.L().Info("webserver initialed")
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		err := app.scheduler.Schedule(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			zap.L().Error("scheduler exit", zap.Error(err))
+		}
+	}()
+	go func() {
+		err := app.relay.Start(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			zap.L().Error("outbox relay exit", zap.Error(err))
+		}
+	}()
 
-	err := app.server.Run(":8081")
+	server := &http.Server{Addr: ":8081", Handler: app.server}
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
+	}()
+	<-ctx.Done()
+	shutdown(server, app)
+}
+
+// shutdown 先停掉 web 服务，不再产生新的事件，再把缓冲的事件写进 outbox
+func shutdown(server *http.Server, app *App) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	err := server.Shutdown(ctx)
+	if err != nil {
+		zap.L().Error("webserver shutdown fail", zap.Error(err))
+	}
+	err = app.writer.Close(ctx)
 	if err != nil {
-		panic(err)
+		zap.L().Error("outbox writer close fail", zap.Error(err))
 	}
 }
 
@@ -102,7 +140,7 @@ func initViperWatch() {
 	viper.SetConfigType("yaml")
 	viper.SetConfigFile(*cfile)
 	viper.WatchConfig()
-	viper.OnConfigChange(func(in fsnotify.Event) {
+	viperx.OnConfigChange(func(in fsnotify.Event) {
 		fmt.Printf("on change test.key: %s", viper.GetString("test.key"))
 	})
 	err := viper.ReadInConfig()
@@ -136,7 +174,7 @@ func initViperRemote() {
 		panic(fmt.Errorf("read config fail %s", err))
 	}
 	viper.SetConfigType("yaml")
-	viper.OnConfigChange(func(in fsnotify.Event) {
+	viperx.OnConfigChange(func(in fsnotify.Event) {
 		fmt.Printf("remote config change")
 	})
 	err = viper.ReadRemoteConfig()
@@ -0,0 +1,7 @@
+-- 没人持有或者就是自己持有的时候加锁，重入次数加一
+if redis.call("exists", KEYS[1]) == 0 or redis.call("hexists", KEYS[1], ARGV[1]) == 1 then
+    redis.call("hincrby", KEYS[1], ARGV[1], 1)
+    redis.call("pexpire", KEYS[1], ARGV[2])
+    return 1
+end
+return 0
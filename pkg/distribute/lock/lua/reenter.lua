@@ -0,0 +1,7 @@
+-- 只有自己还持有锁的时候才能重入，锁过期了不能重新加上
+if redis.call("hexists", KEYS[1], ARGV[1]) == 1 then
+    redis.call("hincrby", KEYS[1], ARGV[1], 1)
+    redis.call("pexpire", KEYS[1], ARGV[2])
+    return 1
+end
+return 0
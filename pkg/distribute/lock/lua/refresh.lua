@@ -0,0 +1,5 @@
+-- 只有自己持有的锁才能续约
+if redis.call("hexists", KEYS[1], ARGV[1]) == 1 then
+    return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0
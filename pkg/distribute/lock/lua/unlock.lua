@@ -0,0 +1,10 @@
+-- 不是自己持有的锁返回 -1，否则返回剩下的重入次数，减到 0 才删掉
+if redis.call("hexists", KEYS[1], ARGV[1]) == 0 then
+    return -1
+end
+local cnt = redis.call("hincrby", KEYS[1], ARGV[1], -1)
+if cnt <= 0 then
+    redis.call("del", KEYS[1])
+    return 0
+end
+return cnt
@@ -0,0 +1,188 @@
+package lock
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	//go:embed lua/lock.lua
+	luaLock string
+	//go:embed lua/reenter.lua
+	luaReenter string
+	//go:embed lua/unlock.lua
+	luaUnlock string
+	//go:embed lua/refresh.lua
+	luaRefresh string
+)
+
+// RedisClient 锁用 hash 保存，field 是持有者的 token，value 是重入次数
+type RedisClient struct {
+	client redis.Cmdable
+}
+
+func NewRedisClient(client redis.Cmdable) Client {
+	return &RedisClient{client: client}
+}
+
+func (r *RedisClient) TryLock(ctx context.Context, key string, ttl time.Duration) (*Mutex, error) {
+	return r.Lock(ctx, key, ttl, nil)
+}
+
+func (r *RedisClient) Lock(ctx context.Context, key string, ttl time.Duration, retry RetryStrategy) (*Mutex, error) {
+	token := uuid.New().String()
+	for retries := 1; ; retries++ {
+		// 出错的时候不重试，不然超时但是其实加上了的锁会被当成重入
+		ok, err := r.client.Eval(ctx, luaLock, []string{key}, token, ttl.Milliseconds()).Bool()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return newMutex(r.client, key, token, ttl), nil
+		}
+		if retry == nil {
+			return nil, ErrLockPreempted
+		}
+		interval, ok := retry.Next(retries)
+		if !ok {
+			return nil, ErrLockPreempted
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Mutex 一次加锁的结果，同一个 Mutex 可以重入，Unlock 的次数要和加锁的次数一样
+type Mutex struct {
+	client     redis.Cmdable
+	key        string
+	token      string
+	ttl        time.Duration
+	lost       chan struct{}
+	lostOnce   sync.Once
+	unlocked   chan struct{}
+	unlockOnce sync.Once
+}
+
+func newMutex(client redis.Cmdable, key string, token string, ttl time.Duration) *Mutex {
+	return &Mutex{client: client, key: key, token: token, ttl: ttl,
+		lost: make(chan struct{}), unlocked: make(chan struct{})}
+}
+
+func (m *Mutex) Key() string {
+	return m.key
+}
+
+func (m *Mutex) Token() string {
+	return m.token
+}
+
+// Lost 发现锁已经不是自己的了就会关闭
+func (m *Mutex) Lost() <-chan struct{} {
+	return m.lost
+}
+
+// Reenter 重入一次，锁已经释放或者丢了返回 ErrLockNotHold，锁过期了也不会重新加上
+func (m *Mutex) Reenter(ctx context.Context) error {
+	select {
+	case <-m.unlocked:
+		return ErrLockNotHold
+	case <-m.lost:
+		return ErrLockNotHold
+	default:
+	}
+	ok, err := m.client.Eval(ctx, luaReenter, []string{m.key}, m.token, m.ttl.Milliseconds()).Bool()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		m.markLost()
+		return ErrLockNotHold
+	}
+	return nil
+}
+
+func (m *Mutex) Refresh(ctx context.Context) error {
+	ok, err := m.client.Eval(ctx, luaRefresh, []string{m.key}, m.token, m.ttl.Milliseconds()).Bool()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		m.markLost()
+		return ErrLockNotHold
+	}
+	return nil
+}
+
+// AutoRefresh 每隔 interval 续约一次，阻塞到 ctx 结束、锁释放了或者锁丢了。
+// 续约出错了下一次再试，超过 ttl 都没有续约成功就当作锁已经丢了
+func (m *Mutex) AutoRefresh(ctx context.Context, interval time.Duration, timeout time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastRefresh := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-m.unlocked:
+			return nil
+		case <-m.lost:
+			return ErrLockNotHold
+		case <-ticker.C:
+		}
+		rCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := m.Refresh(rCtx)
+		cancel()
+		switch {
+		case err == nil:
+			lastRefresh = time.Now()
+		case errors.Is(err, ErrLockNotHold):
+			return err
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case time.Since(lastRefresh) >= m.ttl:
+			m.markLost()
+			return err
+		}
+	}
+}
+
+// Unlock 重入次数减到 0 才真正释放
+func (m *Mutex) Unlock(ctx context.Context) error {
+	cnt, err := m.client.Eval(ctx, luaUnlock, []string{m.key}, m.token).Int64()
+	if err != nil {
+		return err
+	}
+	if cnt < 0 {
+		m.markLost()
+		m.markUnlocked()
+		return ErrLockNotHold
+	}
+	if cnt == 0 {
+		m.markUnlocked()
+	}
+	return nil
+}
+
+func (m *Mutex) markLost() {
+	m.lostOnce.Do(func() {
+		close(m.lost)
+	})
+}
+
+func (m *Mutex) markUnlocked() {
+	m.unlockOnce.Do(func() {
+		close(m.unlocked)
+	})
+}
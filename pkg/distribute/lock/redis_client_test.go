@@ -0,0 +1,200 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/misakimei123/redbook/internal/repository/cache/redismocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRedisClient_Lock(t *testing.T) {
+	redisError := errors.New("redis fail")
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		retry   RetryStrategy
+		wantErr error
+	}{
+		{
+			name: "locked",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:test"}, gomock.Any(), int64(60000)).
+					Return(redis.NewCmdResult(int64(1), nil))
+				return cmd
+			},
+		},
+		{
+			name: "preempted",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:test"}, gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(int64(0), nil))
+				return cmd
+			},
+			wantErr: ErrLockPreempted,
+		},
+		{
+			name: "locked after retry",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				gomock.InOrder(
+					cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:test"}, gomock.Any(), gomock.Any()).
+						Times(2).Return(redis.NewCmdResult(int64(0), nil)),
+					cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:test"}, gomock.Any(), gomock.Any()).
+						Return(redis.NewCmdResult(int64(1), nil)),
+				)
+				return cmd
+			},
+			retry: FixedIntervalRetry{Interval: time.Millisecond, MaxRetries: 3},
+		},
+		{
+			name: "retry exhausted",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:test"}, gomock.Any(), gomock.Any()).
+					Times(3).Return(redis.NewCmdResult(int64(0), nil))
+				return cmd
+			},
+			retry:   FixedIntervalRetry{Interval: time.Millisecond, MaxRetries: 2},
+			wantErr: ErrLockPreempted,
+		},
+		{
+			name: "redis fail",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:test"}, gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(nil, redisError))
+				return cmd
+			},
+			retry:   FixedIntervalRetry{Interval: time.Millisecond, MaxRetries: 2},
+			wantErr: redisError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client := NewRedisClient(tc.mock(ctrl))
+			m, err := client.Lock(context.Background(), "lock:test", time.Minute, tc.retry)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, "lock:test", m.Key())
+			assert.NotEmpty(t, m.Token())
+		})
+	}
+}
+
+func TestMutex_Unlock(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cnt          int64
+		wantErr      error
+		wantUnlocked bool
+		wantLost     bool
+	}{
+		{name: "reentered", cnt: 1},
+		{name: "unlocked", cnt: 0, wantUnlocked: true},
+		{name: "not hold", cnt: -1, wantErr: ErrLockNotHold, wantUnlocked: true, wantLost: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmd := redismocks.NewMockCmdable(ctrl)
+			cmd.EXPECT().Eval(gomock.Any(), luaUnlock, []string{"lock:test"}, "token").
+				Return(redis.NewCmdResult(tc.cnt, nil))
+			m := newMutex(cmd, "lock:test", "token", time.Minute)
+			err := m.Unlock(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantUnlocked, isClosed(m.unlocked))
+			assert.Equal(t, tc.wantLost, isClosed(m.Lost()))
+		})
+	}
+}
+
+func TestMutex_Reenter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ok       int64
+		wantErr  error
+		wantLost bool
+	}{
+		{name: "reentered", ok: 1},
+		{name: "expired", ok: 0, wantErr: ErrLockNotHold, wantLost: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmd := redismocks.NewMockCmdable(ctrl)
+			cmd.EXPECT().Eval(gomock.Any(), luaReenter, []string{"lock:test"}, "token", int64(60000)).
+				Return(redis.NewCmdResult(tc.ok, nil))
+			m := newMutex(cmd, "lock:test", "token", time.Minute)
+			err := m.Reenter(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLost, isClosed(m.Lost()))
+		})
+	}
+}
+
+func TestMutex_AutoRefresh(t *testing.T) {
+	t.Run("lost", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cmd := redismocks.NewMockCmdable(ctrl)
+		gomock.InOrder(
+			cmd.EXPECT().Eval(gomock.Any(), luaRefresh, []string{"lock:test"}, "token", int64(60000)).
+				Return(redis.NewCmdResult(int64(1), nil)),
+			cmd.EXPECT().Eval(gomock.Any(), luaRefresh, []string{"lock:test"}, "token", int64(60000)).
+				Return(redis.NewCmdResult(int64(0), nil)),
+		)
+		m := newMutex(cmd, "lock:test", "token", time.Minute)
+		err := m.AutoRefresh(context.Background(), time.Millisecond*10, time.Second)
+		assert.Equal(t, ErrLockNotHold, err)
+		assert.True(t, isClosed(m.Lost()))
+	})
+
+	t.Run("ctx done", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cmd := redismocks.NewMockCmdable(ctrl)
+		m := newMutex(cmd, "lock:test", "token", time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		err := m.AutoRefresh(ctx, time.Minute, time.Second)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.False(t, isClosed(m.Lost()))
+	})
+}
+
+func TestExponentialRetry_Next(t *testing.T) {
+	retry := ExponentialRetry{Initial: time.Second, MaxInterval: time.Second * 5, MaxRetries: 5}
+	wants := []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 5, time.Second * 5}
+	for i, want := range wants {
+		interval, ok := retry.Next(i + 1)
+		assert.True(t, ok)
+		assert.Equal(t, want, interval)
+	}
+	_, ok := retry.Next(6)
+	assert.False(t, ok)
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,34 @@
+package lock
+
+import "time"
+
+// FixedIntervalRetry 每次间隔相同的时间重试，最多重试 MaxRetries 次
+type FixedIntervalRetry struct {
+	Interval   time.Duration
+	MaxRetries int
+}
+
+func (f FixedIntervalRetry) Next(retries int) (time.Duration, bool) {
+	return f.Interval, retries <= f.MaxRetries
+}
+
+// ExponentialRetry 重试间隔从 Initial 开始翻倍，不超过 MaxInterval，最多重试 MaxRetries 次
+type ExponentialRetry struct {
+	Initial     time.Duration
+	MaxInterval time.Duration
+	MaxRetries  int
+}
+
+func (e ExponentialRetry) Next(retries int) (time.Duration, bool) {
+	if retries > e.MaxRetries {
+		return 0, false
+	}
+	interval := e.Initial
+	for i := 1; i < retries && (e.MaxInterval <= 0 || interval < e.MaxInterval); i++ {
+		interval *= 2
+	}
+	if e.MaxInterval > 0 && interval > e.MaxInterval {
+		interval = e.MaxInterval
+	}
+	return interval, true
+}
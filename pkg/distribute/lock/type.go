@@ -1,9 +1,26 @@
 package lock
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
-type Lock interface {
-	AcquireLock(key string, ttl time.Duration) (bool, error)
-	ReleaseLock(key string) error
-	AutoRefresh(key string, ttl time.Duration, interval time.Duration) error
+var (
+	ErrLockPreempted = errors.New("lock is held by others")
+	ErrLockNotHold   = errors.New("lock is not held")
+)
+
+// Client 加锁成功返回带唯一 token 的 Mutex，只有持有 token 的才能续约和释放
+type Client interface {
+	// TryLock 只尝试一次，锁被别人持有返回 ErrLockPreempted
+	TryLock(ctx context.Context, key string, ttl time.Duration) (*Mutex, error)
+	// Lock 加锁失败按照 retry 重试，retry 为 nil 的时候和 TryLock 一样
+	Lock(ctx context.Context, key string, ttl time.Duration, retry RetryStrategy) (*Mutex, error)
+}
+
+// RetryStrategy 加锁的重试策略
+type RetryStrategy interface {
+	// Next 返回第 retries 次重试之前要等多久，false 表示不再重试
+	Next(retries int) (time.Duration, bool)
 }
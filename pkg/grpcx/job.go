@@ -0,0 +1,41 @@
+package grpcx
+
+import (
+	"context"
+
+	jobv1 "github.com/misakimei123/redbook/api/proto/gen/job/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// JobExecutorServer 托管任务的服务用它实现 JobExecutorService，按任务的名字注册处理函数
+type JobExecutorServer struct {
+	jobv1.UnimplementedJobExecutorServiceServer
+	funcs map[string]func(ctx context.Context, cfg string) error
+}
+
+func NewJobExecutorServer() *JobExecutorServer {
+	return &JobExecutorServer{funcs: make(map[string]func(ctx context.Context, cfg string) error)}
+}
+
+// RegisterFunc 要在 Serve 之前注册
+func (j *JobExecutorServer) RegisterFunc(name string, fn func(ctx context.Context, cfg string) error) {
+	j.funcs[name] = fn
+}
+
+func (j *JobExecutorServer) Register(server *grpc.Server) {
+	jobv1.RegisterJobExecutorServiceServer(server, j)
+}
+
+func (j *JobExecutorServer) Execute(ctx context.Context, req *jobv1.ExecuteRequest) (*jobv1.ExecuteResponse, error) {
+	fn, ok := j.funcs[req.GetName()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "not register job func %s", req.GetName())
+	}
+	err := fn(ctx, req.GetCfg())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &jobv1.ExecuteResponse{}, nil
+}
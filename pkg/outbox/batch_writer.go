@@ -0,0 +1,152 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrBufferFull 缓冲区满了，消息被丢弃
+	ErrBufferFull = errors.New("outbox buffer is full")
+	// ErrWriterClosed Close 之后不再接收消息
+	ErrWriterClosed = errors.New("outbox writer is closed")
+)
+
+// BatchWriter 攒一批消息再一次写进 outbox，给阅读事件这种量大、没有业务事务的场景用。
+// Write 不会阻塞调用方，缓冲区满了直接丢弃并计数；Close 把缓冲区里面剩下的写完
+type BatchWriter struct {
+	db     *gorm.DB
+	l      logger.LoggerV1
+	vector *prometheus.CounterVec
+	msgs   chan Message
+
+	// mu 保证 Close 之后不会再有消息进入 msgs
+	mu     sync.RWMutex
+	closed bool
+	stop   chan struct{}
+	done   chan struct{}
+
+	batchSize int
+	interval  time.Duration
+	timeout   time.Duration
+}
+
+func NewBatchWriter(db *gorm.DB, l logger.LoggerV1, opts prometheus.CounterOpts) *BatchWriter {
+	vec := prometheus.NewCounterVec(opts, []string{"topic", "result"})
+	prometheus.MustRegister(vec)
+	w := &BatchWriter{
+		db:        db,
+		l:         l,
+		vector:    vec,
+		msgs:      make(chan Message, 10000),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		batchSize: 200,
+		interval:  time.Millisecond * 200,
+		timeout:   time.Second,
+	}
+	go w.run()
+	return w
+}
+
+func (w *BatchWriter) Write(topic string, key string, event any) error {
+	val, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	msg := Message{
+		Topic:  topic,
+		Key:    key,
+		Value:  val,
+		Status: StatusPending,
+		Ctime:  now,
+		Utime:  now,
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return ErrWriterClosed
+	}
+	select {
+	case w.msgs <- msg:
+		return nil
+	default:
+		w.vector.WithLabelValues(topic, "dropped").Inc()
+		return ErrBufferFull
+	}
+}
+
+// Close 等缓冲区里面的消息写完，ctx 超时了就不等了
+func (w *BatchWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.stop)
+	}
+	w.mu.Unlock()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *BatchWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	batch := make([]Message, 0, w.batchSize)
+	for {
+		select {
+		case msg := <-w.msgs:
+			batch = append(batch, msg)
+			if len(batch) < w.batchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-w.stop:
+			// Close 之后 msgs 不会再有新消息，取完就退出
+			for {
+				select {
+				case msg := <-w.msgs:
+					batch = append(batch, msg)
+					if len(batch) >= w.batchSize {
+						batch = w.flush(batch)
+					}
+				default:
+					w.flush(batch)
+					return
+				}
+			}
+		}
+		batch = w.flush(batch)
+	}
+}
+
+// flush 写失败了只记录下来，不重试，免得数据库出问题的时候越攒越多
+func (w *BatchWriter) flush(batch []Message) []Message {
+	if len(batch) == 0 {
+		return batch
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+	err := w.db.WithContext(ctx).Create(&batch).Error
+	result := "saved"
+	if err != nil {
+		result = "fail"
+		w.l.Error("outbox batch write fail", logger.Int64("size", int64(len(batch))), logger.Error(err))
+	}
+	for _, msg := range batch {
+		w.vector.WithLabelValues(msg.Topic, result).Inc()
+	}
+	return batch[:0]
+}
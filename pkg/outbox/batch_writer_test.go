@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestBatchWriter(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	// 两条消息在 Close 的时候一次写进去
+	mock.ExpectExec("INSERT INTO `outbox_messages` .* VALUES \\(.*\\),\\(.*\\)").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      conn,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	assert.NoError(t, err)
+
+	w := &BatchWriter{
+		db:        db,
+		l:         logger.NewNopLogger(),
+		vector:    prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test"}, []string{"topic", "result"}),
+		msgs:      make(chan Message, 2),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		batchSize: 10,
+		interval:  time.Hour,
+		timeout:   time.Second,
+	}
+	assert.NoError(t, w.Write("article_read", "", map[string]int64{"Aid": 1}))
+	assert.NoError(t, w.Write("article_read", "", map[string]int64{"Aid": 2}))
+	// 缓冲区满了不阻塞，直接丢
+	assert.Equal(t, ErrBufferFull, w.Write("article_read", "", map[string]int64{"Aid": 3}))
+
+	go w.run()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, w.Close(ctx))
+	assert.Equal(t, ErrWriterClosed, w.Write("article_read", "", map[string]int64{"Aid": 4}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
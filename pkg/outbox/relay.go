@@ -0,0 +1,189 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/misakimei123/redbook/pkg/distribute/lock"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// Relay 把 outbox 里面待发送的消息投递到 kafka，
+// 多个实例通过分布式锁选出一个来发，按 id 顺序发送
+type Relay struct {
+	db       *gorm.DB
+	producer sarama.SyncProducer
+	client   lock.Client
+	// mutex 不为 nil 表示当前实例是 leader，只在 Start 的 goroutine 里面读写
+	mutex       *lock.Mutex
+	stopRefresh context.CancelFunc
+	l           logger.LoggerV1
+	vector      *prometheus.CounterVec
+	key         string
+	ttl         time.Duration
+	interval    time.Duration
+	batchSize   int
+	maxRetries  int
+}
+
+func NewRelay(db *gorm.DB, producer sarama.SyncProducer, client lock.Client,
+	l logger.LoggerV1, opts prometheus.CounterOpts) *Relay {
+	vec := prometheus.NewCounterVec(opts, []string{"topic", "result"})
+	prometheus.MustRegister(vec)
+	return &Relay{
+		db:         db,
+		producer:   producer,
+		client:     client,
+		l:          l,
+		vector:     vec,
+		key:        "lock:outbox:relay",
+		ttl:        time.Second * 30,
+		interval:   time.Second,
+		batchSize:  100,
+		maxRetries: 5,
+	}
+}
+
+// Start 阻塞直到 ctx 结束，退出的时候释放锁
+func (r *Relay) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	defer r.release()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if !r.tryLead(ctx) {
+			continue
+		}
+		err := r.relayWhileLeading(ctx)
+		if err != nil {
+			r.l.Error("outbox relay fail", logger.Error(err))
+		}
+	}
+}
+
+// relayWhileLeading 锁丢了马上停下来，让新的 leader 去发
+func (r *Relay) relayWhileLeading(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	lost := r.mutex.Lost()
+	go func() {
+		select {
+		case <-lost:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return r.relay(ctx)
+}
+
+func (r *Relay) tryLead(ctx context.Context) bool {
+	if r.mutex != nil {
+		select {
+		case <-r.mutex.Lost():
+			r.l.Error("outbox lock lost", logger.String("key", r.key))
+			r.stopRefresh()
+			r.mutex, r.stopRefresh = nil, nil
+		default:
+			return true
+		}
+	}
+	lockCtx, cancel := context.WithTimeout(ctx, time.Second)
+	m, err := r.client.TryLock(lockCtx, r.key, r.ttl)
+	cancel()
+	if errors.Is(err, lock.ErrLockPreempted) {
+		return false
+	}
+	if err != nil {
+		r.l.Error("acquire outbox lock fail", logger.Error(err))
+		return false
+	}
+	r.l.Info("outbox relay got lock")
+	refreshCtx, stop := context.WithCancel(ctx)
+	r.mutex, r.stopRefresh = m, stop
+	go func() {
+		// 续约失败会把锁标记成丢了，Start 里面通过 Lost 发现
+		er := m.AutoRefresh(refreshCtx, r.ttl/2, time.Second)
+		if er != nil && refreshCtx.Err() == nil {
+			r.l.Error("outbox lock refresh fail", logger.Error(er))
+		}
+	}()
+	return true
+}
+
+func (r *Relay) release() {
+	if r.mutex == nil {
+		return
+	}
+	r.stopRefresh()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := r.mutex.Unlock(ctx)
+	r.mutex, r.stopRefresh = nil, nil
+	if err != nil {
+		r.l.Error("release outbox lock fail", logger.Error(err))
+	}
+}
+
+// relay 一批一批地发，直到发完或者遇到要重试的消息
+func (r *Relay) relay(ctx context.Context) error {
+	for {
+		var msgs []Message
+		err := r.db.WithContext(ctx).Where("status = ?", StatusPending).
+			Order("id ASC").Limit(r.batchSize).Find(&msgs).Error
+		if err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			blocked, er := r.send(ctx, msg)
+			if er != nil || blocked {
+				return er
+			}
+		}
+		if len(msgs) < r.batchSize {
+			return nil
+		}
+	}
+}
+
+// send 发送失败的时候返回 blocked，后面的消息等下一轮，保证同一个 key 的顺序
+func (r *Relay) send(ctx context.Context, msg Message) (bool, error) {
+	pm := &sarama.ProducerMessage{
+		Topic: msg.Topic,
+		Value: sarama.ByteEncoder(msg.Value),
+	}
+	if msg.Key != "" {
+		pm.Key = sarama.StringEncoder(msg.Key)
+	}
+	_, _, err := r.producer.SendMessage(pm)
+	if err == nil {
+		r.vector.WithLabelValues(msg.Topic, "success").Inc()
+		return false, r.db.WithContext(ctx).Delete(&Message{}, msg.Id).Error
+	}
+	r.l.Error("outbox send fail",
+		logger.Int64("id", msg.Id),
+		logger.String("topic", msg.Topic),
+		logger.Int64("retries", int64(msg.Retries)),
+		logger.Error(err))
+	updates := map[string]any{
+		"retries": msg.Retries + 1,
+		"utime":   time.Now().UnixMilli(),
+	}
+	blocked := true
+	if msg.Retries+1 >= r.maxRetries {
+		// 不能一直卡住后面的消息
+		updates["status"] = StatusFailed
+		blocked = false
+		r.vector.WithLabelValues(msg.Topic, "failed").Inc()
+	} else {
+		r.vector.WithLabelValues(msg.Topic, "retry").Inc()
+	}
+	return blocked, r.db.WithContext(ctx).Model(&Message{}).Where("id = ?", msg.Id).Updates(updates).Error
+}
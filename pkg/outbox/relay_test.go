@@ -0,0 +1,157 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/misakimei123/redbook/internal/repository/cache/redismocks"
+	"github.com/misakimei123/redbook/pkg/distribute/lock"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestRelay_Relay(t *testing.T) {
+	columns := []string{"id", "topic", "key", "value", "status", "retries"}
+	testCases := []struct {
+		name     string
+		sqlmock  func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		producer func(t *testing.T) sarama.SyncProducer
+		wantErr  error
+	}{
+		{
+			name: "send and delete",
+			sqlmock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `outbox_messages` WHERE status = .* ORDER BY id ASC LIMIT \\?").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "article_read", "", []byte(`{"Aid":1}`), StatusPending, 0).
+						AddRow(2, "article_sync", "1", []byte(`{"Aid":1}`), StatusPending, 0))
+				mock.ExpectExec("DELETE FROM `outbox_messages` WHERE `outbox_messages`.`id` = \\?").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `outbox_messages` WHERE `outbox_messages`.`id` = \\?").
+					WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				return db, mock
+			},
+			producer: func(t *testing.T) sarama.SyncProducer {
+				producer := mocks.NewSyncProducer(t, nil)
+				producer.ExpectSendMessageAndSucceed()
+				producer.ExpectSendMessageAndSucceed()
+				return producer
+			},
+		},
+		{
+			name: "send fail, later messages wait",
+			sqlmock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `outbox_messages` .*").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "article_sync", "1", []byte(`{"Aid":1}`), StatusPending, 0).
+						AddRow(2, "article_sync", "1", []byte(`{"Aid":1}`), StatusPending, 0))
+				mock.ExpectExec("UPDATE `outbox_messages` SET `retries`=\\?,`utime`=\\? WHERE id = \\?").
+					WithArgs(1, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				return db, mock
+			},
+			producer: func(t *testing.T) sarama.SyncProducer {
+				producer := mocks.NewSyncProducer(t, nil)
+				producer.ExpectSendMessageAndFail(errors.New("mock kafka fail"))
+				return producer
+			},
+		},
+		{
+			name: "retries exhausted, mark failed and go on",
+			sqlmock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `outbox_messages` .*").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "article_sync", "1", []byte(`{"Aid":1}`), StatusPending, 2).
+						AddRow(2, "article_sync", "1", []byte(`{"Aid":1}`), StatusPending, 0))
+				mock.ExpectExec("UPDATE `outbox_messages` SET `retries`=\\?,`status`=\\?,`utime`=\\? WHERE id = \\?").
+					WithArgs(3, StatusFailed, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `outbox_messages` .*").
+					WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				return db, mock
+			},
+			producer: func(t *testing.T) sarama.SyncProducer {
+				producer := mocks.NewSyncProducer(t, nil)
+				producer.ExpectSendMessageAndFail(errors.New("mock kafka fail"))
+				producer.ExpectSendMessageAndSucceed()
+				return producer
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, mock := tc.sqlmock(t)
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      conn,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				SkipDefaultTransaction: true,
+				DisableAutomaticPing:   true,
+			})
+			assert.NoError(t, err)
+			producer := tc.producer(t)
+			r := &Relay{
+				db:         db,
+				producer:   producer,
+				l:          logger.NewNopLogger(),
+				vector:     prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test"}, []string{"topic", "result"}),
+				batchSize:  10,
+				maxRetries: 3,
+			}
+			err = r.relay(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, producer.Close())
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// 续约发现锁丢了之后不再当 leader，要重新抢锁
+func TestRelay_TryLead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	gomock.InOrder(
+		// 加锁
+		cmd.EXPECT().Eval(gomock.Any(), gomock.Any(), []string{"lock:outbox:relay"}, gomock.Any(), gomock.Any()).
+			Return(redis.NewCmdResult(int64(1), nil)),
+		// 续约的时候锁已经是别人的了
+		cmd.EXPECT().Eval(gomock.Any(), gomock.Any(), []string{"lock:outbox:relay"}, gomock.Any(), gomock.Any()).
+			Return(redis.NewCmdResult(int64(0), nil)),
+		// 重新抢锁没抢到
+		cmd.EXPECT().Eval(gomock.Any(), gomock.Any(), []string{"lock:outbox:relay"}, gomock.Any(), gomock.Any()).
+			Return(redis.NewCmdResult(int64(0), nil)),
+	)
+	r := &Relay{
+		client: lock.NewRedisClient(cmd),
+		l:      logger.NewNopLogger(),
+		key:    "lock:outbox:relay",
+		ttl:    time.Millisecond * 20,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.True(t, r.tryLead(ctx))
+	select {
+	case <-r.mutex.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("lock should be lost")
+	}
+	assert.False(t, r.tryLead(ctx))
+	assert.Nil(t, r.mutex)
+}
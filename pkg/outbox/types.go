@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusPending uint8 = iota
+	// StatusFailed 重试次数用完了，留着人工处理
+	StatusFailed
+)
+
+// Message outbox 表，发送成功之后就删掉了
+type Message struct {
+	Id      int64  `gorm:"primaryKey, autoIncrement"`
+	Topic   string `gorm:"type:varchar(255)"`
+	Key     string `gorm:"type:varchar(255)"`
+	Value   []byte `gorm:"type:BLOB"`
+	Status  uint8  `gorm:"index:status_id,priority:1"`
+	Retries int
+	Ctime   int64
+	Utime   int64
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// Save 把事件序列化之后写进 outbox，tx 传业务自己的事务，
+// 这样业务改动和事件要么都成功要么都失败
+func Save(ctx context.Context, tx *gorm.DB, topic string, key string, event any) error {
+	val, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	return tx.WithContext(ctx).Create(&Message{
+		Topic:  topic,
+		Key:    key,
+		Value:  val,
+		Status: StatusPending,
+		Ctime:  now,
+		Utime:  now,
+	}).Error
+}
@@ -3,7 +3,6 @@ package saramax
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -11,50 +10,123 @@ import (
 )
 
 type BatchHandler[T any] struct {
-	l  logger.LoggerV1
-	fn func(messages []*sarama.ConsumerMessage, event []T) error
+	l    logger.LoggerV1
+	fn   func(messages []*sarama.ConsumerMessage, event []T) error
+	opts options
 }
 
 func (b *BatchHandler[T]) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	messages := claim.Messages()
-	const batchSize = 10
-
 	for {
-		batch := make([]*sarama.ConsumerMessage, 0, batchSize)
-		ts := make([]T, 0, batchSize)
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		batch, done := b.collect(session.Context(), messages)
+		// 没有消息就不调用 fn
+		if len(batch) > 0 {
+			if err := b.handle(session, batch); err != nil {
+				return err
+			}
+		}
+		if done || session.Context().Err() != nil {
+			// session 结束了，手上这一批已经处理并提交，或者留给下一个消费者
+			return nil
+		}
+	}
+}
 
-		var done = false
-		for i := 0; i < batchSize && !done; i++ {
-			select {
-			case <-ctx.Done():
-				// 超时了
-				done = true
-			case msg, ok := <-messages:
-				if !ok {
-					cancel()
-					return errors.New("chanel closed")
-				}
-				batch = append(batch, msg)
-				var t T
-				err := json.Unmarshal(msg.Value, &t)
-				if err != nil {
-					b.l.Error("unmarshal fail ", logger.Error(err))
-					continue
-				}
-				ts = append(ts, t)
+// collect 凑一批消息，done 表示 session 结束或者 channel 关闭了
+func (b *BatchHandler[T]) collect(ctx context.Context, messages <-chan *sarama.ConsumerMessage) ([]*sarama.ConsumerMessage, bool) {
+	batch := make([]*sarama.ConsumerMessage, 0, b.opts.batchSize)
+	size := 0
+	// 第一条消息到了才开始计时
+	var timeout <-chan time.Time
+	for len(batch) < b.opts.batchSize {
+		select {
+		case <-ctx.Done():
+			return batch, true
+		case <-timeout:
+			return batch, false
+		case msg, ok := <-messages:
+			if !ok {
+				return batch, true
+			}
+			if len(batch) == 0 {
+				timer := time.NewTimer(b.opts.maxWait)
+				defer timer.Stop()
+				timeout = timer.C
+			}
+			batch = append(batch, msg)
+			size += len(msg.Value)
+			if b.opts.maxBytes > 0 && size >= b.opts.maxBytes {
+				return batch, false
 			}
 		}
-		cancel()
-		// 凑够了一批，然后处理
-		err := b.fn(batch, ts)
+	}
+	return batch, false
+}
+
+func (b *BatchHandler[T]) handle(session sarama.ConsumerGroupSession, batch []*sarama.ConsumerMessage) error {
+	// 解析成功的消息，和 ts 一一对应
+	parsed := make([]*sarama.ConsumerMessage, 0, len(batch))
+	ts := make([]T, 0, len(batch))
+	for _, msg := range batch {
+		var t T
+		err := json.Unmarshal(msg.Value, &t)
 		if err != nil {
-			b.l.Error("handle event fail ", logger.Error(err))
+			b.l.Error("unmarshal fail ",
+				logger.String("topic", msg.Topic),
+				logger.Int32("partition", msg.Partition),
+				logger.Int64("offset", msg.Offset),
+				logger.Error(err))
+			if er := b.deadLetter(msg, err, 0); er != nil {
+				return er
+			}
+			continue
+		}
+		parsed = append(parsed, msg)
+		ts = append(ts, t)
+	}
+	if len(parsed) > 0 {
+		attempts, err := b.opts.retry.do(session.Context(), func() error {
+			return b.fn(parsed, ts)
+		})
+		if err != nil && session.Context().Err() != nil {
+			// 重平衡或者退出了，整批都不提交，交给下一个消费者重新消费
+			b.l.Warn("session closed while retrying ",
+				logger.Int64("attempts", int64(attempts)),
+				logger.Error(err))
+			return nil
 		}
-		for _, msg := range batch {
-			session.MarkMessage(msg, "")
+		if err != nil {
+			b.l.Error("handle event fail ",
+				logger.Int64("attempts", int64(attempts)),
+				logger.Error(err))
+			// 整批失败，只能整批进死信
+			for _, msg := range parsed {
+				if er := b.deadLetter(msg, err, attempts); er != nil {
+					return er
+				}
+			}
 		}
 	}
+	for _, msg := range batch {
+		session.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+func (b *BatchHandler[T]) deadLetter(msg *sarama.ConsumerMessage, cause error, attempts int) error {
+	if b.opts.dlq == nil {
+		return nil
+	}
+	err := sendDeadLetter(b.opts.dlq, msg, cause, attempts)
+	if err != nil {
+		// 死信也发不出去，就不提交，等重新分配之后再消费
+		b.l.Error("send dead letter fail ",
+			logger.String("topic", msg.Topic),
+			logger.Int32("partition", msg.Partition),
+			logger.Int64("offset", msg.Offset),
+			logger.Error(err))
+	}
+	return err
 }
 
 func (b *BatchHandler[T]) Setup(session sarama.ConsumerGroupSession) error {
@@ -65,6 +137,6 @@ func (b *BatchHandler[T]) Cleanup(session sarama.ConsumerGroupSession) error {
 	return nil
 }
 
-func NewBatchHandler[T any](l logger.LoggerV1, fn func(messages []*sarama.ConsumerMessage, event []T) error) sarama.ConsumerGroupHandler {
-	return &BatchHandler[T]{l: l, fn: fn}
+func NewBatchHandler[T any](l logger.LoggerV1, fn func(messages []*sarama.ConsumerMessage, event []T) error, opts ...Option) sarama.ConsumerGroupHandler {
+	return &BatchHandler[T]{l: l, fn: fn, opts: newOptions(opts)}
 }
@@ -0,0 +1,123 @@
+package saramax
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchHandler_ConsumeClaim(t *testing.T) {
+	testCases := []struct {
+		name string
+		cnt  int
+		// 消费完之后是否关闭 channel
+		closed  bool
+		timeout time.Duration
+		opts    []Option
+
+		wantBatches []int
+		wantMarked  int
+	}{
+		{
+			name:        "flush by batch size",
+			cnt:         5,
+			closed:      true,
+			opts:        []Option{WithBatchSize(2)},
+			wantBatches: []int{2, 2, 1},
+			wantMarked:  5,
+		},
+		{
+			name:   "flush by max bytes",
+			cnt:    3,
+			closed: true,
+			// 每条消息 9 个字节
+			opts:        []Option{WithMaxBytes(10)},
+			wantBatches: []int{2, 1},
+			wantMarked:  3,
+		},
+		{
+			name:        "skip empty batch",
+			closed:      true,
+			wantBatches: []int{},
+		},
+		{
+			name:        "flush by max wait",
+			cnt:         1,
+			timeout:     time.Millisecond * 100,
+			opts:        []Option{WithMaxWait(time.Millisecond * 10)},
+			wantBatches: []int{1},
+			wantMarked:  1,
+		},
+		{
+			name:        "session done, handle current batch",
+			cnt:         2,
+			timeout:     time.Millisecond * 20,
+			opts:        []Option{WithMaxWait(time.Hour)},
+			wantBatches: []int{2},
+			wantMarked:  2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			batches := []int{}
+			h := NewBatchHandler[testEvent](logger.NewNopLogger(),
+				func(messages []*sarama.ConsumerMessage, events []testEvent) error {
+					batches = append(batches, len(events))
+					return nil
+				}, tc.opts...)
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+			session := &mockSession{ctx: ctx}
+			ch := make(chan *sarama.ConsumerMessage, tc.cnt)
+			for i := 0; i < tc.cnt; i++ {
+				ch <- &sarama.ConsumerMessage{Topic: "article_read", Offset: int64(i), Value: []byte(`{"Aid":1}`)}
+			}
+			if tc.closed {
+				close(ch)
+			}
+			err := h.ConsumeClaim(session, &mockClaim{messages: ch})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantBatches, batches)
+			assert.Equal(t, tc.wantMarked, len(session.marked))
+		})
+	}
+}
+
+// 等待重试的时候 session 结束了，整批既不进死信也不提交
+func TestBatchHandler_ConsumeClaimSessionClosed(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cnt := 0
+	h := NewBatchHandler[testEvent](logger.NewNopLogger(),
+		func(messages []*sarama.ConsumerMessage, events []testEvent) error {
+			cnt++
+			// 模拟重平衡
+			cancel()
+			return errors.New("mock error")
+		}, WithBatchSize(2), WithRetry(RetryPolicy{
+			MaxAttempts: 3,
+			Backoff:     ExponentialBackoff(time.Hour, time.Hour),
+		}), WithDeadLetter(producer))
+	session := &mockSession{ctx: ctx}
+	ch := make(chan *sarama.ConsumerMessage, 2)
+	for i := 0; i < 2; i++ {
+		ch <- &sarama.ConsumerMessage{Topic: "article_read", Offset: int64(i), Value: []byte(`{"Aid":1}`)}
+	}
+	err := h.ConsumeClaim(session, &mockClaim{messages: ch})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cnt)
+	assert.Equal(t, 0, len(session.marked))
+	assert.NoError(t, producer.Close())
+}
@@ -0,0 +1,55 @@
+package saramax
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	dlqSuffix = ".dlq"
+
+	HeaderDLQError     = "x-dlq-error"
+	HeaderDLQAttempts  = "x-dlq-attempts"
+	HeaderDLQTopic     = "x-dlq-origin-topic"
+	HeaderDLQPartition = "x-dlq-origin-partition"
+	HeaderDLQOffset    = "x-dlq-origin-offset"
+)
+
+// DLQTopic 死信队列的 topic
+func DLQTopic(topic string) string {
+	return topic + dlqSuffix
+}
+
+// sendDeadLetter 原始消息原样投递到死信队列，出错原因和尝试次数放在 header 里
+func sendDeadLetter(producer sarama.SyncProducer, msg *sarama.ConsumerMessage, cause error, attempts int) error {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+5)
+	for _, h := range msg.Headers {
+		if h == nil || isDLQHeader(h.Key) {
+			continue
+		}
+		headers = append(headers, *h)
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(HeaderDLQError), Value: []byte(cause.Error())},
+		sarama.RecordHeader{Key: []byte(HeaderDLQAttempts), Value: []byte(strconv.Itoa(attempts))},
+		sarama.RecordHeader{Key: []byte(HeaderDLQTopic), Value: []byte(msg.Topic)},
+		sarama.RecordHeader{Key: []byte(HeaderDLQPartition), Value: []byte(strconv.FormatInt(int64(msg.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(HeaderDLQOffset), Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+	)
+	pm := &sarama.ProducerMessage{
+		Topic:   DLQTopic(msg.Topic),
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	}
+	if msg.Key != nil {
+		pm.Key = sarama.ByteEncoder(msg.Key)
+	}
+	_, _, err := producer.SendMessage(pm)
+	return err
+}
+
+func isDLQHeader(key []byte) bool {
+	return strings.HasPrefix(string(key), "x-dlq-")
+}
@@ -8,8 +8,9 @@ import (
 )
 
 type Handler[T any] struct {
-	fn func(msg *sarama.ConsumerMessage, event T) error
-	l  logger.LoggerV1
+	fn   func(msg *sarama.ConsumerMessage, event T) error
+	l    logger.LoggerV1
+	opts options
 }
 
 // Cleanup implements sarama.ConsumerGroupHandler.
@@ -23,20 +24,47 @@ func (h *Handler[T]) ConsumeClaim(session sarama.ConsumerGroupSession, claim sar
 	for message := range messages {
 		var t T
 		err := json.Unmarshal(message.Value, &t)
+		attempts := 0
 		if err != nil {
+			// 解析不了的消息重试也没用
 			h.l.Error("unmarshal fail ",
 				logger.String("topic", message.Topic),
 				logger.Int32("partition", message.Partition),
 				logger.Int64("offset", message.Offset),
 				logger.Error(err))
+		} else {
+			attempts, err = h.opts.retry.do(session.Context(), func() error {
+				return h.fn(message, t)
+			})
+			if err != nil && session.Context().Err() != nil {
+				// 重平衡或者退出了，不进死信也不提交，交给下一个消费者重新消费
+				h.l.Warn("session closed while retrying ",
+					logger.String("topic", message.Topic),
+					logger.Int32("partition", message.Partition),
+					logger.Int64("offset", message.Offset),
+					logger.Error(err))
+				return nil
+			}
+			if err != nil {
+				h.l.Error("consume fail ",
+					logger.String("topic", message.Topic),
+					logger.Int32("partition", message.Partition),
+					logger.Int64("offset", message.Offset),
+					logger.Int64("attempts", int64(attempts)),
+					logger.Error(err))
+			}
 		}
-		err = h.fn(message, t)
-		if err != nil {
-			h.l.Error("consume fail ",
-				logger.String("topic", message.Topic),
-				logger.Int32("partition", message.Partition),
-				logger.Int64("offset", message.Offset),
-				logger.Error(err))
+		if err != nil && h.opts.dlq != nil {
+			er := sendDeadLetter(h.opts.dlq, message, err, attempts)
+			if er != nil {
+				// 死信也发不出去，就不提交，等重新分配之后再消费
+				h.l.Error("send dead letter fail ",
+					logger.String("topic", message.Topic),
+					logger.Int32("partition", message.Partition),
+					logger.Int64("offset", message.Offset),
+					logger.Error(er))
+				return er
+			}
 		}
 		session.MarkMessage(message, "")
 	}
@@ -48,6 +76,6 @@ func (h *Handler[T]) Setup(session sarama.ConsumerGroupSession) error {
 	return nil
 }
 
-func NewHandler[T any](l logger.LoggerV1, fn func(msg *sarama.ConsumerMessage, event T) error) sarama.ConsumerGroupHandler {
-	return &Handler[T]{fn: fn, l: l}
+func NewHandler[T any](l logger.LoggerV1, fn func(msg *sarama.ConsumerMessage, event T) error, opts ...Option) sarama.ConsumerGroupHandler {
+	return &Handler[T]{fn: fn, l: l, opts: newOptions(opts)}
 }
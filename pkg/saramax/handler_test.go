@@ -0,0 +1,210 @@
+package saramax
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/misakimei123/redbook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	Aid int64
+}
+
+func TestHandler_ConsumeClaim(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		fn       func(cnt *int) func(msg *sarama.ConsumerMessage, event testEvent) error
+		producer func(t *testing.T) *mocks.SyncProducer
+		opts     func(producer sarama.SyncProducer) []Option
+
+		wantErr    error
+		wantCalled int
+		wantMarked int
+	}{
+		{
+			name:  "retry then success",
+			value: `{"Aid":1}`,
+			fn: func(cnt *int) func(msg *sarama.ConsumerMessage, event testEvent) error {
+				return func(msg *sarama.ConsumerMessage, event testEvent) error {
+					*cnt++
+					if *cnt < 2 {
+						return errors.New("mock error")
+					}
+					return nil
+				}
+			},
+			producer: func(t *testing.T) *mocks.SyncProducer {
+				return mocks.NewSyncProducer(t, nil)
+			},
+			opts: func(producer sarama.SyncProducer) []Option {
+				return []Option{WithRetry(RetryPolicy{MaxAttempts: 3}), WithDeadLetter(producer)}
+			},
+			wantCalled: 2,
+			wantMarked: 1,
+		},
+		{
+			name:  "retry exhausted, dead letter",
+			value: `{"Aid":1}`,
+			fn: func(cnt *int) func(msg *sarama.ConsumerMessage, event testEvent) error {
+				return func(msg *sarama.ConsumerMessage, event testEvent) error {
+					*cnt++
+					return errors.New("mock error")
+				}
+			},
+			producer: func(t *testing.T) *mocks.SyncProducer {
+				producer := mocks.NewSyncProducer(t, nil)
+				producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+					assert.Equal(t, "article_read.dlq", msg.Topic)
+					assert.Equal(t, map[string]string{
+						HeaderDLQError:     "mock error",
+						HeaderDLQAttempts:  "3",
+						HeaderDLQTopic:     "article_read",
+						HeaderDLQPartition: "0",
+						HeaderDLQOffset:    "10",
+					}, headerMap(msg.Headers))
+					return nil
+				})
+				return producer
+			},
+			opts: func(producer sarama.SyncProducer) []Option {
+				return []Option{WithRetry(RetryPolicy{MaxAttempts: 3}), WithDeadLetter(producer)}
+			},
+			wantCalled: 3,
+			wantMarked: 1,
+		},
+		{
+			name:  "unmarshal fail, dead letter without calling",
+			value: `{"Aid":`,
+			fn: func(cnt *int) func(msg *sarama.ConsumerMessage, event testEvent) error {
+				return func(msg *sarama.ConsumerMessage, event testEvent) error {
+					*cnt++
+					return nil
+				}
+			},
+			producer: func(t *testing.T) *mocks.SyncProducer {
+				producer := mocks.NewSyncProducer(t, nil)
+				producer.ExpectSendMessageAndSucceed()
+				return producer
+			},
+			opts: func(producer sarama.SyncProducer) []Option {
+				return []Option{WithRetry(RetryPolicy{MaxAttempts: 3}), WithDeadLetter(producer)}
+			},
+			wantMarked: 1,
+		},
+		{
+			name:  "dead letter fail, not marked",
+			value: `{"Aid":1}`,
+			fn: func(cnt *int) func(msg *sarama.ConsumerMessage, event testEvent) error {
+				return func(msg *sarama.ConsumerMessage, event testEvent) error {
+					*cnt++
+					return errors.New("mock error")
+				}
+			},
+			producer: func(t *testing.T) *mocks.SyncProducer {
+				producer := mocks.NewSyncProducer(t, nil)
+				producer.ExpectSendMessageAndFail(errors.New("mock kafka error"))
+				return producer
+			},
+			opts: func(producer sarama.SyncProducer) []Option {
+				return []Option{WithDeadLetter(producer)}
+			},
+			wantErr:    errors.New("mock kafka error"),
+			wantCalled: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			producer := tc.producer(t)
+			cnt := 0
+			h := NewHandler[testEvent](logger.NewNopLogger(), tc.fn(&cnt), tc.opts(producer)...)
+			session := &mockSession{ctx: context.Background()}
+			claim := newMockClaim(&sarama.ConsumerMessage{
+				Topic:  "article_read",
+				Offset: 10,
+				Value:  []byte(tc.value),
+			})
+			err := h.ConsumeClaim(session, claim)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCalled, cnt)
+			assert.Equal(t, tc.wantMarked, len(session.marked))
+			assert.NoError(t, producer.Close())
+		})
+	}
+}
+
+func headerMap(headers []sarama.RecordHeader) map[string]string {
+	res := make(map[string]string, len(headers))
+	for _, h := range headers {
+		res[string(h.Key)] = string(h.Value)
+	}
+	return res
+}
+
+type mockSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *mockSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.marked = append(s.marked, msg)
+}
+
+func (s *mockSession) Context() context.Context {
+	return s.ctx
+}
+
+type mockClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func newMockClaim(msgs ...*sarama.ConsumerMessage) *mockClaim {
+	ch := make(chan *sarama.ConsumerMessage, len(msgs))
+	for _, msg := range msgs {
+		ch <- msg
+	}
+	close(ch)
+	return &mockClaim{messages: ch}
+}
+
+func (c *mockClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+// 等待重试的时候 session 结束了，消息既不进死信也不提交
+func TestHandler_ConsumeClaimSessionClosed(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cnt := 0
+	h := NewHandler[testEvent](logger.NewNopLogger(), func(msg *sarama.ConsumerMessage, event testEvent) error {
+		cnt++
+		// 模拟重平衡
+		cancel()
+		return errors.New("mock error")
+	}, WithRetry(RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     ExponentialBackoff(time.Hour, time.Hour),
+	}), WithDeadLetter(producer))
+	session := &mockSession{ctx: ctx}
+	claim := newMockClaim(&sarama.ConsumerMessage{
+		Topic:  "article_read",
+		Offset: 10,
+		Value:  []byte(`{"Aid":1}`),
+	})
+	err := h.ConsumeClaim(session, claim)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cnt)
+	assert.Equal(t, 0, len(session.marked))
+	// 没有发死信
+	assert.NoError(t, producer.Close())
+}
@@ -0,0 +1,120 @@
+package saramax
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+type options struct {
+	// 下面三个只对 BatchHandler 生效，任意一个满足就处理这一批
+	batchSize int
+	maxWait   time.Duration
+	// maxBytes 为 0 就是不限制
+	maxBytes int
+
+	retry RetryPolicy
+	// dlq 为 nil 的时候，重试失败的消息只打日志
+	dlq sarama.SyncProducer
+}
+
+func newOptions(opts []Option) options {
+	res := options{
+		batchSize: 10,
+		maxWait:   time.Second,
+		retry:     RetryPolicy{MaxAttempts: 1},
+	}
+	for _, opt := range opts {
+		opt(&res)
+	}
+	return res
+}
+
+type Option func(o *options)
+
+// WithBatchSize 一批最多多少条消息
+func WithBatchSize(size int) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.batchSize = size
+		}
+	}
+}
+
+// WithMaxWait 从这一批的第一条消息开始，最多等多久
+func WithMaxWait(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.maxWait = d
+		}
+	}
+}
+
+// WithMaxBytes 一批消息的 value 加起来最多多少字节
+func WithMaxBytes(n int) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+// WithRetry 设置消费失败的重试策略
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		if policy.MaxAttempts < 1 {
+			policy.MaxAttempts = 1
+		}
+		o.retry = policy
+	}
+}
+
+// WithDeadLetter 重试用完之后，把原始消息投递到 <topic>.dlq
+func WithDeadLetter(producer sarama.SyncProducer) Option {
+	return func(o *options) {
+		o.dlq = producer
+	}
+}
+
+// RetryPolicy 消费失败之后的重试策略
+type RetryPolicy struct {
+	// MaxAttempts 总的尝试次数，包含第一次
+	MaxAttempts int
+	// Backoff 第 attempt 次失败之后，等多久再试。为 nil 就是立刻重试
+	Backoff func(attempt int) time.Duration
+}
+
+// ExponentialBackoff 从 initial 开始翻倍，最多等 max
+func ExponentialBackoff(initial, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := initial
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return d
+	}
+}
+
+// do 按照重试策略执行 fn，返回最后一次的错误和尝试的次数。
+// 等待重试的时候 ctx 结束了返回 ctx.Err()，调用方不能把消息当成重试失败处理
+func (p RetryPolicy) do(ctx context.Context, fn func() error) (int, error) {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= p.MaxAttempts {
+			return attempt, err
+		}
+		if p.Backoff == nil {
+			continue
+		}
+		timer := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package saramax
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/misakimei123/redbook/pkg/logger"
+)
+
+// Replayer 把死信队列里的消息重新投递回原来的 topic
+type Replayer struct {
+	client   sarama.Client
+	producer sarama.SyncProducer
+	// group 用来记录重放到哪里了，重复执行不会重复投递
+	group string
+	l     logger.LoggerV1
+}
+
+func NewReplayer(client sarama.Client, producer sarama.SyncProducer, group string, l logger.LoggerV1) *Replayer {
+	return &Replayer{client: client, producer: producer, group: group, l: l}
+}
+
+// Replay 重放 dlqTopic 里截至调用时刻的消息，返回重放的条数
+func (r *Replayer) Replay(ctx context.Context, dlqTopic string) (int, error) {
+	if !strings.HasSuffix(dlqTopic, dlqSuffix) {
+		return 0, fmt.Errorf("%s 不是死信队列", dlqTopic)
+	}
+	partitions, err := r.client.Partitions(dlqTopic)
+	if err != nil {
+		return 0, err
+	}
+	om, err := sarama.NewOffsetManagerFromClient(r.group, r.client)
+	if err != nil {
+		return 0, err
+	}
+	// Close 的时候会把标记的偏移量提交上去
+	defer om.Close()
+	consumer, err := sarama.NewConsumerFromClient(r.client)
+	if err != nil {
+		return 0, err
+	}
+	defer consumer.Close()
+
+	total := 0
+	for _, p := range partitions {
+		cnt, er := r.replayPartition(ctx, om, consumer, dlqTopic, p)
+		total += cnt
+		if er != nil {
+			return total, er
+		}
+	}
+	return total, nil
+}
+
+func (r *Replayer) replayPartition(ctx context.Context, om sarama.OffsetManager, consumer sarama.Consumer,
+	dlqTopic string, partition int32) (int, error) {
+	pom, err := om.ManagePartition(dlqTopic, partition)
+	if err != nil {
+		return 0, err
+	}
+	defer pom.Close()
+	next, _ := pom.NextOffset()
+	if next < 0 {
+		// 没有重放过，从头开始
+		next, err = r.client.GetOffset(dlqTopic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return 0, err
+		}
+	}
+	end, err := r.client.GetOffset(dlqTopic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, err
+	}
+	if next >= end {
+		return 0, nil
+	}
+	pc, err := consumer.ConsumePartition(dlqTopic, partition, next)
+	if err != nil {
+		return 0, err
+	}
+	defer pc.Close()
+
+	cnt := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return cnt, ctx.Err()
+		case msg, ok := <-pc.Messages():
+			if !ok {
+				return cnt, fmt.Errorf("partition %d 被关闭", partition)
+			}
+			_, _, err = r.producer.SendMessage(replayMessage(msg))
+			if err != nil {
+				return cnt, err
+			}
+			pom.MarkOffset(msg.Offset+1, "")
+			cnt++
+			if msg.Offset+1 >= end {
+				r.l.Info("replay dead letter done",
+					logger.String("topic", dlqTopic),
+					logger.Int32("partition", partition),
+					logger.Int64("count", int64(cnt)))
+				return cnt, nil
+			}
+		}
+	}
+}
+
+// replayMessage 去掉死信相关的 header，还原成原来的消息
+func replayMessage(msg *sarama.ConsumerMessage) *sarama.ProducerMessage {
+	topic := strings.TrimSuffix(msg.Topic, dlqSuffix)
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers))
+	for _, h := range msg.Headers {
+		if h == nil {
+			continue
+		}
+		if string(h.Key) == HeaderDLQTopic {
+			topic = string(h.Value)
+		}
+		if isDLQHeader(h.Key) {
+			continue
+		}
+		headers = append(headers, *h)
+	}
+	pm := &sarama.ProducerMessage{
+		Topic:   topic,
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	}
+	if msg.Key != nil {
+		pm.Key = sarama.ByteEncoder(msg.Key)
+	}
+	return pm
+}
@@ -0,0 +1,165 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type Field struct {
+	Text string
+	// Weight 词频按权重累加，比如标题可以给得比正文高
+	Weight float64
+}
+
+type Hit[T any] struct {
+	Id    int64
+	Score float64
+	Doc   T
+}
+
+type document[T any] struct {
+	version int64
+	// deleted 删除之后保留版本号，防止旧数据被重新写进来
+	deleted bool
+	length  float64
+	terms   map[string]float64
+	payload T
+}
+
+// Index 进程内的倒排索引，用 BM25 打分，并发安全
+type Index[T any] struct {
+	mu       sync.RWMutex
+	docs     map[int64]*document[T]
+	postings map[string]map[int64]float64
+	// totalLen 所有未删除文档的长度之和，用来算平均长度
+	totalLen float64
+	cnt      int
+}
+
+func NewIndex[T any]() *Index[T] {
+	return &Index[T]{
+		docs:     make(map[int64]*document[T]),
+		postings: make(map[string]map[int64]float64),
+	}
+}
+
+// Upsert 写入文档，version 比已有的旧就忽略，返回是否写入
+func (idx *Index[T]) Upsert(id int64, version int64, fields []Field, payload T) bool {
+	terms := make(map[string]float64)
+	var length float64
+	for _, f := range fields {
+		for _, token := range Tokenize(f.Text) {
+			terms[token] += f.Weight
+			length += f.Weight
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if old, ok := idx.docs[id]; ok {
+		if old.version > version {
+			return false
+		}
+		idx.remove(id, old)
+	}
+	idx.docs[id] = &document[T]{version: version, length: length, terms: terms, payload: payload}
+	for term, tf := range terms {
+		posting, ok := idx.postings[term]
+		if !ok {
+			posting = make(map[int64]float64)
+			idx.postings[term] = posting
+		}
+		posting[id] = tf
+	}
+	idx.totalLen += length
+	idx.cnt++
+	return true
+}
+
+// Delete 删除文档，version 比已有的旧就忽略
+func (idx *Index[T]) Delete(id int64, version int64) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	old, ok := idx.docs[id]
+	if ok {
+		if old.version > version {
+			return false
+		}
+		idx.remove(id, old)
+	}
+	idx.docs[id] = &document[T]{version: version, deleted: true}
+	return true
+}
+
+func (idx *Index[T]) remove(id int64, doc *document[T]) {
+	if doc.deleted {
+		return
+	}
+	for term := range doc.terms {
+		posting := idx.postings[term]
+		delete(posting, id)
+		if len(posting) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLen -= doc.length
+	idx.cnt--
+	doc.deleted = true
+}
+
+func (idx *Index[T]) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.cnt
+}
+
+// Search 按分数倒序返回 [offset, offset+limit) 的结果，以及命中的总数
+func (idx *Index[T]) Search(query string, offset int, limit int) ([]Hit[T], int) {
+	terms := make(map[string]struct{})
+	for _, token := range Tokenize(query) {
+		terms[token] = struct{}{}
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	if idx.cnt == 0 || len(terms) == 0 {
+		return nil, 0
+	}
+	avgLen := idx.totalLen / float64(idx.cnt)
+	scores := make(map[int64]float64)
+	for term := range terms {
+		posting := idx.postings[term]
+		if len(posting) == 0 {
+			continue
+		}
+		n := float64(len(posting))
+		idf := math.Log(1 + (float64(idx.cnt)-n+0.5)/(n+0.5))
+		for id, tf := range posting {
+			norm := 1 - bm25B + bm25B*idx.docs[id].length/avgLen
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	hits := make([]Hit[T], 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit[T]{Id: id, Score: score, Doc: idx.docs[id].payload})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Id > hits[j].Id
+	})
+	total := len(hits)
+	if offset >= total {
+		return nil, total
+	}
+	end := min(offset+limit, total)
+	return hits[offset:end], total
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "latin", text: "Hello, Go-Lang 123", want: []string{"hello", "go", "lang", "123"}},
+		{name: "cjk bigram", text: "分布式锁", want: []string{"分布", "布式", "式锁"}},
+		{name: "single cjk", text: "锁", want: []string{"锁"}},
+		{name: "mixed", text: "Redis分布式锁", want: []string{"redis", "分布", "布式", "式锁"}},
+		{name: "empty", text: " ,. ", want: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Tokenize(tc.text))
+		})
+	}
+}
+
+func TestIndex(t *testing.T) {
+	idx := NewIndex[string]()
+	idx.Upsert(1, 1, []Field{{Text: "Redis 分布式锁", Weight: 2}, {Text: "用 SETNX 实现", Weight: 1}}, "a")
+	idx.Upsert(2, 1, []Field{{Text: "MySQL 索引", Weight: 2}, {Text: "分布式事务和 Redis 没关系", Weight: 1}}, "b")
+	idx.Upsert(3, 1, []Field{{Text: "Kafka", Weight: 2}, {Text: "消息队列", Weight: 1}}, "c")
+
+	hits, total := idx.Search("redis 分布式", 0, 10)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, int64(1), hits[0].Id)
+	assert.Equal(t, "a", hits[0].Doc)
+	assert.Equal(t, int64(2), hits[1].Id)
+
+	hits, total = idx.Search("redis", 1, 10)
+	assert.Equal(t, 2, total)
+	assert.Len(t, hits, 1)
+
+	// 旧版本不会覆盖新版本
+	assert.False(t, idx.Upsert(1, 0, []Field{{Text: "Kafka", Weight: 1}}, "old"))
+	assert.True(t, idx.Delete(1, 2))
+	// 删除之后，比删除更早的数据也写不进来
+	assert.False(t, idx.Upsert(1, 1, []Field{{Text: "Redis", Weight: 1}}, "a"))
+	hits, total = idx.Search("redis", 0, 10)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, int64(2), hits[0].Id)
+	assert.Equal(t, 2, idx.Len())
+
+	assert.True(t, idx.Upsert(1, 3, []Field{{Text: "Redis", Weight: 1}}, "a"))
+	_, total = idx.Search("redis", 0, 10)
+	assert.Equal(t, 2, total)
+
+	hits, total = idx.Search("不存在", 0, 10)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, hits)
+}
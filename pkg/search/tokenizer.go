@@ -0,0 +1,55 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize 拉丁字母和数字按单词切分并转小写，
+// 中日韩文字没有空格分词，按相邻两个字（bigram）切分，单独一个字就是一个词
+func Tokenize(text string) []string {
+	var (
+		tokens []string
+		word   strings.Builder
+		cjk    []rune
+	)
+	flushWord := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	flushCJK := func() {
+		switch len(cjk) {
+		case 0:
+			return
+		case 1:
+			tokens = append(tokens, string(cjk))
+		default:
+			for i := 0; i+1 < len(cjk); i++ {
+				tokens = append(tokens, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,122 @@
+package textdiff
+
+import "strings"
+
+type Op uint8
+
+const (
+	OpEqual Op = iota
+	OpInsert
+	OpDelete
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpInsert:
+		return "insert"
+	case OpDelete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines 按行比较 a 和 b，返回把 a 变成 b 的逐行差异
+func Lines(a, b string) []Line {
+	return Diff(split(a), split(b))
+}
+
+// maxCells 动态规划表最多的格子数，int32 一共 64MB 左右，超过了就退化成整段替换
+const maxCells = 1 << 24
+
+// Diff 基于最长公共子序列，先去掉公共的前后缀再做动态规划
+func Diff(a, b []string) []Line {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	res := make([]Line, 0, len(a)+len(b))
+	for _, text := range a[:prefix] {
+		res = append(res, Line{Op: OpEqual, Text: text})
+	}
+	res = append(res, lcs(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		res = append(res, Line{Op: OpEqual, Text: text})
+	}
+	return res
+}
+
+func lcs(a, b []string) []Line {
+	n, m := len(a), len(b)
+	if int64(n+1)*int64(m+1) > maxCells {
+		return replace(a, b)
+	}
+	// dp[i][j] 是 a[i:] 和 b[j:] 的最长公共子序列长度
+	dp := make([][]int32, n+1)
+	for i := range dp {
+		dp[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else {
+				dp[i][j] = max(dp[i+1][j], dp[i][j+1])
+			}
+		}
+	}
+
+	res := make([]Line, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			res = append(res, Line{Op: OpEqual, Text: a[i]})
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			res = append(res, Line{Op: OpDelete, Text: a[i]})
+			i++
+		default:
+			res = append(res, Line{Op: OpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		res = append(res, Line{Op: OpDelete, Text: a[i]})
+	}
+	for ; j < m; j++ {
+		res = append(res, Line{Op: OpInsert, Text: b[j]})
+	}
+	return res
+}
+
+// replace 粗粒度的差异，a 全部删掉再全部插入 b
+func replace(a, b []string) []Line {
+	res := make([]Line, 0, len(a)+len(b))
+	for _, text := range a {
+		res = append(res, Line{Op: OpDelete, Text: text})
+	}
+	for _, text := range b {
+		res = append(res, Line{Op: OpInsert, Text: text})
+	}
+	return res
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
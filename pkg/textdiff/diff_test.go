@@ -0,0 +1,89 @@
+package textdiff
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    string
+		b    string
+		want []Line
+	}{
+		{
+			name: "same",
+			a:    "a\nb",
+			b:    "a\nb",
+			want: []Line{{Op: OpEqual, Text: "a"}, {Op: OpEqual, Text: "b"}},
+		},
+		{
+			name: "from empty",
+			a:    "",
+			b:    "a\nb",
+			want: []Line{{Op: OpInsert, Text: "a"}, {Op: OpInsert, Text: "b"}},
+		},
+		{
+			name: "to empty",
+			a:    "a",
+			b:    "",
+			want: []Line{{Op: OpDelete, Text: "a"}},
+		},
+		{
+			name: "modify middle line",
+			a:    "a\nb\nc",
+			b:    "a\nx\nc",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpInsert, Text: "x"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name: "insert and delete",
+			a:    "a\nb\nc\nd",
+			b:    "b\nc\ne\nd",
+			want: []Line{
+				{Op: OpDelete, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+				{Op: OpEqual, Text: "c"},
+				{Op: OpInsert, Text: "e"},
+				{Op: OpEqual, Text: "d"},
+			},
+		},
+		{
+			name: "crlf",
+			a:    "a\r\nb",
+			b:    "a\nb",
+			want: []Line{{Op: OpEqual, Text: "a"}, {Op: OpEqual, Text: "b"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Lines(tc.a, tc.b))
+		})
+	}
+}
+
+func TestDiff_TooLarge(t *testing.T) {
+	a := make([]string, 0, 5000)
+	b := make([]string, 0, 5000)
+	for i := 0; i < 5000; i++ {
+		a = append(a, "a"+strconv.Itoa(i))
+		b = append(b, "b"+strconv.Itoa(i))
+	}
+	a = append([]string{"same"}, a...)
+	res := Diff(a, b)
+	assert.Len(t, res, len(a)+len(b))
+	for i, line := range res {
+		if i < len(a) {
+			assert.Equal(t, Line{Op: OpDelete, Text: a[i]}, line)
+		} else {
+			assert.Equal(t, Line{Op: OpInsert, Text: b[i-len(a)]}, line)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package viperx
+
+import (
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+var (
+	mu          sync.RWMutex
+	subscribers []func(in fsnotify.Event)
+	register    sync.Once
+)
+
+// OnConfigChange viper.OnConfigChange 只保留最后一个回调，
+// 所有关心配置变更的地方都通过这里订阅，配置变了按注册的顺序逐个通知
+func OnConfigChange(fn func(in fsnotify.Event)) {
+	register.Do(func() {
+		viper.OnConfigChange(dispatch)
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	subscribers = append(subscribers, fn)
+}
+
+func dispatch(in fsnotify.Event) {
+	mu.RLock()
+	subs := make([]func(in fsnotify.Event), len(subscribers))
+	copy(subs, subscribers)
+	mu.RUnlock()
+	for _, fn := range subs {
+		fn(in)
+	}
+}
@@ -0,0 +1,20 @@
+package viperx
+
+import (
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnConfigChange(t *testing.T) {
+	var calls []string
+	OnConfigChange(func(in fsnotify.Event) {
+		calls = append(calls, "ranking")
+	})
+	OnConfigChange(func(in fsnotify.Event) {
+		calls = append(calls, "intr")
+	})
+	dispatch(fsnotify.Event{Name: "config/dev.yaml", Op: fsnotify.Write})
+	assert.Equal(t, []string{"ranking", "intr"}, calls)
+}
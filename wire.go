@@ -3,6 +3,8 @@
 package main
 
 import (
+	feedEvents "github.com/misakimei123/redbook/internal/events/feed"
+	searchEvents "github.com/misakimei123/redbook/internal/events/search"
 	"github.com/misakimei123/redbook/internal/repository"
 	"github.com/misakimei123/redbook/internal/repository/cache"
 	"github.com/misakimei123/redbook/internal/repository/cache/code"
@@ -20,14 +22,37 @@ import (
 
 var (
 	rankingSvcSet = wire.NewSet(
+		dao.NewGormRankingDao,
 		cache.NewRedisRankingCache,
 		repository.NewCachedRankingRepository,
-		service.NewArticleRankingService,
+		ioc.InitRankingService,
+	)
+	commentSvcSet = wire.NewSet(
+		dao.NewGormCommentDao,
+		repository.NewDaoCommentRepository,
+		service.NewCommentService,
+		web.NewCommentHandler,
+	)
+	feedSvcSet = wire.NewSet(
+		dao.NewGormFeedDao,
+		repository.NewDaoFeedRepository,
+		service.NewFeedService,
+		feedEvents.NewSyncEventConsumer,
+		web.NewFeedHandler,
+	)
+	jobSvcSet = wire.NewSet(
+		dao.NewGormJobDao,
+		dao.NewGormJobExecutionDao,
+		repository.NewPreemptJobRepository,
+		service.NewCronJobService,
+		web.NewJobHandler,
 	)
 	// interactiveSvcSet = wire.NewSet(
 	// 	dao2.NewInteractiveGormDao,
+	// 	dao2.NewGormCollectionDao,
 	// 	cache2.NewInteractiveRedisCache,
 	// 	repository2.NewCachedInteractiveRepository,
+	// 	repository2.NewCachedCollectionRepository,
 	// 	service2.NewInteractiveService,
 	// )
 )
@@ -37,30 +62,42 @@ func InitWebServer() *App {
 		ioc.InitialLogger, ioc.InitRedis, ioc.InitDB, ioc.InitSMSService,
 		ioc.InitSaramaClient,
 		ioc.InitialProducer,
-		lock.NewRedisLock,
+		lock.NewRedisClient,
 		ioc.InitEtcdClient,
 		dao.NewGormUserDao, dao.NewGormProfileDao,
 		dao.NewArticleGormDao,
 		cache.NewRedisUserCache, code.NewRedisCodeCache,
-		cache.NewArticleRedisCache,
+		ioc.InitArticleCache,
 		repository.NewCacheUserRepository, repository.NewCacheCodeRepository,
 		repository.NewCachedArticleRepository,
 		service.NewUserService, service.NewCodeService,
 		service.NewArticleService,
+		jobSvcSet,
+		ioc.InitScheduler,
+		service.NewArticleSearchService,
+		searchEvents.NewSyncEventConsumer,
+		web.NewSearchHandler,
 		rankingSvcSet,
 		ioc.InitBalancer,
 		ioc.InitRankingJob,
 		ioc.InitJobs,
 		// interactiveSvcSet,
 		ioc.InitIntrClientV1,
+		ioc.InitFollowClient,
+		web.NewFollowHandler,
+		feedSvcSet,
 		// ioc.InitialInteractiveReadEventBatchConsumer,
 		ioc.InitConsumers,
-		ioc.InitialSaramaSyncProducer,
+		ioc.InitArticleProducer,
+		ioc.InitOutboxRelay,
+		ioc.InitOutboxWriter,
 		ijwt.NewRedisJWTHandler,
 		ginCtx.NewLogContextBuilder,
 		web.NewUserHandler,
 		ioc.InitWechatService,
 		web.NewArticleHandler,
+		web.NewCollectionHandler,
+		commentSvcSet,
 		web.NewOAuth2WechatHandler,
 		ioc.InitGinMiddlewares,
 		ioc.InitWebServer,
@@ -8,6 +8,8 @@ package main
 
 import (
 	"github.com/google/wire"
+	"github.com/misakimei123/redbook/internal/events/feed"
+	"github.com/misakimei123/redbook/internal/events/search"
 	"github.com/misakimei123/redbook/internal/repository"
 	"github.com/misakimei123/redbook/internal/repository/cache"
 	"github.com/misakimei123/redbook/internal/repository/cache/code"
@@ -42,32 +44,59 @@ func InitWebServer() *App {
 	smsService := ioc.InitSMSService()
 	codeService := service.NewCodeService(codeRepository, smsService)
 	context := ginadaptor.NewLogContextBuilder(loggerV1)
-	userHandler := web.NewUserHandler(userService, codeService, handler, context)
+	client := ioc.InitEtcdClient()
+	followServiceClient := ioc.InitFollowClient(client)
+	userHandler := web.NewUserHandler(userService, codeService, handler, context, followServiceClient)
 	articleDao := dao.NewArticleGormDao(db)
-	articleCache := cache.NewArticleRedisCache(cmdable)
+	articleCache := ioc.InitArticleCache(cmdable)
 	articleRepository := repository.NewCachedArticleRepository(articleDao, articleCache, userRepository)
-	client := ioc.InitSaramaClient()
-	syncProducer := ioc.InitialProducer(client)
-	producer := ioc.InitialSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer, loggerV1)
-	clientv3Client := ioc.InitEtcdClient()
-	interactiveServiceClient := ioc.InitIntrClientV1(clientv3Client)
+	jobDao := dao.NewGormJobDao(db)
+	jobExecutionDao := dao.NewGormJobExecutionDao(db)
+	jobRepository := repository.NewPreemptJobRepository(jobDao, jobExecutionDao)
+	jobService := service.NewCronJobService(jobRepository, loggerV1)
+	batchWriter := ioc.InitOutboxWriter(db, loggerV1)
+	producer := ioc.InitArticleProducer(batchWriter)
+	articleService := service.NewArticleService(articleRepository, jobService, producer, loggerV1)
+	interactiveServiceClient := ioc.InitIntrClientV1(client)
 	rankingCache := cache.NewRedisRankingCache(cmdable)
-	rankingRepository := repository.NewCachedRankingRepository(rankingCache, loggerV1)
-	rankingService := service.NewArticleRankingService(rankingRepository, articleService, interactiveServiceClient, loggerV1)
+	rankingDao := dao.NewGormRankingDao(db)
+	rankingRepository := repository.NewCachedRankingRepository(rankingCache, rankingDao, loggerV1)
+	rankingService := ioc.InitRankingService(rankingRepository, articleService, interactiveServiceClient, loggerV1)
 	articleHandler := web.NewArticleHandler(articleService, interactiveServiceClient, loggerV1, rankingService)
 	wechatService := ioc.InitWechatService()
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
-	engine := ioc.InitWebServer(v, userHandler, articleHandler, oAuth2WechatHandler)
-	v2 := ioc.InitConsumers()
-	lockLock := lock.NewRedisLock(cmdable)
+	searchService := service.NewArticleSearchService(articleService, loggerV1)
+	searchHandler := web.NewSearchHandler(searchService, loggerV1)
+	commentDao := dao.NewGormCommentDao(db)
+	commentRepository := repository.NewDaoCommentRepository(commentDao)
+	commentService := service.NewCommentService(commentRepository, articleRepository, interactiveServiceClient, loggerV1)
+	commentHandler := web.NewCommentHandler(commentService, loggerV1)
+	feedDao := dao.NewGormFeedDao(db)
+	feedRepository := repository.NewDaoFeedRepository(feedDao)
+	feedService := service.NewFeedService(feedRepository, followServiceClient, loggerV1)
+	followHandler := web.NewFollowHandler(followServiceClient, feedService, loggerV1)
+	feedHandler := web.NewFeedHandler(feedService, loggerV1)
+	collectionHandler := web.NewCollectionHandler(interactiveServiceClient, loggerV1)
+	jobHandler := web.NewJobHandler(jobService, loggerV1)
+	engine := ioc.InitWebServer(v, userHandler, articleHandler, oAuth2WechatHandler, searchHandler, commentHandler, followHandler, feedHandler, collectionHandler, jobHandler)
+	saramaClient := ioc.InitSaramaClient()
+	syncEventConsumer := search.NewSyncEventConsumer(searchService, saramaClient, loggerV1)
+	feedSyncEventConsumer := feed.NewSyncEventConsumer(feedService, saramaClient, loggerV1)
+	v2 := ioc.InitConsumers(syncEventConsumer, feedSyncEventConsumer)
+	lockClient := lock.NewRedisClient(cmdable)
 	loadBalance := ioc.InitBalancer(cmdable, loggerV1)
-	job := ioc.InitRankingJob(rankingService, loggerV1, lockLock, loadBalance)
+	job := ioc.InitRankingJob(rankingService, loggerV1, lockClient, loadBalance)
 	cron := ioc.InitJobs(job, loggerV1)
+	scheduler := ioc.InitScheduler(jobService, articleService, client, loggerV1)
+	syncProducer := ioc.InitialProducer(saramaClient)
+	relay := ioc.InitOutboxRelay(db, syncProducer, lockClient, loggerV1)
 	app := &App{
 		server:    engine,
 		consumers: v2,
 		cron:      cron,
+		scheduler: scheduler,
+		relay:     relay,
+		writer:    batchWriter,
 	}
 	return app
 }
@@ -75,5 +104,8 @@ func InitWebServer() *App {
 // wire.go:
 
 var (
-	rankingSvcSet = wire.NewSet(cache.NewRedisRankingCache, repository.NewCachedRankingRepository, service.NewArticleRankingService)
+	rankingSvcSet = wire.NewSet(dao.NewGormRankingDao, cache.NewRedisRankingCache, repository.NewCachedRankingRepository, ioc.InitRankingService)
+	commentSvcSet = wire.NewSet(dao.NewGormCommentDao, repository.NewDaoCommentRepository, service.NewCommentService, web.NewCommentHandler)
+	feedSvcSet    = wire.NewSet(dao.NewGormFeedDao, repository.NewDaoFeedRepository, service.NewFeedService, feed.NewSyncEventConsumer, web.NewFeedHandler)
+	jobSvcSet     = wire.NewSet(dao.NewGormJobDao, dao.NewGormJobExecutionDao, repository.NewPreemptJobRepository, service.NewCronJobService, web.NewJobHandler)
 )